@@ -28,6 +28,9 @@ func commentsForStruct(v reflect.Value, dr DirResolver) (map[string]string, erro
 	if v.Kind() == reflect.Ptr {
 		elem = v.Elem()
 	}
+	if elem.Type().Name() == "" {
+		return map[string]string{}, nil // anonymous structs have no doc comments
+	}
 	dir, err := dr.PackageDir(elem.Type())
 	if err != nil {
 		return nil, err
@@ -18,7 +18,14 @@
 
 package configschema
 
-import "time"
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	// register the operators documented by TestReadFieldsWithOperators
+	_ "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+	_ "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/noop"
+)
 
 type testPerson struct {
 	Name string
@@ -40,6 +47,10 @@ type testStruct struct {
 	Ignored       string        `mapstructure:"-"`
 }
 
+type testOperatorsStruct struct {
+	Operators []operator.Config `mapstructure:"operators"`
+}
+
 func testDR(root string) DirResolver {
 	return DirResolver{
 		SrcRoot:    root,
@@ -21,8 +21,12 @@ import (
 	"time"
 
 	"github.com/fatih/structtag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
+var operatorConfigType = reflect.TypeOf(operator.Config{})
+
 // Field holds attributes and subfields of a config struct.
 type Field struct {
 	Name    string      `yaml:",omitempty"`
@@ -69,6 +73,9 @@ func refl(f *Field, v reflect.Value, dr DirResolver) error {
 
 	for i := 0; i < v.NumField(); i++ {
 		structField := v.Type().Field(i)
+		if structField.PkgPath != "" {
+			continue // unexported fields are not decoded from the config
+		}
 		tagName, options, err := mapstructure(structField.Tag)
 		if err != nil {
 			fmt.Printf("error parsing mapstructure tag for field %v: %q", structField, err.Error())
@@ -117,7 +124,9 @@ func handleKind(v reflect.Value, f *Field, dr DirResolver) (err error) {
 		}
 	case reflect.Slice:
 		e := v.Type().Elem()
-		if e.Kind() == reflect.Struct {
+		if e == operatorConfigType {
+			err = reflOperators(f, dr)
+		} else if e.Kind() == reflect.Struct {
 			err = refl(f, reflect.New(e), dr)
 		} else if e.Kind() == reflect.Ptr {
 			err = refl(f, reflect.New(e.Elem()), dr)
@@ -146,6 +155,25 @@ func handleKind(v reflect.Value, f *Field, dr DirResolver) (err error) {
 	return
 }
 
+// reflOperators adds a subfield per registered stanza operator type, since the
+// fields of an operator config depend on its type.
+func reflOperators(f *Field, dr DirResolver) error {
+	for _, operatorType := range operator.Types() {
+		newBuilder, _ := operator.Lookup(operatorType)
+		builder := reflect.ValueOf(newBuilder())
+		next := &Field{
+			Name: operatorType,
+			Type: builder.Type().String(),
+			Kind: builder.Kind().String(),
+		}
+		f.Fields = append(f.Fields, next)
+		if err := refl(next, builder, dr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func mapstructure(st reflect.StructTag) (string, []string, error) {
 	tag := string(st)
 	if tag == "" {
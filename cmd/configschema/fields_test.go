@@ -23,6 +23,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 func TestReadFieldsWithDefaults(t *testing.T) {
@@ -154,3 +157,31 @@ func testReadFields(t *testing.T, s testStruct, defaults map[string]interface{})
 		Kind: "string",
 	}, getField(personPtrs.Fields, "name"))
 }
+
+func TestReadFieldsWithOperators(t *testing.T) {
+	root, err := ReadFields(
+		reflect.ValueOf(testOperatorsStruct{}),
+		testDR("../.."),
+	)
+	require.NoError(t, err)
+
+	operators := getField(root.Fields, "operators")
+	assert.Equal(t, "[]operator.Config", operators.Type)
+	assert.Equal(t, "slice", operators.Kind)
+	assert.Equal(t, len(operator.Types()), len(operators.Fields))
+
+	noopOperator := getField(operators.Fields, "noop")
+	assert.Equal(t, "*noop.Config", noopOperator.Type)
+	assert.Equal(t, "ptr", noopOperator.Kind)
+	assert.Equal(t, &Field{
+		Name:    "type",
+		Kind:    "string",
+		Default: "noop",
+	}, getField(noopOperator.Fields, "type"))
+	assert.NotNil(t, getField(noopOperator.Fields, "output"))
+
+	regexParser := getField(operators.Fields, "regex_parser")
+	assert.Equal(t, "*regex.Config", regexParser.Type)
+	assert.NotNil(t, getField(regexParser.Fields, "regex"))
+	assert.NotNil(t, getField(regexParser.Fields, "parse_to"))
+}
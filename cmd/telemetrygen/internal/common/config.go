@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+var (
+	errFormatOTLPAttributes       = fmt.Errorf("value should be of the format key=\"value\"")
+	errDoubleQuotesOTLPAttributes = fmt.Errorf("value should be a string wrapped in double quotes")
+	errClientCertWithoutKey       = errors.New("client certificate and client key must be set together")
+)
+
+const (
+	defaultGRPCEndpoint = "localhost:4317"
+	defaultHTTPEndpoint = "localhost:4318"
+)
+
+type KeyValue map[string]string
+
+var _ pflag.Value = (*KeyValue)(nil)
+
+func (v *KeyValue) String() string {
+	return ""
+}
+
+func (v *KeyValue) Set(s string) error {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 {
+		return errFormatOTLPAttributes
+	}
+	val := kv[1]
+	if len(val) < 2 || !strings.HasPrefix(val, "\"") || !strings.HasSuffix(val, "\"") {
+		return errDoubleQuotesOTLPAttributes
+	}
+
+	(*v)[kv[0]] = val[1 : len(val)-1]
+	return nil
+}
+
+func (v *KeyValue) Type() string {
+	return "map[string]string"
+}
+
+// Config describes the settings shared by all signals.
+type Config struct {
+	WorkerCount   int
+	Rate          int64
+	TotalDuration time.Duration
+	ServiceName   string
+
+	// OTLP config
+	CustomEndpoint      string
+	Insecure            bool
+	UseHTTP             bool
+	HTTPPath            string
+	Headers             KeyValue
+	ResourceAttributes  KeyValue
+	TelemetryAttributes KeyValue
+
+	// TLS config
+	CaFile             string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+// Endpoint returns the target of the exporter, defaulting
+// to the standard OTLP port of the selected protocol.
+func (c *Config) Endpoint() string {
+	if c.CustomEndpoint != "" {
+		return c.CustomEndpoint
+	}
+	if c.UseHTTP {
+		return defaultHTTPEndpoint
+	}
+	return defaultGRPCEndpoint
+}
+
+// CommonFlags registers the flags shared by all signals.
+func (c *Config) CommonFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.WorkerCount, "workers", 1, "Number of workers (goroutines) to run")
+	fs.Int64Var(&c.Rate, "rate", 0, "Approximately how many items per second each worker should generate. Zero means no throttling.")
+	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the test")
+	fs.StringVar(&c.ServiceName, "service", "telemetrygen", "Service name to use")
+
+	fs.StringVar(&c.CustomEndpoint, "otlp-endpoint", "", "Destination endpoint for exporting telemetry, defaults to localhost:4317 for gRPC and localhost:4318 for HTTP")
+	fs.BoolVar(&c.Insecure, "otlp-insecure", false, "Whether to disable client transport security for the exporter's gRPC or HTTP connection")
+	fs.BoolVar(&c.UseHTTP, "otlp-http", false, "Whether to use HTTP exporter rather than a gRPC one")
+
+	fs.StringVar(&c.CaFile, "ca-cert", "", "Trusted Certificate Authority to verify the server certificate")
+	fs.StringVar(&c.ClientCertFile, "client-cert", "", "Client certificate file, used along with client-key to enable mutual TLS")
+	fs.StringVar(&c.ClientKeyFile, "client-key", "", "Client private key file, used along with client-cert to enable mutual TLS")
+	fs.BoolVar(&c.InsecureSkipVerify, "insecure-skip-verify", false, "Whether to skip the verification of the server certificate")
+
+	// custom headers
+	c.Headers = make(map[string]string)
+	fs.Var(&c.Headers, "otlp-header", "Custom header to be passed along with each OTLP request. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple headers (e.g --otlp-header key1=\"value1\" --otlp-header key2=\"value2\")")
+
+	// custom resource attributes
+	c.ResourceAttributes = make(map[string]string)
+	fs.Var(&c.ResourceAttributes, "otlp-attributes", "Custom resource attributes to use. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple attributes (e.g --otlp-attributes key1=\"value1\" --otlp-attributes key2=\"value2\")")
+
+	// custom telemetry attributes
+	c.TelemetryAttributes = make(map[string]string)
+	fs.Var(&c.TelemetryAttributes, "telemetry-attributes", "Custom attributes set on each span, data point or log record. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple attributes (e.g --telemetry-attributes key1=\"value1\" --telemetry-attributes key2=\"value2\")")
+}
+
+// Limit returns the rate each worker is throttled to.
+func (c *Config) Limit(logger *zap.Logger) rate.Limit {
+	if c.Rate == 0 {
+		logger.Info("generation isn't being throttled")
+		return rate.Inf
+	}
+	limit := rate.Limit(c.Rate)
+	logger.Info("generation is limited", zap.Float64("per-second", float64(limit)))
+	return limit
+}
+
+// TLSConfig returns the client TLS configuration, nil when transport security is disabled.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	if c.Insecure {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify, // #nosec G402 explicitly requested by the user
+		MinVersion:         tls.VersionTLS12,
+	}
+	if c.CaFile != "" {
+		pem, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA certificate %q", c.CaFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return nil, errClientCertWithoutKey
+	}
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyValueSet(t *testing.T) {
+	tests := []struct {
+		flag     string
+		expected KeyValue
+		err      error
+	}{
+		{
+			flag:     "key=\"value\"",
+			expected: KeyValue(map[string]string{"key": "value"}),
+		},
+		{
+			flag:     "key=\"\"",
+			expected: KeyValue(map[string]string{"key": ""}),
+		},
+		{
+			flag: "key=\"",
+			err:  errDoubleQuotesOTLPAttributes,
+		},
+		{
+			flag: "key=value",
+			err:  errDoubleQuotesOTLPAttributes,
+		},
+		{
+			flag: "key",
+			err:  errFormatOTLPAttributes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			kv := KeyValue(make(map[string]string))
+			err := kv.Set(tt.flag)
+			if err != nil || tt.err != nil {
+				assert.Equal(t, err, tt.err)
+			} else {
+				assert.Equal(t, tt.expected, kv)
+			}
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected string
+	}{
+		{
+			name:     "default gRPC",
+			expected: "localhost:4317",
+		},
+		{
+			name:     "default HTTP",
+			cfg:      Config{UseHTTP: true},
+			expected: "localhost:4318",
+		},
+		{
+			name:     "custom",
+			cfg:      Config{CustomEndpoint: "collector:1234", UseHTTP: true},
+			expected: "collector:1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.cfg.Endpoint())
+		})
+	}
+}
+
+func TestHTTPURL(t *testing.T) {
+	cfg := Config{UseHTTP: true, HTTPPath: "/v1/logs"}
+	assert.Equal(t, "https://localhost:4318/v1/logs", cfg.HTTPURL())
+
+	cfg.Insecure = true
+	assert.Equal(t, "http://localhost:4318/v1/logs", cfg.HTTPURL())
+}
+
+func TestTLSConfig(t *testing.T) {
+	cfg := Config{Insecure: true}
+	tlsCfg, err := cfg.TLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg, "transport security must be disabled when insecure")
+
+	cfg = Config{ClientCertFile: "cert.pem"}
+	_, err = cfg.TLSConfig()
+	assert.ErrorIs(t, err, errClientCertWithoutKey)
+
+	cfg = Config{CaFile: "does-not-exist.pem"}
+	_, err = cfg.TLSConfig()
+	assert.Error(t, err)
+
+	cfg = Config{InsecureSkipVerify: true}
+	tlsCfg, err = cfg.TLSConfig()
+	require.NoError(t, err)
+	assert.True(t, tlsCfg.InsecureSkipVerify)
+}
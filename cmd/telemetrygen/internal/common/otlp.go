@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCConn dials the endpoint with the configured transport security.
+func (c *Config) GRPCConn() (*grpc.ClientConn, error) {
+	tlsCfg, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil {
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	return grpc.Dial(c.Endpoint(), grpc.WithTransportCredentials(creds))
+}
+
+// GRPCContext attaches the custom headers to the outgoing requests.
+func (c *Config) GRPCContext(ctx context.Context) context.Context {
+	if len(c.Headers) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.New(c.Headers))
+}
+
+// HTTPClient returns a client using the configured transport security.
+func (c *Config) HTTPClient() (*http.Client, error) {
+	tlsCfg, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	return &http.Client{Transport: transport}, nil
+}
+
+// HTTPURL returns the url OTLP HTTP requests are sent to.
+func (c *Config) HTTPURL() string {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, c.Endpoint(), strings.TrimPrefix(c.HTTPPath, "/"))
+}
+
+// PostProto sends a protobuf encoded OTLP request over HTTP.
+func (c *Config) PostProto(ctx context.Context, client *http.Client, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.HTTPURL(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to export to %s: unexpected status %s", req.URL, resp.Status)
+	}
+	return nil
+}
+
+// SetResourceAttributes sets the service name and the custom resource attributes,
+// which may override the service name.
+func (c *Config) SetResourceAttributes(attrs pcommon.Map) {
+	attrs.UpsertString("service.name", c.ServiceName)
+	for k, v := range c.ResourceAttributes {
+		attrs.UpsertString(k, v)
+	}
+}
+
+// SetTelemetryAttributes sets the custom attributes of spans, data points and log records.
+func (c *Config) SetTelemetryAttributes(attrs pcommon.Map) {
+	for k, v := range c.TelemetryAttributes {
+		attrs.UpsertString(k, v)
+	}
+}
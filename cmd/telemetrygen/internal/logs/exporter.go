@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"google.golang.org/grpc"
+)
+
+type exporter interface {
+	export(plog.Logs) error
+	shutdown() error
+}
+
+func newExporter(cfg *Config) (exporter, error) {
+	if cfg.UseHTTP {
+		client, err := cfg.HTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		return &httpExporter{cfg: cfg, client: client}, nil
+	}
+
+	conn, err := cfg.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	return &gRPCExporter{cfg: cfg, conn: conn, client: plogotlp.NewClient(conn)}, nil
+}
+
+type gRPCExporter struct {
+	cfg    *Config
+	conn   *grpc.ClientConn
+	client plogotlp.Client
+}
+
+func (e *gRPCExporter) export(logs plog.Logs) error {
+	req := plogotlp.NewRequestFromLogs(logs)
+	_, err := e.client.Export(e.cfg.GRPCContext(context.Background()), req)
+	return err
+}
+
+func (e *gRPCExporter) shutdown() error {
+	return e.conn.Close()
+}
+
+type httpExporter struct {
+	cfg    *Config
+	client *http.Client
+}
+
+func (e *httpExporter) export(logs plog.Logs) error {
+	body, err := plogotlp.NewRequestFromLogs(logs).MarshalProto()
+	if err != nil {
+		return err
+	}
+	return e.cfg.PostProto(context.Background(), e.client, body)
+}
+
+func (e *httpExporter) shutdown() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu   sync.Mutex
+	logs []plog.Logs
+}
+
+func (m *mockExporter) export(logs plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, logs)
+	return nil
+}
+
+func (m *mockExporter) shutdown() error {
+	return nil
+}
+
+func TestFixedNumberOfLogs(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:        2,
+			ServiceName:        "telemetrygen",
+			ResourceAttributes: common.KeyValue{"service.name": "custom"},
+		},
+		NumLogs:      3,
+		Body:         "hello",
+		SeverityText: "Info",
+	}
+	exp := &mockExporter{}
+
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	require.Len(t, exp.logs, 6)
+	for _, ld := range exp.logs {
+		rl := ld.ResourceLogs().At(0)
+		assert.Equal(t, map[string]interface{}{"service.name": "custom"}, rl.Resource().Attributes().AsRaw(), "resource attributes must override the service name")
+		lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, "hello", lr.Body().StringVal())
+		assert.Equal(t, "Info", lr.SeverityText())
+	}
+}
+
+func TestNoLogsNorDuration(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+	}
+	assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+}
+
+type mockServer struct {
+	mu       sync.Mutex
+	requests []plogotlp.Request
+}
+
+func (s *mockServer) Export(_ context.Context, req plogotlp.Request) (plogotlp.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	return plogotlp.NewResponse(), nil
+}
+
+func TestGRPCExporter(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	mock := &mockServer{}
+	plogotlp.RegisterServer(srv, mock)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:    1,
+			CustomEndpoint: ln.Addr().String(),
+			Insecure:       true,
+		},
+		NumLogs: 2,
+	}
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+	require.NoError(t, exp.shutdown())
+
+	require.Len(t, mock.requests, 2)
+	assert.Equal(t, 1, mock.requests[0].Logs().LogRecordCount())
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []plogotlp.Request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := plogotlp.NewRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:    1,
+			CustomEndpoint: srv.Listener.Addr().String(),
+			Insecure:       true,
+			UseHTTP:        true,
+			HTTPPath:       "/v1/logs",
+		},
+		NumLogs: 2,
+	}
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+	require.NoError(t, exp.shutdown())
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, 1, requests[1].Logs().LogRecordCount())
+}
+
+func TestHTTPExporterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		Config: common.Config{
+			CustomEndpoint: srv.Listener.Addr().String(),
+			Insecure:       true,
+			UseHTTP:        true,
+			HTTPPath:       "/v1/logs",
+		},
+	}
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	assert.Error(t, exp.export(plog.NewLogs()))
+}
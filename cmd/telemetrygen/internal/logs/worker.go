@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+type worker struct {
+	cfg            *Config
+	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numLogs        int             // how many logs the worker has to generate (only when duration==0)
+	limitPerSecond rate.Limit      // how many logs per second to generate
+	wg             *sync.WaitGroup // notify when done
+	logger         *zap.Logger
+	index          int // worker index
+}
+
+func (w worker) simulateLogs(exp exporter) {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	var i int
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		if err := exp.export(w.newLogs()); err != nil {
+			w.logger.Error("exporter failed", zap.Error(err))
+		}
+
+		i++
+		if w.numLogs != 0 {
+			if i >= w.numLogs {
+				break
+			}
+		}
+	}
+	w.logger.Info("logs generated", zap.Int("logs", i))
+	w.wg.Done()
+}
+
+func (w worker) newLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	w.cfg.SetResourceAttributes(rl.Resource().Attributes())
+
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("telemetrygen")
+	lr := sl.LogRecords().AppendEmpty()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	lr.SetTimestamp(now)
+	lr.SetObservedTimestamp(now)
+	lr.Body().SetStringVal(w.cfg.Body)
+	lr.SetSeverityText(w.cfg.SeverityText)
+	lr.SetSeverityNumber(plog.SeverityNumberINFO)
+	lr.Attributes().InsertInt("worker", int64(w.index))
+	w.cfg.SetTelemetryAttributes(lr.Attributes())
+	return ld
+}
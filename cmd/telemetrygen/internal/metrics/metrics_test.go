@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu      sync.Mutex
+	metrics []pmetric.Metrics
+}
+
+func (m *mockExporter) export(metrics pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, metrics)
+	return nil
+}
+
+func (m *mockExporter) shutdown() error {
+	return nil
+}
+
+func TestFixedNumberOfMetrics(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:         2,
+			ServiceName:         "telemetrygen",
+			TelemetryAttributes: common.KeyValue{"load.test": "true"},
+		},
+		NumMetrics: 3,
+		MetricType: metricTypeSum,
+	}
+	exp := &mockExporter{}
+
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	require.Len(t, exp.metrics, 6)
+	for _, md := range exp.metrics {
+		rm := md.ResourceMetrics().At(0)
+		assert.Equal(t, map[string]interface{}{"service.name": "telemetrygen"}, rm.Resource().Attributes().AsRaw())
+		metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, pmetric.MetricDataTypeSum, metric.DataType())
+		attr, ok := metric.Sum().DataPoints().At(0).Attributes().Get("load.test")
+		assert.True(t, ok)
+		assert.Equal(t, "true", attr.StringVal())
+	}
+}
+
+func TestRateOfMetrics(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		MetricType: metricTypeGauge,
+	}
+	exp := &mockExporter{}
+
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// the minimum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, len(exp.metrics) >= 3, "there should have been more than 3 metrics, had %d", len(exp.metrics))
+	// the maximum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, len(exp.metrics) <= 10, "there should have been less than 10 metrics, had %d", len(exp.metrics))
+}
+
+func TestInvalidMetricType(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumMetrics: 1,
+		MetricType: "Histogram",
+	}
+	assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+}
+
+type mockServer struct {
+	mu       sync.Mutex
+	requests []pmetricotlp.Request
+	headers  []metadata.MD
+}
+
+func (s *mockServer) Export(ctx context.Context, req pmetricotlp.Request) (pmetricotlp.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.requests = append(s.requests, req)
+	s.headers = append(s.headers, md)
+	return pmetricotlp.NewResponse(), nil
+}
+
+func TestGRPCExporter(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	mock := &mockServer{}
+	pmetricotlp.RegisterServer(srv, mock)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:    1,
+			CustomEndpoint: ln.Addr().String(),
+			Insecure:       true,
+			Headers:        common.KeyValue{"x-tenant": "load"},
+		},
+		NumMetrics: 2,
+		MetricType: metricTypeGauge,
+	}
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+	require.NoError(t, exp.shutdown())
+
+	require.Len(t, mock.requests, 2)
+	assert.Equal(t, 1, mock.requests[0].Metrics().MetricCount())
+	assert.Equal(t, []string{"load"}, mock.headers[0].Get("x-tenant"))
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []pmetricotlp.Request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "load", r.Header.Get("x-tenant"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := pmetricotlp.NewRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:    1,
+			CustomEndpoint: srv.Listener.Addr().String(),
+			Insecure:       true,
+			UseHTTP:        true,
+			HTTPPath:       "/v1/metrics",
+			Headers:        common.KeyValue{"x-tenant": "load"},
+		},
+		NumMetrics: 2,
+		MetricType: metricTypeSum,
+	}
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+	require.NoError(t, exp.shutdown())
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, 1, requests[1].Metrics().MetricCount())
+}
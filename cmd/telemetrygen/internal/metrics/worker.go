@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+type worker struct {
+	cfg            *Config
+	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numMetrics     int             // how many metrics the worker has to generate (only when duration==0)
+	limitPerSecond rate.Limit      // how many metrics per second to generate
+	wg             *sync.WaitGroup // notify when done
+	logger         *zap.Logger
+	index          int // worker index
+}
+
+func (w worker) simulateMetrics(exp exporter) {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	start := pcommon.NewTimestampFromTime(time.Now())
+	var i int64
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		if err := exp.export(w.newMetrics(start, i)); err != nil {
+			w.logger.Error("exporter failed", zap.Error(err))
+		}
+
+		i++
+		if w.numMetrics != 0 {
+			if i >= int64(w.numMetrics) {
+				break
+			}
+		}
+	}
+	w.logger.Info("metrics generated", zap.Int64("metrics", i))
+	w.wg.Done()
+}
+
+func (w worker) newMetrics(start pcommon.Timestamp, value int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	w.cfg.SetResourceAttributes(rm.Resource().Attributes())
+
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("telemetrygen")
+	metric := sm.Metrics().AppendEmpty()
+	metric.SetName("gen")
+
+	var dp pmetric.NumberDataPoint
+	switch w.cfg.MetricType {
+	case metricTypeSum:
+		metric.SetDataType(pmetric.MetricDataTypeSum)
+		metric.Sum().SetIsMonotonic(true)
+		metric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		dp = metric.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+	default:
+		metric.SetDataType(pmetric.MetricDataTypeGauge)
+		dp = metric.Gauge().DataPoints().AppendEmpty()
+	}
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	dp.SetIntVal(value)
+	dp.Attributes().InsertInt("worker", int64(w.index))
+	w.cfg.SetTelemetryAttributes(dp.Attributes())
+	return md
+}
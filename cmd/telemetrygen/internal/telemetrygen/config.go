@@ -13,20 +13,30 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package telemetrygen // import "github.com/open-telemetry/opentelemetry-collector-contrib/telemetrygen/internal/telemetrygen"
+package telemetrygen // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/telemetrygen"
 
 import (
-	"errors"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+)
+
+var (
+	tracesCfg  *traces.Config
+	metricsCfg *metrics.Config
+	logsCfg    *logs.Config
 )
 
 // rootCmd is the root command on which will be run children commands
 var rootCmd = &cobra.Command{
 	Use:     "telemetrygen",
-	Short:   "Telemetrygen simulates a client generating traces and metrics",
-	Example: "telemetrygen metrics\ntelemetrygen traces",
+	Short:   "Telemetrygen simulates a client generating traces, metrics and logs",
+	Example: "telemetrygen traces\ntelemetrygen metrics\ntelemetrygen logs",
 }
 
 // tracesCmd is the command responsible for sending traces
@@ -35,8 +45,7 @@ var tracesCmd = &cobra.Command{
 	Short:   "Simulates a client generating traces",
 	Example: "telemetrygen traces",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := errors.New("[WIP] The 'traces' command is a work in progress, come back later")
-		return err
+		return traces.Start(tracesCfg)
 	},
 }
 
@@ -46,25 +55,42 @@ var metricsCmd = &cobra.Command{
 	Short:   "Simulates a client generating metrics",
 	Example: "telemetrygen metrics",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := errors.New("[WIP] The 'metrics' command is a work in progress, come back later")
-		return err
+		return metrics.Start(metricsCfg)
+	},
+}
+
+// logsCmd is the command responsible for sending logs
+var logsCmd = &cobra.Command{
+	Use:     "logs",
+	Short:   "Simulates a client generating logs",
+	Example: "telemetrygen logs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return logs.Start(logsCfg)
 	},
 }
 
 func init() {
-	rootCmd.AddCommand(tracesCmd, metricsCmd)
+	rootCmd.AddCommand(tracesCmd, metricsCmd, logsCmd)
+
+	tracesCfg = new(traces.Config)
+	tracesCfg.Flags(tracesCmd.Flags())
+
+	metricsCfg = new(metrics.Config)
+	metricsCfg.Flags(metricsCmd.Flags())
+
+	logsCfg = new(logs.Config)
+	logsCfg.Flags(logsCmd.Flags())
 
 	// Disabling completion command for end user
 	// https://github.com/spf13/cobra/blob/master/shell_completions.md
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
-
+	rootCmd.SilenceUsage = true
 }
 
 // Execute tries to run the input command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		// TODO: Uncomment the line below when using Run instead of RunE in the xxxCmd functions
-		// fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
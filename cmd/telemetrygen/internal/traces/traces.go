@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Start starts the trace telemetry generator.
+func Start(cfg *Config) error {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		return fmt.Errorf("failed to obtain logger: %w", err)
+	}
+
+	exp, err := newExporter(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if tempError := exp.Shutdown(context.Background()); tempError != nil {
+			logger.Error("failed to stop the exporter", zap.Error(tempError))
+		}
+	}()
+
+	ssp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithBatchTimeout(time.Second))
+	defer func() {
+		logger.Info("stop the batch span processor")
+		if tempError := ssp.Shutdown(context.Background()); tempError != nil {
+			logger.Error("failed to stop the batch span processor", zap.Error(tempError))
+		}
+	}()
+
+	// may be overridden by `--otlp-attributes service.name="foo"`
+	attributes := []attribute.KeyValue{semconv.ServiceNameKey.String(cfg.ServiceName)}
+	for k, v := range cfg.ResourceAttributes {
+		attributes = append(attributes, attribute.String(k, v))
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
+	)
+	tracerProvider.RegisterSpanProcessor(ssp)
+	otel.SetTracerProvider(tracerProvider)
+
+	return Run(cfg, logger)
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) (*otlptrace.Exporter, error) {
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.UseHTTP {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.Endpoint()),
+			otlptracehttp.WithURLPath(cfg.HTTPPath),
+		}
+		if tlsCfg == nil {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		logger.Info("starting HTTP exporter")
+		return otlptracehttp.New(context.Background(), opts...)
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint()),
+		otlptracegrpc.WithDialOption(grpc.WithBlock()),
+	}
+	if tlsCfg == nil {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	logger.Info("starting gRPC exporter")
+	return otlptracegrpc.New(context.Background(), opts...)
+}
+
+// Run executes the test scenario.
+func Run(c *Config, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumTraces = 0
+	} else if c.NumTraces <= 0 {
+		return fmt.Errorf("either `traces` or `duration` must be greater than 0")
+	}
+
+	limit := c.Limit(logger)
+
+	var attributes []attribute.KeyValue
+	for k, v := range c.TelemetryAttributes {
+		attributes = append(attributes, attribute.String(k, v))
+	}
+
+	wg := sync.WaitGroup{}
+	running := atomic.NewBool(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			numTraces:        c.NumTraces,
+			propagateContext: c.PropagateContext,
+			limitPerSecond:   limit,
+			totalDuration:    c.TotalDuration,
+			attributes:       attributes,
+			running:          running,
+			wg:               &wg,
+			logger:           logger.With(zap.Int("worker", i)),
+		}
+
+		go w.simulateTraces()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
@@ -37,6 +37,13 @@ type Config struct {
 	DSN string `mapstructure:"dsn"`
 	// LogsTableName is the table name for logs. default is `otel_logs`.
 	LogsTableName string `mapstructure:"logs_table_name"`
+	// TracesTableName is the table name for traces. default is `otel_traces`.
+	TracesTableName string `mapstructure:"traces_table_name"`
+	// MetricsTableName is the prefix of the tables for metrics. default is `otel_metrics`.
+	// Each metric type is stored in its own table, e.g. `otel_metrics_gauge`.
+	MetricsTableName string `mapstructure:"metrics_table_name"`
+	// CreateSchema controls whether the tables are created on start. default is true.
+	CreateSchema bool `mapstructure:"create_schema"`
 	// TTLDays is The data time-to-live in days, 0 means no ttl.
 	TTLDays uint `mapstructure:"ttl_days"`
 }
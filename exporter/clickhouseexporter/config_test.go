@@ -50,6 +50,9 @@ func TestLoadConfig(t *testing.T) {
 		DSN:              "tcp://127.0.0.1:9000?database=default",
 		TTLDays:          3,
 		LogsTableName:    "otel_logs",
+		TracesTableName:  "traces",
+		MetricsTableName: "metrics",
+		CreateSchema:     true,
 		TimeoutSettings: exporterhelper.TimeoutSettings{
 			Timeout: 5 * time.Second,
 		},
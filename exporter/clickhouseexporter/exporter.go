@@ -45,6 +45,12 @@ func newExporter(logger *zap.Logger, cfg *Config) (*clickhouseExporter, error) {
 		return nil, err
 	}
 
+	if cfg.CreateSchema {
+		if err = createLogsTable(cfg, client); err != nil {
+			return nil, err
+		}
+	}
+
 	insertLogsSQL := renderInsertLogsSQL(cfg)
 
 	return &clickhouseExporter{
@@ -189,17 +195,27 @@ func newClickhouseClient(cfg *Config) (*sql.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open:%w", err)
 	}
-	// create table
-	query := fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, "")
-	if cfg.TTLDays > 0 {
-		query = fmt.Sprintf(createLogsTableSQL,
-			cfg.LogsTableName,
-			fmt.Sprintf(`TTL Timestamp + INTERVAL %d DAY`, cfg.TTLDays))
+	return db, nil
+}
+
+func createLogsTable(cfg *Config, db *sql.DB) error {
+	return createTable(db, createLogsTableSQL, cfg.LogsTableName, renderTTL(cfg, "Timestamp"))
+}
+
+// createTable creates the table from the template, which accepts the table name and the TTL clause.
+func createTable(db *sql.DB, template string, tableName string, ttl string) error {
+	if _, err := db.Exec(fmt.Sprintf(template, tableName, ttl)); err != nil {
+		return fmt.Errorf("exec create table sql: %w", err)
 	}
-	if _, err := db.Exec(query); err != nil {
-		return nil, fmt.Errorf("exec create table sql: %w", err)
+	return nil
+}
+
+// renderTTL returns the TTL clause based on the given DateTime column, or nothing if TTL is disabled.
+func renderTTL(cfg *Config, timeField string) string {
+	if cfg.TTLDays == 0 {
+		return ""
 	}
-	return db, nil
+	return fmt.Sprintf(`TTL toDateTime(%s) + INTERVAL %d DAY`, timeField, cfg.TTLDays)
 }
 
 func renderInsertLogsSQL(cfg *Config) string {
@@ -0,0 +1,398 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// metricsTable describes the table storing the data points of one metric type.
+type metricsTable struct {
+	dataType pmetric.MetricDataType
+	suffix   string
+	columns  string
+	// insertColumns are the inserted columns following metricsCommonColumns.
+	insertColumns []string
+	insertSQL     string
+}
+
+type metricsExporter struct {
+	client *sql.DB
+	tables []*metricsTable
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := newMetricsTables(cfg)
+	if cfg.CreateSchema {
+		if err = createMetricsTables(cfg, client, tables); err != nil {
+			return nil, err
+		}
+	}
+
+	return &metricsExporter{
+		client: client,
+		tables: tables,
+		logger: logger,
+		cfg:    cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	start := time.Now()
+	rows := metricsToRows(md)
+	// clickhouse-go only supports a single prepared insert per transaction,
+	// so every metric type is written in its own transaction.
+	for _, table := range e.tables {
+		tableRows := rows[table.dataType]
+		if len(tableRows) == 0 {
+			continue
+		}
+		err := doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+			statement, err := tx.PrepareContext(ctx, table.insertSQL)
+			if err != nil {
+				return fmt.Errorf("PrepareContext:%w", err)
+			}
+			defer func() {
+				_ = statement.Close()
+			}()
+			for _, row := range tableRows {
+				if _, err = statement.ExecContext(ctx, row...); err != nil {
+					return fmt.Errorf("ExecContext:%w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	duration := time.Since(start)
+	e.logger.Debug("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	return nil
+}
+
+// metricsToRows converts the data points to the insert arguments of their metric type table.
+func metricsToRows(md pmetric.Metrics) map[pmetric.MetricDataType][][]interface{} {
+	rows := make(map[pmetric.MetricDataType][][]interface{})
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		metrics := md.ResourceMetrics().At(i)
+		resourceKeys, resourceValues := attributesToSlice(metrics.Resource().Attributes())
+		for j := 0; j < metrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := metrics.ScopeMetrics().At(j)
+			scope := scopeMetrics.Scope()
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				metric := scopeMetrics.Metrics().At(k)
+				common := func(attributes pcommon.Map, startTime, timestamp pcommon.Timestamp, flags pmetric.MetricDataPointFlags) []interface{} {
+					attrKeys, attrValues := attributesToSlice(attributes)
+					return []interface{}{
+						resourceKeys,
+						resourceValues,
+						metrics.SchemaUrl(),
+						scope.Name(),
+						scope.Version(),
+						metric.Name(),
+						metric.Description(),
+						metric.Unit(),
+						attrKeys,
+						attrValues,
+						startTime.AsTime(),
+						timestamp.AsTime(),
+						uint32(flags),
+					}
+				}
+				dataType := metric.DataType()
+				switch dataType {
+				case pmetric.MetricDataTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						rows[dataType] = append(rows[dataType], append(row, numberValue(dp)))
+					}
+				case pmetric.MetricDataTypeSum:
+					sum := metric.Sum()
+					dps := sum.DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						rows[dataType] = append(rows[dataType], append(row,
+							numberValue(dp),
+							int32(sum.AggregationTemporality()),
+							sum.IsMonotonic(),
+						))
+					}
+				case pmetric.MetricDataTypeHistogram:
+					histogram := metric.Histogram()
+					dps := histogram.DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						rows[dataType] = append(rows[dataType], append(row,
+							dp.Count(),
+							optionalValue(dp.HasSum(), dp.Sum()),
+							nonNilUint64s(dp.MBucketCounts()),
+							nonNilFloat64s(dp.MExplicitBounds()),
+							optionalValue(dp.HasMin(), dp.Min()),
+							optionalValue(dp.HasMax(), dp.Max()),
+							int32(histogram.AggregationTemporality()),
+						))
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					histogram := metric.ExponentialHistogram()
+					dps := histogram.DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						rows[dataType] = append(rows[dataType], append(row,
+							dp.Count(),
+							optionalValue(dp.HasSum(), dp.Sum()),
+							dp.Scale(),
+							dp.ZeroCount(),
+							dp.Positive().Offset(),
+							nonNilUint64s(dp.Positive().MBucketCounts()),
+							dp.Negative().Offset(),
+							nonNilUint64s(dp.Negative().MBucketCounts()),
+							optionalValue(dp.HasMin(), dp.Min()),
+							optionalValue(dp.HasMax(), dp.Max()),
+							int32(histogram.AggregationTemporality()),
+						))
+					}
+				case pmetric.MetricDataTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						quantiles := make([]float64, 0, dp.QuantileValues().Len())
+						values := make([]float64, 0, dp.QuantileValues().Len())
+						for m := 0; m < dp.QuantileValues().Len(); m++ {
+							quantiles = append(quantiles, dp.QuantileValues().At(m).Quantile())
+							values = append(values, dp.QuantileValues().At(m).Value())
+						}
+						rows[dataType] = append(rows[dataType], append(row,
+							dp.Count(),
+							dp.Sum(),
+							quantiles,
+							values,
+						))
+					}
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+// optionalValue returns nil for the Nullable columns when the value is not set.
+func optionalValue(ok bool, v float64) interface{} {
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func nonNilUint64s(v []uint64) []uint64 {
+	if v == nil {
+		return []uint64{}
+	}
+	return v
+}
+
+func nonNilFloat64s(v []float64) []float64 {
+	if v == nil {
+		return []float64{}
+	}
+	return v
+}
+
+const (
+	// language=ClickHouse SQL
+	createMetricsTableSQL = `
+CREATE TABLE IF NOT EXISTS %%s (
+     ResourceAttributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     ResourceSchemaUrl String CODEC(ZSTD(1)),
+     ScopeName String CODEC(ZSTD(1)),
+     ScopeVersion String CODEC(ZSTD(1)),
+     MetricName LowCardinality(String) CODEC(ZSTD(1)),
+     MetricDescription String CODEC(ZSTD(1)),
+     MetricUnit String CODEC(ZSTD(1)),
+     Attributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),%s
+     INDEX idx_res_attr_keys ResourceAttributes.Key TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_keys Attributes.Key TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (MetricName, toUnixTimestamp64Nano(TimeUnix));
+`
+	// language=ClickHouse SQL
+	gaugeColumnsSQL = `
+     Value Float64 CODEC(ZSTD(1)),`
+	// language=ClickHouse SQL
+	sumColumnsSQL = `
+     Value Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),
+     IsMonotonic UInt8 CODEC(Delta, ZSTD(1)),`
+	// language=ClickHouse SQL
+	histogramColumnsSQL = `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Nullable(Float64) CODEC(ZSTD(1)),
+     BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     ExplicitBounds Array(Float64) CODEC(ZSTD(1)),
+     Min Nullable(Float64) CODEC(ZSTD(1)),
+     Max Nullable(Float64) CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`
+	// language=ClickHouse SQL
+	exponentialHistogramColumnsSQL = `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Nullable(Float64) CODEC(ZSTD(1)),
+     Scale Int32 CODEC(ZSTD(1)),
+     ZeroCount UInt64 CODEC(ZSTD(1)),
+     PositiveOffset Int32 CODEC(ZSTD(1)),
+     PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     NegativeOffset Int32 CODEC(ZSTD(1)),
+     NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     Min Nullable(Float64) CODEC(ZSTD(1)),
+     Max Nullable(Float64) CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`
+	// language=ClickHouse SQL
+	summaryColumnsSQL = `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     ValueAtQuantiles Nested
+     (
+         Quantile Float64,
+         Value Float64
+     ) CODEC(ZSTD(1)),`
+)
+
+// metricsCommonColumns are the inserted columns shared by all metric types, in the order of metricsToRows.
+var metricsCommonColumns = []string{
+	"ResourceAttributes.Key",
+	"ResourceAttributes.Value",
+	"ResourceSchemaUrl",
+	"ScopeName",
+	"ScopeVersion",
+	"MetricName",
+	"MetricDescription",
+	"MetricUnit",
+	"Attributes.Key",
+	"Attributes.Value",
+	"StartTimeUnix",
+	"TimeUnix",
+	"Flags",
+}
+
+func newMetricsTables(cfg *Config) []*metricsTable {
+	tables := []*metricsTable{
+		{
+			dataType:      pmetric.MetricDataTypeGauge,
+			suffix:        "_gauge",
+			columns:       gaugeColumnsSQL,
+			insertColumns: []string{"Value"},
+		},
+		{
+			dataType:      pmetric.MetricDataTypeSum,
+			suffix:        "_sum",
+			columns:       sumColumnsSQL,
+			insertColumns: []string{"Value", "AggTemp", "IsMonotonic"},
+		},
+		{
+			dataType:      pmetric.MetricDataTypeHistogram,
+			suffix:        "_histogram",
+			columns:       histogramColumnsSQL,
+			insertColumns: []string{"Count", "Sum", "BucketCounts", "ExplicitBounds", "Min", "Max", "AggTemp"},
+		},
+		{
+			dataType: pmetric.MetricDataTypeExponentialHistogram,
+			suffix:   "_exponential_histogram",
+			columns:  exponentialHistogramColumnsSQL,
+			insertColumns: []string{"Count", "Sum", "Scale", "ZeroCount", "PositiveOffset", "PositiveBucketCounts",
+				"NegativeOffset", "NegativeBucketCounts", "Min", "Max", "AggTemp"},
+		},
+		{
+			dataType:      pmetric.MetricDataTypeSummary,
+			suffix:        "_summary",
+			columns:       summaryColumnsSQL,
+			insertColumns: []string{"Count", "Sum", "ValueAtQuantiles.Quantile", "ValueAtQuantiles.Value"},
+		},
+	}
+	for _, table := range tables {
+		table.insertSQL = renderInsertMetricsSQL(cfg.MetricsTableName+table.suffix, table.insertColumns)
+	}
+	return tables
+}
+
+func createMetricsTables(cfg *Config, db *sql.DB, tables []*metricsTable) error {
+	for _, table := range tables {
+		template := fmt.Sprintf(createMetricsTableSQL, table.columns)
+		if err := createTable(db, template, cfg.MetricsTableName+table.suffix, renderTTL(cfg, "TimeUnix")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderInsertMetricsSQL renders the insert statement of a metric type table.
+func renderInsertMetricsSQL(tableName string, insertColumns []string) string {
+	columns := append(append([]string{}, metricsCommonColumns...), insertColumns...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(columns, ", "), placeholders)
+}
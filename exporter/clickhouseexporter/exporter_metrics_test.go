@@ -0,0 +1,151 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := map[string]int{}
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				table := strings.Fields(query)[2]
+				require.Equal(t, strings.Count(query, "?"), len(values))
+				items[table]++
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("create schema", func(t *testing.T) {
+		var created []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.Contains(query, "CREATE TABLE") {
+				created = append(created, query)
+			}
+			return nil
+		})
+
+		newTestMetricsExporter(t, defaultDSN, func(cfg *Config) {
+			cfg.MetricsTableName = "metrics"
+			cfg.TTLDays = 3
+		})
+		require.Len(t, created, 5)
+		for i, table := range []string{"metrics_gauge", "metrics_sum", "metrics_histogram",
+			"metrics_exponential_histogram", "metrics_summary"} {
+			require.Contains(t, created[i], "CREATE TABLE IF NOT EXISTS "+table+" (")
+			require.Contains(t, created[i], "TTL toDateTime(TimeUnix) + INTERVAL 3 DAY")
+		}
+
+		created = nil
+		newTestMetricsExporter(t, defaultDSN, func(cfg *Config) {
+			cfg.CreateSchema = false
+		})
+		require.Empty(t, created)
+	})
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "test-service")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("test-scope")
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := 0; i < count; i++ {
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("gauge")
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntVal(1)
+		dp.Attributes().InsertString("k", "v")
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("sum")
+		m.SetDataType(pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(true)
+		dp = m.Sum().DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetDoubleVal(1.5)
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("histogram")
+		m.SetDataType(pmetric.MetricDataTypeHistogram)
+		hdp := m.Histogram().DataPoints().AppendEmpty()
+		hdp.SetTimestamp(now)
+		hdp.SetCount(2)
+		hdp.SetSum(3)
+		hdp.SetMBucketCounts([]uint64{1, 1})
+		hdp.SetMExplicitBounds([]float64{1})
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("exponential_histogram")
+		m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+		edp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		edp.SetTimestamp(now)
+		edp.SetCount(2)
+		edp.SetScale(1)
+		edp.Positive().SetMBucketCounts([]uint64{2})
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("summary")
+		m.SetDataType(pmetric.MetricDataTypeSummary)
+		sdp := m.Summary().DataPoints().AppendEmpty()
+		sdp.SetTimestamp(now)
+		sdp.SetCount(2)
+		sdp.SetSum(3)
+		q := sdp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0.5)
+		q.SetValue(1)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
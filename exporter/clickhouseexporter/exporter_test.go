@@ -20,6 +20,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -142,10 +143,16 @@ func mustPushLogsData(t *testing.T, exporter *clickhouseExporter, ld plog.Logs)
 
 const testDriverName = "clickhouse-test"
 
+var (
+	testDriver             = &testClickhouseDriver{}
+	registerTestDriverOnce sync.Once
+)
+
 func initClickhouseTestServer(_ *testing.T, recorder recorder) {
 	driverName = testDriverName
-	sql.Register(testDriverName, &testClickhouseDriver{
-		recorder: recorder,
+	testDriver.recorder = recorder
+	registerTestDriverOnce.Do(func() {
+		sql.Register(testDriverName, testDriver)
 	})
 }
 
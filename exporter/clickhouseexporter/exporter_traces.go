@@ -0,0 +1,280 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+type tracesExporter struct {
+	client    *sql.DB
+	insertSQL string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newTracesExporter(logger *zap.Logger, cfg *Config) (*tracesExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CreateSchema {
+		if err = createTracesTable(cfg, client); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tracesExporter{
+		client:    client,
+		insertSQL: renderInsertTracesSQL(cfg),
+		logger:    logger,
+		cfg:       cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *tracesExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) error {
+	start := time.Now()
+	err := doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+		statement, err := tx.PrepareContext(ctx, e.insertSQL)
+		if err != nil {
+			return fmt.Errorf("PrepareContext:%w", err)
+		}
+		defer func() {
+			_ = statement.Close()
+		}()
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			spans := td.ResourceSpans().At(i)
+			res := spans.Resource()
+			resourceKeys, resourceValues := attributesToSlice(res.Attributes())
+			var serviceName string
+			if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+				serviceName = v.StringVal()
+			}
+			for j := 0; j < spans.ScopeSpans().Len(); j++ {
+				rs := spans.ScopeSpans().At(j).Spans()
+				for k := 0; k < rs.Len(); k++ {
+					r := rs.At(k)
+					spanAttrKeys, spanAttrValues := attributesToSlice(r.Attributes())
+					eventTimes, eventNames, eventAttrKeys, eventAttrValues := convertEvents(r.Events())
+					linksTraceIDs, linksSpanIDs, linksTraceStates, linksAttrKeys, linksAttrValues := convertLinks(r.Links())
+					_, err = statement.ExecContext(ctx,
+						r.StartTimestamp().AsTime(),
+						r.TraceID().HexString(),
+						r.SpanID().HexString(),
+						r.ParentSpanID().HexString(),
+						string(r.TraceState()),
+						r.Name(),
+						r.Kind().String(),
+						serviceName,
+						resourceKeys,
+						resourceValues,
+						spanAttrKeys,
+						spanAttrValues,
+						r.EndTimestamp().AsTime().Sub(r.StartTimestamp().AsTime()).Nanoseconds(),
+						r.Status().Code().String(),
+						r.Status().Message(),
+						eventTimes,
+						eventNames,
+						eventAttrKeys,
+						eventAttrValues,
+						linksTraceIDs,
+						linksSpanIDs,
+						linksTraceStates,
+						linksAttrKeys,
+						linksAttrValues,
+					)
+					if err != nil {
+						return fmt.Errorf("ExecContext:%w", err)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	duration := time.Since(start)
+	e.logger.Debug("insert traces", zap.Int("records", td.SpanCount()),
+		zap.String("cost", duration.String()))
+	return err
+}
+
+func convertEvents(events ptrace.SpanEventSlice) ([]time.Time, []string, [][]string, [][]string) {
+	var (
+		times      []time.Time
+		names      []string
+		attrKeys   [][]string
+		attrValues [][]string
+	)
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		keys, values := attributesToSlice(event.Attributes())
+		times = append(times, event.Timestamp().AsTime())
+		names = append(names, event.Name())
+		attrKeys = append(attrKeys, keys)
+		attrValues = append(attrValues, values)
+	}
+	return times, names, attrKeys, attrValues
+}
+
+func convertLinks(links ptrace.SpanLinkSlice) ([]string, []string, []string, [][]string, [][]string) {
+	var (
+		traceIDs    []string
+		spanIDs     []string
+		traceStates []string
+		attrKeys    [][]string
+		attrValues  [][]string
+	)
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		keys, values := attributesToSlice(link.Attributes())
+		traceIDs = append(traceIDs, link.TraceID().HexString())
+		spanIDs = append(spanIDs, link.SpanID().HexString())
+		traceStates = append(traceStates, string(link.TraceState()))
+		attrKeys = append(attrKeys, keys)
+		attrValues = append(attrValues, values)
+	}
+	return traceIDs, spanIDs, traceStates, attrKeys, attrValues
+}
+
+const (
+	// language=ClickHouse SQL
+	createTracesTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (
+     Timestamp DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TraceId String CODEC(ZSTD(1)),
+     SpanId String CODEC(ZSTD(1)),
+     ParentSpanId String CODEC(ZSTD(1)),
+     TraceState String CODEC(ZSTD(1)),
+     SpanName LowCardinality(String) CODEC(ZSTD(1)),
+     SpanKind LowCardinality(String) CODEC(ZSTD(1)),
+     ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+     ResourceAttributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     SpanAttributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     Duration Int64 CODEC(ZSTD(1)),
+     StatusCode LowCardinality(String) CODEC(ZSTD(1)),
+     StatusMessage String CODEC(ZSTD(1)),
+     Events Nested
+     (
+         Timestamp DateTime64(9),
+         Name LowCardinality(String),
+         AttributesKey Array(LowCardinality(String)),
+         AttributesValue Array(String)
+     ) CODEC(ZSTD(1)),
+     Links Nested
+     (
+         TraceId String,
+         SpanId String,
+         TraceState String,
+         AttributesKey Array(LowCardinality(String)),
+         AttributesValue Array(String)
+     ) CODEC(ZSTD(1)),
+     INDEX idx_trace_id TraceId TYPE bloom_filter(0.001) GRANULARITY 1,
+     INDEX idx_res_attr_keys ResourceAttributes.Key TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_span_attr_keys SpanAttributes.Key TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_duration Duration TYPE minmax GRANULARITY 1
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(Timestamp)
+ORDER BY (ServiceName, SpanName, toUnixTimestamp(Timestamp), TraceId);
+`
+	// language=ClickHouse SQL
+	insertTracesSQLTemplate = `INSERT INTO %s (
+                        Timestamp,
+                        TraceId,
+                        SpanId,
+                        ParentSpanId,
+                        TraceState,
+                        SpanName,
+                        SpanKind,
+                        ServiceName,
+                        ResourceAttributes.Key,
+                        ResourceAttributes.Value,
+                        SpanAttributes.Key,
+                        SpanAttributes.Value,
+                        Duration,
+                        StatusCode,
+                        StatusMessage,
+                        Events.Timestamp,
+                        Events.Name,
+                        Events.AttributesKey,
+                        Events.AttributesValue,
+                        Links.TraceId,
+                        Links.SpanId,
+                        Links.TraceState,
+                        Links.AttributesKey,
+                        Links.AttributesValue
+                        ) VALUES (
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?
+                                  )`
+)
+
+func createTracesTable(cfg *Config, db *sql.DB) error {
+	return createTable(db, createTracesTableSQL, cfg.TracesTableName, renderTTL(cfg, "Timestamp"))
+}
+
+func renderInsertTracesSQL(cfg *Config) string {
+	return fmt.Sprintf(insertTracesSQLTemplate, cfg.TracesTableName)
+}
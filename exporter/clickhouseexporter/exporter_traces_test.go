@@ -0,0 +1,102 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_pushTraceData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		var items int
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				require.Contains(t, query, "INSERT INTO otel_traces")
+				require.Equal(t, "test-service", values[7])
+				items++
+			}
+			return nil
+		})
+
+		exporter := newTestTracesExporter(t, defaultDSN)
+		mustPushTracesData(t, exporter, simpleTraces(1))
+		mustPushTracesData(t, exporter, simpleTraces(2))
+
+		require.Equal(t, 3, items)
+	})
+	t.Run("create schema", func(t *testing.T) {
+		var created []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.Contains(query, "CREATE TABLE") {
+				created = append(created, query)
+			}
+			return nil
+		})
+
+		newTestTracesExporter(t, defaultDSN, func(cfg *Config) {
+			cfg.TTLDays = 3
+		})
+		require.Len(t, created, 1)
+		require.Contains(t, created[0], "CREATE TABLE IF NOT EXISTS otel_traces")
+		require.Contains(t, created[0], "TTL toDateTime(Timestamp) + INTERVAL 3 DAY")
+
+		created = nil
+		newTestTracesExporter(t, defaultDSN, func(cfg *Config) {
+			cfg.CreateSchema = false
+		})
+		require.Empty(t, created)
+	})
+}
+
+func newTestTracesExporter(t *testing.T, dsn string, fns ...func(*Config)) *tracesExporter {
+	exporter, err := newTracesExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleTraces(count int) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "test-service")
+	ss := rs.ScopeSpans().AppendEmpty()
+	for i := 0; i < count; i++ {
+		s := ss.Spans().AppendEmpty()
+		s.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		s.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		s.Attributes().InsertString("k", "v")
+		event := s.Events().AppendEmpty()
+		event.SetName("event1")
+		event.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		link := s.Links().AppendEmpty()
+		link.Attributes().InsertString("k", "v")
+	}
+	return traces
+}
+
+func mustPushTracesData(t *testing.T, exporter *tracesExporter, td ptrace.Traces) {
+	err := exporter.pushTraceData(context.TODO(), td)
+	require.NoError(t, err)
+}
@@ -5,7 +5,6 @@ go 1.17
 require (
 	go.opentelemetry.io/collector v0.55.1-0.20220711160057-6133c820fd50
 	go.uber.org/zap v1.21.0
-
 )
 
 require go.uber.org/multierr v1.8.0
@@ -14,6 +13,7 @@ require (
 	github.com/ClickHouse/clickhouse-go v1.5.4
 	github.com/stretchr/testify v1.8.0
 	go.opentelemetry.io/collector/pdata v0.55.1-0.20220711160057-6133c820fd50
+	go.opentelemetry.io/collector/semconv v0.55.1-0.20220711160057-6133c820fd50
 )
 
 require (
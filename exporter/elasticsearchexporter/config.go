@@ -49,9 +49,25 @@ type Config struct {
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/indices.html
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/data-streams.html
 	//
-	// This setting is required.
+	// Deprecated: use LogsIndex instead. When set, Index takes precedence over LogsIndex.
 	Index string `mapstructure:"index"`
 
+	// LogsIndex configures the index, index alias, or data stream name logs should be indexed in.
+	//
+	// This setting is required when Index is not set.
+	LogsIndex string `mapstructure:"logs_index"`
+
+	// TracesIndex configures the index, index alias, or data stream name spans should be indexed in.
+	//
+	// This setting is required.
+	TracesIndex string `mapstructure:"traces_index"`
+
+	// MetricsIndex configures the index, index alias, or data stream name metric data points
+	// should be indexed in.
+	//
+	// This setting is required.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -217,7 +233,7 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
-	if cfg.Index == "" {
+	if cfg.logsIndex() == "" || cfg.TracesIndex == "" || cfg.MetricsIndex == "" {
 		return errConfigNoIndex
 	}
 
@@ -227,3 +243,16 @@ func (cfg *Config) Validate() error {
 
 	return nil
 }
+
+// mappingMode returns the configured mapping mode, the configuration is expected to be valid.
+func (cfg *Config) mappingMode() MappingMode {
+	return mappingModes[cfg.Mapping.Mode]
+}
+
+// logsIndex returns the index logs are indexed in, honoring the deprecated Index setting.
+func (cfg *Config) logsIndex() string {
+	if cfg.Index != "" {
+		return cfg.Index
+	}
+	return cfg.LogsIndex
+}
@@ -49,6 +49,9 @@ func TestLoadConfig(t *testing.T) {
 		Endpoints:        []string{"https://elastic.example.com:9200"},
 		CloudID:          "TRNMxjXlNJEt",
 		Index:            "myindex",
+		LogsIndex:        "logs-generic-default",
+		TracesIndex:      "traces-myindex",
+		MetricsIndex:     "metrics-myindex",
 		Pipeline:         "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
@@ -88,3 +91,50 @@ func withDefaultConfig(fns ...func(*Config)) *Config {
 	}
 	return cfg
 }
+
+func TestConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		config *Config
+		err    error
+	}{
+		"default": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+			}),
+		},
+		"deprecated index replaces the logs index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.Index = "myindex"
+				cfg.LogsIndex = ""
+			}),
+		},
+		"no logs index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.LogsIndex = ""
+			}),
+			err: errConfigNoIndex,
+		},
+		"no traces index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.TracesIndex = ""
+			}),
+			err: errConfigNoIndex,
+		},
+		"no metrics index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.MetricsIndex = ""
+			}),
+			err: errConfigNoIndex,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.err, test.config.Validate())
+		})
+	}
+}
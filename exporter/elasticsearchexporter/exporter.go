@@ -31,6 +31,8 @@ import (
 	esutil7 "github.com/elastic/go-elasticsearch/v7/esutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -58,7 +60,7 @@ var retryOnStatus = []int{500, 502, 503, 504, 429}
 
 const createAction = "create"
 
-func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error) {
+func newExporter(logger *zap.Logger, cfg *Config, index string) (*elasticsearchExporter, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
@@ -78,15 +80,15 @@ func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error
 		maxAttempts = cfg.Retry.MaxRequests
 	}
 
-	// TODO: Apply encoding and field mapping settings.
-	model := &encodeModel{dedup: true, dedot: false}
+	// TODO: Apply encoding and additional field mapping settings.
+	model := &encodeModel{dedup: true, dedot: false, mode: cfg.mappingMode()}
 
 	return &elasticsearchExporter{
 		logger:      logger,
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.Index,
+		index:       index,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}, nil
@@ -129,6 +131,79 @@ func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pcom
 	return e.pushEvent(ctx, document)
 }
 
+func (e *elasticsearchExporter) pushTraceData(ctx context.Context, td ptrace.Traces) error {
+	var errs []error
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource()
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			scope := ilss.At(j).Scope()
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if err := e.pushTraceRecord(ctx, resource, scope, spans.At(k)); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, span ptrace.Span) error {
+	document, err := e.model.encodeSpan(resource, scope, span)
+	if err != nil {
+		return fmt.Errorf("Failed to encode trace record: %w", err)
+	}
+	return e.pushEvent(ctx, document)
+}
+
+func (e *elasticsearchExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	var errs []error
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource()
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			scope := ilms.At(j).Scope()
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if err := e.pushMetricRecord(ctx, resource, scope, metrics.At(k)); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchExporter) pushMetricRecord(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric) error {
+	documents, err := e.model.encodeMetric(resource, scope, metric)
+	if err != nil {
+		return fmt.Errorf("Failed to encode metric %q: %w", metric.Name(), err)
+	}
+
+	var errs []error
+	for _, document := range documents {
+		errs = append(errs, e.pushEvent(ctx, document))
+	}
+	return multierr.Combine(errs...)
+}
+
 func (e *elasticsearchExporter) pushEvent(ctx context.Context, document []byte) error {
 	attempts := 1
 	body := bytes.NewReader(document)
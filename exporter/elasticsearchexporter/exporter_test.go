@@ -27,6 +27,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -110,7 +112,7 @@ func TestExporter_New(t *testing.T) {
 				t.Setenv(k, v)
 			}
 
-			exporter, err := newExporter(zap.NewNop(), test.config)
+			exporter, err := newExporter(zap.NewNop(), test.config, test.config.logsIndex())
 			if exporter != nil {
 				defer func() {
 					require.NoError(t, exporter.Shutdown(context.TODO()))
@@ -302,8 +304,72 @@ func TestExporter_PushEvent(t *testing.T) {
 	})
 }
 
+func TestExporter_PushTraceAndMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+
+	t.Run("publish spans to the traces index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		cfg := withTestExporterConfig()(server.URL)
+		exporter, err := newExporter(zaptest.NewLogger(t), cfg, cfg.TracesIndex)
+		require.NoError(t, err)
+		t.Cleanup(func() { exporter.Shutdown(context.TODO()) })
+
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		spans.AppendEmpty().SetName("first")
+		spans.AppendEmpty().SetName("second")
+		require.NoError(t, exporter.pushTraceData(context.TODO(), td))
+
+		rec.WaitItems(2)
+		var names []string
+		for _, item := range rec.Items() {
+			assert.JSONEq(t, `{"create":{"_index":"traces-generic-default"}}`, string(item.Action))
+
+			// the default ecs mapping mode is applied
+			var document map[string]interface{}
+			require.NoError(t, json.Unmarshal(item.Document, &document))
+			names = append(names, document["span.name"].(string))
+		}
+		assert.ElementsMatch(t, []string{"first", "second"}, names)
+	})
+
+	t.Run("publish data points to the metrics index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		cfg := withTestExporterConfig()(server.URL)
+		exporter, err := newExporter(zaptest.NewLogger(t), cfg, cfg.MetricsIndex)
+		require.NoError(t, err)
+		t.Cleanup(func() { exporter.Shutdown(context.TODO()) })
+
+		md := pmetric.NewMetrics()
+		metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("requests")
+		metric.SetDataType(pmetric.MetricDataTypeGauge)
+		metric.Gauge().DataPoints().AppendEmpty().SetIntVal(1)
+		metric.Gauge().DataPoints().AppendEmpty().SetIntVal(2)
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), md))
+
+		rec.WaitItems(2)
+		for _, item := range rec.Items() {
+			assert.JSONEq(t, `{"create":{"_index":"metrics-generic-default"}}`, string(item.Action))
+		}
+	})
+}
+
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchExporter {
-	exporter, err := newExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
+	cfg := withTestExporterConfig(fns...)(url)
+	exporter, err := newExporter(zaptest.NewLogger(t), cfg, cfg.logsIndex())
 	require.NoError(t, err)
 
 	t.Cleanup(func() { exporter.Shutdown(context.TODO()) })
@@ -37,6 +37,8 @@ func NewFactory() component.ExporterFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithLogsExporterAndStabilityLevel(createLogsExporter, stability),
+		component.WithTracesExporterAndStabilityLevel(createTracesExporter, stability),
+		component.WithMetricsExporterAndStabilityLevel(createMetricsExporter, stability),
 	)
 }
 
@@ -46,7 +48,9 @@ func createDefaultConfig() config.Exporter {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index: "logs-generic-default",
+		LogsIndex:    "logs-generic-default",
+		TracesIndex:  "traces-generic-default",
+		MetricsIndex: "metrics-generic-default",
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -69,7 +73,8 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	exporter, err := newExporter(set.Logger, cfg.(*Config))
+	cf := cfg.(*Config)
+	exporter, err := newExporter(set.Logger, cf, cf.logsIndex())
 	if err != nil {
 		return nil, fmt.Errorf("cannot configure Elasticsearch logs exporter: %w", err)
 	}
@@ -81,3 +86,47 @@ func createLogsExporter(
 		exporterhelper.WithShutdown(exporter.Shutdown),
 	)
 }
+
+// createTracesExporter creates a new exporter for traces.
+//
+// Each span is indexed into Elasticsearch as a separate document.
+func createTracesExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	cf := cfg.(*Config)
+	exporter, err := newExporter(set.Logger, cf, cf.TracesIndex)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch traces exporter: %w", err)
+	}
+
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		exporter.pushTraceData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
+
+// createMetricsExporter creates a new exporter for metrics.
+//
+// Each metric data point is indexed into Elasticsearch as a separate document.
+func createMetricsExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	cf := cfg.(*Config)
+	exporter, err := newExporter(set.Logger, cf, cf.MetricsIndex)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
@@ -44,12 +44,30 @@ func TestFactory_CreateLogsExporter(t *testing.T) {
 	require.NoError(t, exporter.Shutdown(context.TODO()))
 }
 
-func TestFactory_CreateMetricsExporter_Fail(t *testing.T) {
+func TestFactory_CreateMetricsExporter(t *testing.T) {
 	factory := NewFactory()
-	cfg := factory.CreateDefaultConfig()
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+	})
 	params := componenttest.NewNopExporterCreateSettings()
-	_, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
-	require.Error(t, err, "expected an error when creating a traces exporter")
+	exporter, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+
+	require.NoError(t, exporter.Shutdown(context.TODO()))
+}
+
+func TestFactory_CreateTracesExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+	})
+	params := componenttest.NewNopExporterCreateSettings()
+	exporter, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+
+	require.NoError(t, exporter.Shutdown(context.TODO()))
 }
 
 func TestFactory_CreateTracesExporter_Fail(t *testing.T) {
@@ -57,5 +75,5 @@ func TestFactory_CreateTracesExporter_Fail(t *testing.T) {
 	cfg := factory.CreateDefaultConfig()
 	params := componenttest.NewNopExporterCreateSettings()
 	_, err := factory.CreateTracesExporter(context.Background(), params, cfg)
-	require.Error(t, err, "expected an error when creating a traces exporter")
+	require.Error(t, err, "expected an error when creating a traces exporter without endpoints")
 }
@@ -153,6 +153,16 @@ func (doc *Document) AddInt(key string, value int64) {
 	doc.Add(key, IntValue(value))
 }
 
+// AddDouble adds a double value to the document.
+func (doc *Document) AddDouble(key string, value float64) {
+	doc.Add(key, DoubleValue(value))
+}
+
+// AddBool adds a boolean value to the document.
+func (doc *Document) AddBool(key string, value bool) {
+	doc.Add(key, BoolValue(value))
+}
+
 // AddAttributes expands and flattens all key-value pairs from the input attribute map into
 // the document.
 func (doc *Document) AddAttributes(key string, attributes pcommon.Map) {
@@ -362,6 +372,11 @@ func ArrValue(values ...Value) Value {
 	return Value{kind: KindArr, arr: values}
 }
 
+// ObjectValue wraps a document into a value, such that it can be nested into arrays.
+func ObjectValue(doc Document) Value {
+	return Value{kind: KindObject, doc: doc}
+}
+
 // TimestampValue create a new value from a time.Time.
 func TimestampValue(ts time.Time) Value {
 	return Value{kind: KindTimestamp, ts: ts}
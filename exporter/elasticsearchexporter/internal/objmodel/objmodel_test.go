@@ -434,6 +434,15 @@ func TestValue_Serialize(t *testing.T) {
 			value: Value{kind: KindObject, doc: Document{}},
 			want:  "null",
 		},
+		"array of objects": {
+			value: func() Value {
+				doc := Document{}
+				doc.AddDouble("a", 1.5)
+				doc.AddBool("b", true)
+				return ArrValue(ObjectValue(doc))
+			}(),
+			want: `[{"a":1.5,"b":true}]`,
+		},
 	}
 
 	for name, test := range tests {
@@ -16,15 +16,22 @@ package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"bytes"
+	"fmt"
+	"math"
+	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
 )
 
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
+	encodeSpan(pcommon.Resource, pcommon.InstrumentationScope, ptrace.Span) ([]byte, error)
+	encodeMetric(pcommon.Resource, pcommon.InstrumentationScope, pmetric.Metric) ([][]byte, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -32,10 +39,53 @@ type mappingModel interface {
 //
 // Field deduplication and dedotting of attributes is supported by the encodeModel.
 //
+// With the ECS mapping mode, spans and metric data points are mapped to the Elastic Common Schema
+// instead.
+//
 // See: https://github.com/open-telemetry/oteps/blob/master/text/logs/0097-log-data-model.md
 type encodeModel struct {
 	dedup bool
 	dedot bool
+	mode  MappingMode
+}
+
+// ecsResourceFields maps the resource semantic conventions to their ECS field.
+var ecsResourceFields = map[string]string{
+	"service.name":            "service.name",
+	"service.version":         "service.version",
+	"service.instance.id":     "service.node.name",
+	"deployment.environment":  "service.environment",
+	"telemetry.sdk.name":      "agent.name",
+	"telemetry.sdk.version":   "agent.version",
+	"host.name":               "host.hostname",
+	"host.id":                 "host.id",
+	"host.arch":               "host.architecture",
+	"os.type":                 "host.os.platform",
+	"os.description":          "host.os.full",
+	"process.pid":             "process.pid",
+	"process.executable.name": "process.name",
+	"process.command_line":    "process.command_line",
+	"container.id":            "container.id",
+	"container.name":          "container.name",
+	"container.image.name":    "container.image.name",
+	"container.image.tag":     "container.image.tag",
+	"k8s.namespace.name":      "kubernetes.namespace",
+	"k8s.node.name":           "kubernetes.node.name",
+	"k8s.pod.name":            "kubernetes.pod.name",
+	"k8s.pod.uid":             "kubernetes.pod.uid",
+	"cloud.provider":          "cloud.provider",
+	"cloud.platform":          "cloud.service.name",
+	"cloud.region":            "cloud.region",
+	"cloud.availability_zone": "cloud.availability_zone",
+	"cloud.account.id":        "cloud.account.id",
+}
+
+// ecsSpanFields maps the span semantic conventions to their ECS field.
+var ecsSpanFields = map[string]string{
+	"http.method":      "http.request.method",
+	"http.status_code": "http.response.status_code",
+	"http.url":         "url.original",
+	"http.user_agent":  "user_agent.original",
 }
 
 func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord) ([]byte, error) {
@@ -50,6 +100,285 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
 
+	return m.serialize(document)
+}
+
+func (m *encodeModel) encodeSpan(resource pcommon.Resource, scope pcommon.InstrumentationScope, span ptrace.Span) ([]byte, error) {
+	if m.mode == MappingECS {
+		return m.encodeSpanECS(resource, scope, span)
+	}
+
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream traces template is used.
+	document.AddTimestamp("EndTimestamp", span.EndTimestamp())
+	document.AddInt("Duration", int64(span.EndTimestamp()-span.StartTimestamp()))
+	document.AddID("TraceId", span.TraceID())
+	document.AddID("SpanId", span.SpanID())
+	document.AddID("ParentSpanId", span.ParentSpanID())
+	document.AddString("TraceState", string(span.TraceState()))
+	document.AddString("Name", span.Name())
+	document.AddString("Kind", span.Kind().String())
+	document.AddString("TraceStatus", span.Status().Code().String())
+	document.AddString("TraceStatusDescription", span.Status().Message())
+	document.AddAttributes("Attributes", span.Attributes())
+	document.AddAttributes("Resource", resource.Attributes())
+	addScope(&document, scope)
+
+	if events := span.Events(); events.Len() > 0 {
+		values := make([]objmodel.Value, 0, events.Len())
+		for i := 0; i < events.Len(); i++ {
+			event := events.At(i)
+			var doc objmodel.Document
+			doc.AddTimestamp("Timestamp", event.Timestamp())
+			doc.AddString("Name", event.Name())
+			doc.AddAttributes("Attributes", event.Attributes())
+			values = append(values, objmodel.ObjectValue(doc))
+		}
+		document.Add("Events", objmodel.ArrValue(values...))
+	}
+
+	if links := span.Links(); links.Len() > 0 {
+		values := make([]objmodel.Value, 0, links.Len())
+		for i := 0; i < links.Len(); i++ {
+			link := links.At(i)
+			var doc objmodel.Document
+			doc.AddID("TraceId", link.TraceID())
+			doc.AddID("SpanId", link.SpanID())
+			doc.AddString("TraceState", string(link.TraceState()))
+			doc.AddAttributes("Attributes", link.Attributes())
+			values = append(values, objmodel.ObjectValue(doc))
+		}
+		document.Add("Links", objmodel.ArrValue(values...))
+	}
+
+	return m.serialize(document)
+}
+
+// encodeMetric creates a document for each data point of the metric.
+func (m *encodeModel) encodeMetric(resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric) ([][]byte, error) {
+	if m.mode == MappingECS {
+		return m.encodeMetricECS(resource, scope, metric)
+	}
+
+	var documents []objmodel.Document
+	newDocument := func(timestamp, startTimestamp pcommon.Timestamp, attributes pcommon.Map) *objmodel.Document {
+		var document objmodel.Document
+		document.AddTimestamp("@timestamp", timestamp) // We use @timestamp in order to ensure that we can index if the default data stream metrics template is used.
+		document.AddTimestamp("StartTimestamp", startTimestamp)
+		document.AddString("Name", metric.Name())
+		document.AddString("Description", metric.Description())
+		document.AddString("Unit", metric.Unit())
+		document.AddString("Type", metric.DataType().String())
+		document.AddAttributes("Attributes", attributes)
+		document.AddAttributes("Resource", resource.Attributes())
+		addScope(&document, scope)
+		documents = append(documents, document)
+		return &documents[len(documents)-1]
+	}
+
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			addNumberValue(newDocument(dp.Timestamp(), dp.StartTimestamp(), dp.Attributes()), dp)
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			document := newDocument(dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			addNumberValue(document, dp)
+			document.AddString("AggregationTemporality", metric.Sum().AggregationTemporality().String())
+			document.AddBool("IsMonotonic", metric.Sum().IsMonotonic())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			document := newDocument(dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			document.AddString("AggregationTemporality", metric.Histogram().AggregationTemporality().String())
+			document.AddInt("Count", int64(dp.Count()))
+			if dp.HasSum() {
+				document.AddDouble("Sum", dp.Sum())
+			}
+			if dp.HasMin() {
+				document.AddDouble("Min", dp.Min())
+			}
+			if dp.HasMax() {
+				document.AddDouble("Max", dp.Max())
+			}
+			document.Add("BucketCounts", uint64Values(dp.MBucketCounts()))
+			document.Add("ExplicitBounds", float64Values(dp.MExplicitBounds()))
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			document := newDocument(dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			document.AddString("AggregationTemporality", metric.ExponentialHistogram().AggregationTemporality().String())
+			document.AddInt("Count", int64(dp.Count()))
+			if dp.HasSum() {
+				document.AddDouble("Sum", dp.Sum())
+			}
+			if dp.HasMin() {
+				document.AddDouble("Min", dp.Min())
+			}
+			if dp.HasMax() {
+				document.AddDouble("Max", dp.Max())
+			}
+			document.AddInt("Scale", int64(dp.Scale()))
+			document.AddInt("ZeroCount", int64(dp.ZeroCount()))
+			document.AddInt("Positive.Offset", int64(dp.Positive().Offset()))
+			document.Add("Positive.BucketCounts", uint64Values(dp.Positive().MBucketCounts()))
+			document.AddInt("Negative.Offset", int64(dp.Negative().Offset()))
+			document.Add("Negative.BucketCounts", uint64Values(dp.Negative().MBucketCounts()))
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			document := newDocument(dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			document.AddInt("Count", int64(dp.Count()))
+			document.AddDouble("Sum", dp.Sum())
+			quantiles := make([]objmodel.Value, 0, dp.QuantileValues().Len())
+			for j := 0; j < dp.QuantileValues().Len(); j++ {
+				qv := dp.QuantileValues().At(j)
+				var doc objmodel.Document
+				doc.AddDouble("Quantile", qv.Quantile())
+				doc.AddDouble("Value", qv.Value())
+				quantiles = append(quantiles, objmodel.ObjectValue(doc))
+			}
+			document.Add("Quantiles", objmodel.ArrValue(quantiles...))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported metric type %q", metric.DataType())
+	}
+
+	return m.serializeAll(documents)
+}
+
+func (m *encodeModel) encodeSpanECS(resource pcommon.Resource, scope pcommon.InstrumentationScope, span ptrace.Span) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", span.StartTimestamp())
+	document.AddID("trace.id", span.TraceID())
+	document.AddID("span.id", span.SpanID())
+	document.AddID("parent.id", span.ParentSpanID())
+	document.AddString("span.name", span.Name())
+	document.AddString("span.kind", strings.ToLower(strings.TrimPrefix(span.Kind().String(), "SPAN_KIND_")))
+	document.AddString("span.trace_state", string(span.TraceState()))
+	document.AddTimestamp("event.end", span.EndTimestamp())
+	document.AddInt("event.duration", int64(span.EndTimestamp()-span.StartTimestamp()))
+	document.AddString("event.outcome", ecsOutcome(span.Status().Code()))
+	if span.Status().Code() == ptrace.StatusCodeError {
+		document.AddString("error.message", span.Status().Message())
+	}
+	addECSResource(&document, resource, scope)
+	addECSAttributes(&document, span.Attributes(), ecsSpanFields)
+
+	if events := span.Events(); events.Len() > 0 {
+		values := make([]objmodel.Value, 0, events.Len())
+		for i := 0; i < events.Len(); i++ {
+			event := events.At(i)
+			var doc objmodel.Document
+			doc.AddTimestamp("@timestamp", event.Timestamp())
+			doc.AddString("name", event.Name())
+			doc.AddAttributes("attributes", event.Attributes())
+			values = append(values, objmodel.ObjectValue(doc))
+		}
+		document.Add("span.events", objmodel.ArrValue(values...))
+	}
+
+	if links := span.Links(); links.Len() > 0 {
+		values := make([]objmodel.Value, 0, links.Len())
+		for i := 0; i < links.Len(); i++ {
+			link := links.At(i)
+			var doc objmodel.Document
+			doc.AddID("trace.id", link.TraceID())
+			doc.AddID("span.id", link.SpanID())
+			values = append(values, objmodel.ObjectValue(doc))
+		}
+		document.Add("span.links", objmodel.ArrValue(values...))
+	}
+
+	return m.serialize(document)
+}
+
+// encodeMetricECS creates a document for each data point of the metric, holding the value of
+// the data point in a field named after the metric. Histograms are encoded with the values and
+// counts of the Elasticsearch histogram field type, and summaries with the sum and value_count
+// of the aggregate_metric_double field type.
+func (m *encodeModel) encodeMetricECS(resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric) ([][]byte, error) {
+	var documents []objmodel.Document
+	newDocument := func(timestamp pcommon.Timestamp, attributes pcommon.Map) *objmodel.Document {
+		var document objmodel.Document
+		document.AddTimestamp("@timestamp", timestamp)
+		addECSResource(&document, resource, scope)
+		addECSAttributes(&document, attributes, nil)
+		documents = append(documents, document)
+		return &documents[len(documents)-1]
+	}
+
+	name := metric.Name()
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			addNumberValueAt(newDocument(dp.Timestamp(), dp.Attributes()), name, dp)
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			addNumberValueAt(newDocument(dp.Timestamp(), dp.Attributes()), name, dp)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values, counts := histogramValues(dp)
+			document := newDocument(dp.Timestamp(), dp.Attributes())
+			document.Add(name+".values", float64Values(values))
+			document.Add(name+".counts", uint64Values(counts))
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values, counts := exponentialHistogramValues(dp)
+			document := newDocument(dp.Timestamp(), dp.Attributes())
+			document.Add(name+".values", float64Values(values))
+			document.Add(name+".counts", uint64Values(counts))
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			document := newDocument(dp.Timestamp(), dp.Attributes())
+			document.AddDouble(name+".sum", dp.Sum())
+			document.AddInt(name+".value_count", int64(dp.Count()))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported metric type %q", metric.DataType())
+	}
+
+	return m.serializeAll(documents)
+}
+
+func (m *encodeModel) serializeAll(documents []objmodel.Document) ([][]byte, error) {
+	encoded := make([][]byte, 0, len(documents))
+	for _, document := range documents {
+		buf, err := m.serialize(document)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, buf)
+	}
+	return encoded, nil
+}
+
+func (m *encodeModel) serialize(document objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -60,3 +389,137 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	err := document.Serialize(&buf, m.dedot)
 	return buf.Bytes(), err
 }
+
+func addScope(document *objmodel.Document, scope pcommon.InstrumentationScope) {
+	document.AddString("Scope.Name", scope.Name())
+	document.AddString("Scope.Version", scope.Version())
+}
+
+func addNumberValue(document *objmodel.Document, dp pmetric.NumberDataPoint) {
+	addNumberValueAt(document, "Value", dp)
+}
+
+func addNumberValueAt(document *objmodel.Document, key string, dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		document.AddInt(key, dp.IntVal())
+	case pmetric.NumberDataPointValueTypeDouble:
+		document.AddDouble(key, dp.DoubleVal())
+	}
+}
+
+// addECSResource adds the resource attributes and the instrumentation scope to the document,
+// under their ECS field.
+func addECSResource(document *objmodel.Document, resource pcommon.Resource, scope pcommon.InstrumentationScope) {
+	addECSAttributes(document, resource.Attributes(), ecsResourceFields)
+	document.AddString("service.framework.name", scope.Name())
+	document.AddString("service.framework.version", scope.Version())
+}
+
+// addECSAttributes adds the attributes having an ECS field to the document under that field, and
+// the other ones under labels, with their dots replaced by underscores as ECS labels are flat.
+func addECSAttributes(document *objmodel.Document, attributes pcommon.Map, fields map[string]string) {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		if field, ok := fields[k]; ok {
+			document.AddAttribute(field, v)
+		} else {
+			document.AddAttribute("labels."+strings.ReplaceAll(k, ".", "_"), v)
+		}
+		return true
+	})
+}
+
+func ecsOutcome(code ptrace.StatusCode) string {
+	switch code {
+	case ptrace.StatusCodeOk:
+		return "success"
+	case ptrace.StatusCodeError:
+		return "failure"
+	default:
+		return "unknown"
+	}
+}
+
+// histogramValues returns a representative value of each non-empty bucket of an explicit bucket
+// histogram with its count, in strictly increasing order: the midpoint of the bucket, or its only
+// finite bound for the underflow and the overflow buckets. A histogram without bounds has a single
+// bucket, represented by the mean of the histogram when its sum is known.
+func histogramValues(dp pmetric.HistogramDataPoint) ([]float64, []uint64) {
+	bounds := dp.MExplicitBounds()
+
+	var values []float64
+	var counts []uint64
+	for i, count := range dp.MBucketCounts() {
+		if count == 0 {
+			continue
+		}
+		var value float64
+		switch {
+		case len(bounds) == 0:
+			if dp.HasSum() && dp.Count() != 0 {
+				value = dp.Sum() / float64(dp.Count())
+			}
+		case i == 0:
+			value = bounds[0]
+		case i >= len(bounds):
+			value = bounds[len(bounds)-1]
+		default:
+			value = bounds[i-1] + (bounds[i]-bounds[i-1])/2
+		}
+		// The underflow and the overflow buckets share the bound of a histogram with a single
+		// bound, the overflow value is then the next float after it.
+		if n := len(values); n != 0 && value <= values[n-1] {
+			value = math.Nextafter(values[n-1], math.Inf(1))
+		}
+		values = append(values, value)
+		counts = append(counts, count)
+	}
+	return values, counts
+}
+
+// exponentialHistogramValues returns the midpoint of each non-empty bucket of an exponential
+// histogram with its count, in increasing order.
+func exponentialHistogramValues(dp pmetric.ExponentialHistogramDataPoint) ([]float64, []uint64) {
+	var values []float64
+	var counts []uint64
+	base := math.Exp2(math.Exp2(-float64(dp.Scale())))
+	midpoint := func(index int) float64 {
+		lower := math.Pow(base, float64(index))
+		return lower + (lower*base-lower)/2
+	}
+
+	negative := dp.Negative().MBucketCounts()
+	for i := len(negative) - 1; i >= 0; i-- {
+		if negative[i] != 0 {
+			values = append(values, -midpoint(int(dp.Negative().Offset())+i))
+			counts = append(counts, negative[i])
+		}
+	}
+	if dp.ZeroCount() != 0 {
+		values = append(values, 0)
+		counts = append(counts, dp.ZeroCount())
+	}
+	for i, count := range dp.Positive().MBucketCounts() {
+		if count != 0 {
+			values = append(values, midpoint(int(dp.Positive().Offset())+i))
+			counts = append(counts, count)
+		}
+	}
+	return values, counts
+}
+
+func uint64Values(values []uint64) objmodel.Value {
+	arr := make([]objmodel.Value, 0, len(values))
+	for _, v := range values {
+		arr = append(arr, objmodel.IntValue(int64(v)))
+	}
+	return objmodel.ArrValue(arr...)
+}
+
+func float64Values(values []float64) objmodel.Value {
+	arr := make([]objmodel.Value, 0, len(values))
+	for _, v := range values {
+		arr = append(arr, objmodel.DoubleValue(v))
+	}
+	return objmodel.ArrValue(arr...)
+}
@@ -0,0 +1,464 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	testStart = pcommon.NewTimestampFromTime(time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC))
+	testEnd   = pcommon.NewTimestampFromTime(time.Date(2022, 7, 1, 10, 0, 1, 0, time.UTC))
+)
+
+func TestEncodeSpan(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: false}
+
+	span := ptrace.NewSpan()
+	span.SetName("GET /users")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{2}))
+	span.SetParentSpanID(pcommon.NewSpanID([8]byte{3}))
+	span.SetStartTimestamp(testStart)
+	span.SetEndTimestamp(testEnd)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("failed")
+	span.Attributes().InsertString("http.method", "GET")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(testStart)
+	event.Attributes().InsertString("exception.type", "Error")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.NewTraceID([16]byte{4}))
+	link.SetSpanID(pcommon.NewSpanID([8]byte{5}))
+
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertString("service.name", "users")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("net/http")
+
+	// test
+	doc, err := model.encodeSpan(resource, scope, span)
+
+	// verify
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2022-07-01T10:00:00.000000000Z",
+		"Attributes.http.method": "GET",
+		"Duration": 1000000000,
+		"EndTimestamp": "2022-07-01T10:00:01.000000000Z",
+		"Events": [{
+			"Attributes": {"exception": {"type": "Error"}},
+			"Name": "exception",
+			"Timestamp": "2022-07-01T10:00:00.000000000Z"
+		}],
+		"Kind": "SPAN_KIND_SERVER",
+		"Links": [{
+			"SpanId": "0500000000000000",
+			"TraceId": "04000000000000000000000000000000"
+		}],
+		"Name": "GET /users",
+		"ParentSpanId": "0300000000000000",
+		"Resource.service.name": "users",
+		"Scope.Name": "net/http",
+		"SpanId": "0200000000000000",
+		"TraceId": "01000000000000000000000000000000",
+		"TraceStatus": "STATUS_CODE_ERROR",
+		"TraceStatusDescription": "failed"
+	}`, string(doc))
+}
+
+func TestEncodeSpanECS(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: true, mode: MappingECS}
+
+	span := ptrace.NewSpan()
+	span.SetName("GET /users")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{2}))
+	span.SetParentSpanID(pcommon.NewSpanID([8]byte{3}))
+	span.SetStartTimestamp(testStart)
+	span.SetEndTimestamp(testEnd)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("failed")
+	span.Attributes().InsertString("http.method", "GET")
+	span.Attributes().InsertInt("http.status_code", 500)
+	span.Attributes().InsertString("user.tier", "premium")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(testStart)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.NewTraceID([16]byte{4}))
+	link.SetSpanID(pcommon.NewSpanID([8]byte{5}))
+
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertString("service.name", "users")
+	resource.Attributes().InsertString("service.instance.id", "users-1")
+	resource.Attributes().InsertString("host.name", "localhost")
+	resource.Attributes().InsertString("k8s.pod.name", "users-5d9f")
+	resource.Attributes().InsertString("team.name", "identity")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("net/http")
+
+	// test
+	doc, err := model.encodeSpan(resource, scope, span)
+
+	// verify
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2022-07-01T10:00:00.000000000Z",
+		"error": {"message": "failed"},
+		"event": {
+			"duration": 1000000000,
+			"end": "2022-07-01T10:00:01.000000000Z",
+			"outcome": "failure"
+		},
+		"host": {"hostname": "localhost"},
+		"http": {
+			"request": {"method": "GET"},
+			"response": {"status_code": 500}
+		},
+		"kubernetes": {"pod": {"name": "users-5d9f"}},
+		"labels": {"team_name": "identity", "user_tier": "premium"},
+		"parent": {"id": "0300000000000000"},
+		"service": {
+			"framework": {"name": "net/http"},
+			"name": "users",
+			"node": {"name": "users-1"}
+		},
+		"span": {
+			"events": [{"@timestamp": "2022-07-01T10:00:00.000000000Z", "name": "exception"}],
+			"id": "0200000000000000",
+			"kind": "server",
+			"links": [{"span": {"id": "0500000000000000"}, "trace": {"id": "04000000000000000000000000000000"}}],
+			"name": "GET /users"
+		},
+		"trace": {"id": "01000000000000000000000000000000"}
+	}`, string(doc))
+}
+
+func TestEncodeMetric(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: true}
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertString("service.name", "users")
+	scope := pcommon.NewInstrumentationScope()
+
+	tests := map[string]struct {
+		metric func() pmetric.Metric
+		want   []string
+	}{
+		"sum": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("requests")
+				metric.SetUnit("1")
+				metric.SetDataType(pmetric.MetricDataTypeSum)
+				metric.Sum().SetIsMonotonic(true)
+				metric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+				for _, method := range []string{"GET", "POST"} {
+					dp := metric.Sum().DataPoints().AppendEmpty()
+					dp.SetTimestamp(testEnd)
+					dp.SetIntVal(42)
+					dp.Attributes().InsertString("method", method)
+				}
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"AggregationTemporality": "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				"Attributes": {"method": "GET"},
+				"IsMonotonic": true,
+				"Name": "requests",
+				"Resource": {"service": {"name": "users"}},
+				"StartTimestamp": "1970-01-01T00:00:00.000000000Z",
+				"Type": "Sum",
+				"Unit": "1",
+				"Value": 42
+			}`, `{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"AggregationTemporality": "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				"Attributes": {"method": "POST"},
+				"IsMonotonic": true,
+				"Name": "requests",
+				"Resource": {"service": {"name": "users"}},
+				"StartTimestamp": "1970-01-01T00:00:00.000000000Z",
+				"Type": "Sum",
+				"Unit": "1",
+				"Value": 42
+			}`},
+		},
+		"histogram": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("latency")
+				metric.SetDataType(pmetric.MetricDataTypeHistogram)
+				metric.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+				dp := metric.Histogram().DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(testStart)
+				dp.SetTimestamp(testEnd)
+				dp.SetCount(3)
+				dp.SetSum(1.5)
+				dp.SetMBucketCounts([]uint64{1, 2})
+				dp.SetMExplicitBounds([]float64{0.5})
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"AggregationTemporality": "AGGREGATION_TEMPORALITY_DELTA",
+				"BucketCounts": [1, 2],
+				"Count": 3,
+				"ExplicitBounds": [0.5],
+				"Name": "latency",
+				"Resource": {"service": {"name": "users"}},
+				"StartTimestamp": "2022-07-01T10:00:00.000000000Z",
+				"Sum": 1.5,
+				"Type": "Histogram"
+			}`},
+		},
+		"summary": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("latency")
+				metric.SetDataType(pmetric.MetricDataTypeSummary)
+				dp := metric.Summary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testEnd)
+				dp.SetCount(3)
+				dp.SetSum(1.5)
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(0.99)
+				qv.SetValue(0.9)
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"Count": 3,
+				"Name": "latency",
+				"Quantiles": [{"Quantile": 0.99, "Value": 0.9}],
+				"Resource": {"service": {"name": "users"}},
+				"StartTimestamp": "1970-01-01T00:00:00.000000000Z",
+				"Sum": 1.5,
+				"Type": "Summary"
+			}`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// test
+			docs, err := model.encodeMetric(resource, scope, test.metric())
+
+			// verify
+			require.NoError(t, err)
+			require.Len(t, docs, len(test.want))
+			for i, want := range test.want {
+				assert.JSONEq(t, want, string(docs[i]))
+			}
+		})
+	}
+}
+
+func TestEncodeMetricECS(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: true, mode: MappingECS}
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertString("service.name", "users")
+	scope := pcommon.NewInstrumentationScope()
+
+	tests := map[string]struct {
+		metric func() pmetric.Metric
+		want   []string
+	}{
+		"sum": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("http.server.requests")
+				metric.SetDataType(pmetric.MetricDataTypeSum)
+				dp := metric.Sum().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testEnd)
+				dp.SetIntVal(42)
+				dp.Attributes().InsertString("http.method", "GET")
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"http": {"server": {"requests": 42}},
+				"labels": {"http_method": "GET"},
+				"service": {"name": "users"}
+			}`},
+		},
+		"histogram": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("latency")
+				metric.SetDataType(pmetric.MetricDataTypeHistogram)
+				dp := metric.Histogram().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testEnd)
+				dp.SetMBucketCounts([]uint64{1, 0, 2, 3})
+				dp.SetMExplicitBounds([]float64{1, 2, 4})
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"latency": {"counts": [1, 2, 3], "values": [1, 3, 4]},
+				"service": {"name": "users"}
+			}`},
+		},
+		"exponential histogram": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("latency")
+				metric.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+				dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testEnd)
+				dp.SetScale(0)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(1)
+				dp.Positive().SetMBucketCounts([]uint64{2, 0, 3})
+				dp.Negative().SetMBucketCounts([]uint64{4})
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"latency": {"counts": [4, 1, 2, 3], "values": [-1.5, 0, 3, 12]},
+				"service": {"name": "users"}
+			}`},
+		},
+		"summary": {
+			metric: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("latency")
+				metric.SetDataType(pmetric.MetricDataTypeSummary)
+				dp := metric.Summary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testEnd)
+				dp.SetCount(3)
+				dp.SetSum(1.5)
+				return metric
+			},
+			want: []string{`{
+				"@timestamp": "2022-07-01T10:00:01.000000000Z",
+				"latency": {"sum": 1.5, "value_count": 3},
+				"service": {"name": "users"}
+			}`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// test
+			docs, err := model.encodeMetric(resource, scope, test.metric())
+
+			// verify
+			require.NoError(t, err)
+			require.Len(t, docs, len(test.want))
+			for i, want := range test.want {
+				assert.JSONEq(t, want, string(docs[i]))
+			}
+		})
+	}
+}
+
+func TestHistogramValues(t *testing.T) {
+	tests := map[string]struct {
+		counts     []uint64
+		bounds     []float64
+		sum        float64
+		wantValues []float64
+		wantCounts []uint64
+	}{
+		"no bounds": {
+			counts:     []uint64{4},
+			sum:        10,
+			wantValues: []float64{2.5},
+			wantCounts: []uint64{4},
+		},
+		"single bound": {
+			counts:     []uint64{1, 2},
+			bounds:     []float64{5},
+			sum:        12,
+			wantValues: []float64{5, math.Nextafter(5, math.Inf(1))},
+			wantCounts: []uint64{1, 2},
+		},
+		"single bound with empty underflow": {
+			counts:     []uint64{0, 2},
+			bounds:     []float64{5},
+			sum:        12,
+			wantValues: []float64{5},
+			wantCounts: []uint64{2},
+		},
+		"multiple bounds": {
+			counts:     []uint64{1, 0, 2, 3},
+			bounds:     []float64{1, 2, 4},
+			sum:        20,
+			wantValues: []float64{1, 3, 4},
+			wantCounts: []uint64{1, 2, 3},
+		},
+		"equal bounds": {
+			counts:     []uint64{1, 2, 3},
+			bounds:     []float64{1, 1},
+			sum:        6,
+			wantValues: []float64{1, math.Nextafter(1, math.Inf(1)), math.Nextafter(math.Nextafter(1, math.Inf(1)), math.Inf(1))},
+			wantCounts: []uint64{1, 2, 3},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			dp := pmetric.NewHistogramDataPoint()
+			dp.SetMBucketCounts(test.counts)
+			dp.SetMExplicitBounds(test.bounds)
+			var count uint64
+			for _, c := range test.counts {
+				count += c
+			}
+			dp.SetCount(count)
+			dp.SetSum(test.sum)
+
+			// test
+			values, counts := histogramValues(dp)
+
+			// verify
+			assert.Equal(t, test.wantValues, values)
+			assert.Equal(t, test.wantCounts, counts)
+		})
+	}
+}
+
+func TestHistogramValuesWithoutBoundsAndSum(t *testing.T) {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.SetCount(4)
+	dp.SetMBucketCounts([]uint64{4})
+
+	values, counts := histogramValues(dp)
+
+	assert.Equal(t, []float64{0}, values)
+	assert.Equal(t, []uint64{4}, counts)
+}
+
+func TestEncodeMetricUnsupportedType(t *testing.T) {
+	model := &encodeModel{}
+
+	_, err := model.encodeMetric(pcommon.NewResource(), pcommon.NewInstrumentationScope(), pmetric.NewMetric())
+
+	assert.EqualError(t, err, `unsupported metric type "None"`)
+}
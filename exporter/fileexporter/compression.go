@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bytes"
+	"compress/gzip"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdEncoder is shared by all the exporters, EncodeAll is safe for concurrent use.
+var zstdEncoder, _ = zstd.NewWriter(nil)
+
+// compress compresses the message with the given codec, or returns it unchanged when no codec is set.
+func compress(compression string, buf []byte) ([]byte, error) {
+	switch compression {
+	case compressionGzip:
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		if _, err := w.Write(buf); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case compressionZSTD:
+		return zstdEncoder.EncodeAll(buf, make([]byte, 0, len(buf))), nil
+	default:
+		return buf, nil
+	}
+}
@@ -16,16 +16,65 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	compressionGzip = "gzip"
+	compressionZSTD = "zstd"
 )
 
 // Config defines configuration for file exporter.
 type Config struct {
-	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	config.ExporterSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.QueueSettings `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
 
 	// Path of the file to write to. Path is relative to current directory.
 	Path string `mapstructure:"path"`
+
+	// Rotation defines an option about rotation of telemetry files.
+	// Rotation is disabled when not set.
+	Rotation *Rotation `mapstructure:"rotation"`
+
+	// FormatType defines the data format of encoded telemetry data.
+	// Options: json (default), proto.
+	FormatType string `mapstructure:"format"`
+
+	// Compression codec applied to every exported message, so that the file is a valid gzip or zstd stream.
+	// Options: gzip, zstd. No compression by default.
+	Compression string `mapstructure:"compression"`
+}
+
+// Rotation an option to rolling log files.
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of the file before it gets
+	// rotated. It defaults to 100 megabytes.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Interval is the maximum age of the current file before it gets rotated.
+	// 0 disables the time based rotation.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxDays is the maximum number of days to retain old files based on the
+	// timestamp encoded in their filename. The default is not to remove old files
+	// based on age.
+	MaxDays int `mapstructure:"max_days"`
+
+	// MaxBackups is the maximum number of old files to retain. The default
+	// is to retain all old files (though MaxDays may still cause them to get deleted).
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// LocalTime determines if the time used for formatting the timestamps in
+	// backup files is the computer's local time. The default is to use UTC time.
+	LocalTime bool `mapstructure:"localtime"`
 }
 
 var _ config.Exporter = (*Config)(nil)
@@ -35,6 +84,20 @@ func (cfg *Config) Validate() error {
 	if cfg.Path == "" {
 		return errors.New("path must be non-empty")
 	}
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+		return fmt.Errorf("format type %q is not supported, must be %q or %q", cfg.FormatType, formatTypeJSON, formatTypeProto)
+	}
+	if cfg.Compression != "" && cfg.Compression != compressionGzip && cfg.Compression != compressionZSTD {
+		return fmt.Errorf("compression %q is not supported, must be %q or %q", cfg.Compression, compressionGzip, compressionZSTD)
+	}
+	if cfg.Rotation != nil {
+		if cfg.Rotation.MaxMegabytes < 0 || cfg.Rotation.MaxDays < 0 || cfg.Rotation.MaxBackups < 0 || cfg.Rotation.Interval < 0 {
+			return errors.New("rotation settings must not be negative")
+		}
+	}
+	if err := cfg.QueueSettings.Validate(); err != nil {
+		return fmt.Errorf("queue settings has invalid configuration: %w", err)
+	}
 
 	return nil
 }
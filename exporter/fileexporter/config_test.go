@@ -17,11 +17,13 @@ package fileexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -42,6 +44,88 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, e1,
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
+			QueueSettings:    exporterhelper.QueueSettings{Enabled: false, NumConsumers: 10, QueueSize: 5000},
+			RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
 			Path:             "./filename.json",
+			FormatType:       formatTypeJSON,
 		})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+			QueueSettings: exporterhelper.QueueSettings{
+				Enabled:      true,
+				NumConsumers: 2,
+				QueueSize:    100,
+			},
+			RetrySettings: exporterhelper.RetrySettings{
+				Enabled:         true,
+				InitialInterval: time.Second,
+				MaxInterval:     10 * time.Second,
+				MaxElapsedTime:  time.Minute,
+			},
+			Path:        "./filename.pb",
+			FormatType:  formatTypeProto,
+			Compression: compressionZSTD,
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				Interval:     24 * time.Hour,
+				MaxDays:      3,
+				MaxBackups:   3,
+				LocalTime:    true,
+			},
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{Path: "./filename.json", FormatType: formatTypeJSON},
+		},
+		{
+			name:   "no path",
+			cfg:    &Config{FormatType: formatTypeJSON},
+			errMsg: "path must be non-empty",
+		},
+		{
+			name:   "unknown format",
+			cfg:    &Config{Path: "./filename.json", FormatType: "xml"},
+			errMsg: `format type "xml" is not supported, must be "json" or "proto"`,
+		},
+		{
+			name:   "unknown compression",
+			cfg:    &Config{Path: "./filename.json", FormatType: formatTypeJSON, Compression: "lz4"},
+			errMsg: `compression "lz4" is not supported, must be "gzip" or "zstd"`,
+		},
+		{
+			name: "invalid queue",
+			cfg: &Config{
+				Path:          "./filename.json",
+				FormatType:    formatTypeJSON,
+				QueueSettings: exporterhelper.QueueSettings{Enabled: true, QueueSize: 0},
+			},
+			errMsg: "queue settings has invalid configuration: queue size must be positive",
+		},
+		{
+			name:   "negative rotation",
+			cfg:    &Config{Path: "./filename.json", FormatType: formatTypeJSON, Rotation: &Rotation{MaxBackups: -1}},
+			errMsg: "rotation settings must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
 }
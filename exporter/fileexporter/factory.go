@@ -42,8 +42,14 @@ func NewFactory() component.ExporterFactory {
 }
 
 func createDefaultConfig() config.Exporter {
+	// The telemetry is written synchronously unless the queue is enabled
+	queueSettings := exporterhelper.NewDefaultQueueSettings()
+	queueSettings.Enabled = false
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		QueueSettings:    queueSettings,
+		RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
+		FormatType:       formatTypeJSON,
 	}
 }
 
@@ -53,7 +59,7 @@ func createTracesExporter(
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(cfg.(*Config))
 	})
 	return exporterhelper.NewTracesExporter(
 		cfg,
@@ -61,6 +67,8 @@ func createTracesExporter(
 		fe.Unwrap().(*fileExporter).ConsumeTraces,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
+		exporterhelper.WithQueue(cfg.(*Config).QueueSettings),
+		exporterhelper.WithRetry(cfg.(*Config).RetrySettings),
 	)
 }
 
@@ -70,7 +78,7 @@ func createMetricsExporter(
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(cfg.(*Config))
 	})
 	return exporterhelper.NewMetricsExporter(
 		cfg,
@@ -78,6 +86,8 @@ func createMetricsExporter(
 		fe.Unwrap().(*fileExporter).ConsumeMetrics,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
+		exporterhelper.WithQueue(cfg.(*Config).QueueSettings),
+		exporterhelper.WithRetry(cfg.(*Config).RetrySettings),
 	)
 }
 
@@ -87,7 +97,7 @@ func createLogsExporter(
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(cfg.(*Config))
 	})
 	return exporterhelper.NewLogsExporter(
 		cfg,
@@ -95,6 +105,8 @@ func createLogsExporter(
 		fe.Unwrap().(*fileExporter).ConsumeLogs,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
+		exporterhelper.WithQueue(cfg.(*Config).QueueSettings),
+		exporterhelper.WithRetry(cfg.(*Config).RetrySettings),
 	)
 }
 
@@ -16,15 +16,18 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"encoding/binary"
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Marshaler configuration used for marhsaling Protobuf to JSON.
@@ -32,12 +35,33 @@ var tracesMarshaler = ptrace.NewJSONMarshaler()
 var metricsMarshaler = pmetric.NewJSONMarshaler()
 var logsMarshaler = plog.NewJSONMarshaler()
 
+// Marshaler configuration used for marhsaling to Protobuf.
+var tracesProtoMarshaler = ptrace.NewProtoMarshaler()
+var metricsProtoMarshaler = pmetric.NewProtoMarshaler()
+var logsProtoMarshaler = plog.NewProtoMarshaler()
+
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// in Protobuf-JSON format by default, or in Protobuf format.
 type fileExporter struct {
 	path  string
 	file  io.WriteCloser
 	mutex sync.Mutex
+
+	formatType  string
+	compression string
+	rotation    *Rotation
+
+	stopRotation chan struct{}
+	rotationWg   sync.WaitGroup
+}
+
+func newFileExporter(cfg *Config) *fileExporter {
+	return &fileExporter{
+		path:        cfg.Path,
+		formatType:  cfg.FormatType,
+		compression: cfg.Compression,
+		rotation:    cfg.Rotation,
+	}
 }
 
 func (e *fileExporter) Capabilities() consumer.Capabilities {
@@ -45,49 +69,117 @@ func (e *fileExporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
-	buf, err := tracesMarshaler.MarshalTraces(td)
+	var marshaler ptrace.Marshaler = tracesMarshaler
+	if e.formatType == formatTypeProto {
+		marshaler = tracesProtoMarshaler
+	}
+	buf, err := marshaler.MarshalTraces(td)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.export(buf)
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
-	buf, err := metricsMarshaler.MarshalMetrics(md)
+	var marshaler pmetric.Marshaler = metricsMarshaler
+	if e.formatType == formatTypeProto {
+		marshaler = metricsProtoMarshaler
+	}
+	buf, err := marshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.export(buf)
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
-	buf, err := logsMarshaler.MarshalLogs(ld)
+	var marshaler plog.Marshaler = logsMarshaler
+	if e.formatType == formatTypeProto {
+		marshaler = logsProtoMarshaler
+	}
+	buf, err := marshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.export(buf)
 }
 
-func exportMessageAsLine(e *fileExporter, buf []byte) error {
+// export writes the message as a line when it is JSON, and as a length-delimited message otherwise.
+// When a compression is set, every message is compressed with its framing as an independent gzip member
+// or zstd frame, so that the file is a valid gzip or zstd stream of the same content as without compression.
+func (e *fileExporter) export(buf []byte) error {
+	if e.formatType == formatTypeProto {
+		buf = lengthDelimited(buf)
+	} else {
+		buf = append(buf, '\n')
+	}
+	buf, err := compress(e.compression, buf)
+	if err != nil {
+		return err
+	}
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	_, err = e.file.Write(buf)
+	return err
+}
+
+// lengthDelimited prefixes the message with its length as a 4 bytes big endian integer.
+func lengthDelimited(buf []byte) []byte {
+	data := make([]byte, 4, 4+len(buf))
+	binary.BigEndian.PutUint32(data, uint32(len(buf)))
+	return append(data, buf...)
+}
+
+func (e *fileExporter) Start(context.Context, component.Host) error {
+	if e.rotation == nil {
+		var err error
+		e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
-		return err
+
+	logger := &lumberjack.Logger{
+		Filename:   e.path,
+		MaxSize:    e.rotation.MaxMegabytes,
+		MaxAge:     e.rotation.MaxDays,
+		MaxBackups: e.rotation.MaxBackups,
+		LocalTime:  e.rotation.LocalTime,
+	}
+	e.file = logger
+	if e.rotation.Interval > 0 {
+		e.stopRotation = make(chan struct{})
+		e.rotationWg.Add(1)
+		go e.rotateEvery(logger, e.rotation.Interval)
 	}
 	return nil
 }
 
-func (e *fileExporter) Start(context.Context, component.Host) error {
-	var err error
-	e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	return err
+// rotateEvery rotates the file on every interval until the exporter is shut down.
+func (e *fileExporter) rotateEvery(logger *lumberjack.Logger, interval time.Duration) {
+	defer e.rotationWg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.mutex.Lock()
+			_ = logger.Rotate()
+			e.mutex.Unlock()
+		case <-e.stopRotation:
+			return
+		}
+	}
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (e *fileExporter) Shutdown(context.Context) error {
+	if e.stopRotation != nil {
+		close(e.stopRotation)
+		e.rotationWg.Wait()
+		e.stopRotation = nil
+	}
+	if e.file == nil {
+		return nil
+	}
 	return e.file.Close()
 }
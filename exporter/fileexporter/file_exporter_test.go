@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//       http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -14,12 +14,18 @@
 package fileexporter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -114,6 +120,131 @@ func TestFileLogsExporterErrors(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 }
 
+func TestFileExporterFormatAndCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		formatType  string
+		compression string
+		unmarshaler ptrace.Unmarshaler
+	}{
+		{
+			name:        "proto",
+			formatType:  formatTypeProto,
+			unmarshaler: ptrace.NewProtoUnmarshaler(),
+		},
+		{
+			name:        "json",
+			formatType:  formatTypeJSON,
+			unmarshaler: ptrace.NewJSONUnmarshaler(),
+		},
+		{
+			name:        "json with gzip",
+			formatType:  formatTypeJSON,
+			compression: compressionGzip,
+			unmarshaler: ptrace.NewJSONUnmarshaler(),
+		},
+		{
+			name:        "proto with zstd",
+			formatType:  formatTypeProto,
+			compression: compressionZSTD,
+			unmarshaler: ptrace.NewProtoUnmarshaler(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fe := newFileExporter(&Config{
+				Path:        tempFileName(t),
+				FormatType:  tt.formatType,
+				Compression: tt.compression,
+			})
+
+			td := testdata.GenerateTracesTwoSpansSameResource()
+			assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+			assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+			assert.NoError(t, fe.Shutdown(context.Background()))
+
+			// The whole file is a valid stream of the compression
+			buf, err := ioutil.ReadFile(fe.path)
+			require.NoError(t, err)
+			buf = decompress(t, tt.compression, buf)
+			for i := 0; i < 2; i++ {
+				var msg []byte
+				if tt.formatType == formatTypeProto {
+					require.True(t, len(buf) >= 4)
+					size := binary.BigEndian.Uint32(buf[:4])
+					msg, buf = buf[4:4+size], buf[4+size:]
+				} else {
+					end := bytes.IndexByte(buf, '\n')
+					require.True(t, end >= 0)
+					msg, buf = buf[:end], buf[end+1:]
+				}
+
+				got, err := tt.unmarshaler.UnmarshalTraces(msg)
+				require.NoError(t, err)
+				assert.EqualValues(t, td, got)
+			}
+			assert.Empty(t, buf)
+		})
+	}
+}
+
+func TestFileExporterRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	fe := newFileExporter(&Config{
+		Path:       path,
+		FormatType: formatTypeJSON,
+		Rotation: &Rotation{
+			Interval:   10 * time.Millisecond,
+			MaxBackups: 1,
+		},
+	})
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	require.Eventually(t, func() bool {
+		files, err := ioutil.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		return len(files) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	for _, file := range files {
+		if file.Size() == 0 {
+			continue
+		}
+		buf, err := ioutil.ReadFile(filepath.Join(filepath.Dir(path), file.Name()))
+		require.NoError(t, err)
+		got, err := plog.NewJSONUnmarshaler().UnmarshalLogs(buf)
+		require.NoError(t, err)
+		assert.EqualValues(t, ld, got)
+	}
+}
+
+func decompress(t *testing.T, compression string, buf []byte) []byte {
+	switch compression {
+	case compressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(buf))
+		require.NoError(t, err)
+		out, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		return out
+	case compressionZSTD:
+		r, err := zstd.NewReader(nil)
+		require.NoError(t, err)
+		defer r.Close()
+		out, err := r.DecodeAll(buf, nil)
+		require.NoError(t, err)
+		return out
+	default:
+		return buf
+	}
+}
+
 // tempFileName provides a temporary file name for testing.
 func tempFileName(t *testing.T) string {
 	tmpfile, err := ioutil.TempFile("", "*.json")
@@ -15,15 +15,46 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 )
 
+const (
+	// serviceRoutingKey routes metrics by the service name of their resource.
+	serviceRoutingKey = "service"
+	// resourceRoutingKey routes metrics by the attribute set of their resource.
+	resourceRoutingKey = "resource"
+	// metricRoutingKey routes each metric series by its name and data point attributes.
+	metricRoutingKey = "metric"
+)
+
+var errUnknownRoutingKey = errors.New("unknown routing key")
+
 // Config defines configuration for the exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
+
+	// RoutingKey defines how metrics are assigned to a backend, one of
+	// "service" (default), "resource" or "metric". Traces and logs are
+	// always routed by trace ID.
+	RoutingKey string `mapstructure:"routing_key"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.RoutingKey {
+	case "", serviceRoutingKey, resourceRoutingKey, metricRoutingKey:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, must be one of %q, %q or %q", errUnknownRoutingKey, cfg.RoutingKey, serviceRoutingKey, resourceRoutingKey, metricRoutingKey)
+	}
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -15,12 +15,14 @@
 package loadbalancingexporter
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -34,3 +36,26 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 }
+
+func TestValidateRoutingKey(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		err        error
+	}{
+		{"", nil},
+		{serviceRoutingKey, nil},
+		{resourceRoutingKey, nil},
+		{metricRoutingKey, nil},
+		{"traceID", errUnknownRoutingKey},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				RoutingKey:       tt.routingKey,
+			}
+
+			err := cfg.Validate()
+			assert.True(t, errors.Is(err, tt.err))
+		})
+	}
+}
@@ -40,6 +40,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		component.WithTracesExporterAndStabilityLevel(createTracesExporter, stability),
 		component.WithLogsExporterAndStabilityLevel(createLogsExporter, stability),
+		component.WithMetricsExporterAndStabilityLevel(createMetricsExporter, stability),
 	)
 }
 
@@ -52,6 +53,7 @@ func createDefaultConfig() config.Exporter {
 		Protocol: Protocol{
 			OTLP: *otlpDefaultCfg,
 		},
+		RoutingKey: serviceRoutingKey,
 	}
 }
 
@@ -62,3 +64,7 @@ func createTracesExporter(_ context.Context, params component.ExporterCreateSett
 func createLogsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
 	return newLogsExporter(params, cfg)
 }
+
+func createMetricsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	return newMetricsExporter(params, cfg)
+}
@@ -94,7 +94,8 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 		balancingKey = random()
 	}
 
-	endpoint := e.loadBalancer.Endpoint(balancingKey)
+	b := balancingKey.Bytes()
+	endpoint := e.loadBalancer.Endpoint(b[:])
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
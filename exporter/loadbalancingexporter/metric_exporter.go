@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   string
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routingKey := cfg.(*Config).RoutingKey
+	if routingKey == "" {
+		routingKey = serviceRoutingKey
+	}
+
+	return &metricExporterImp{
+		loadBalancer: lb,
+		routingKey:   routingKey,
+	}, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var batches map[string]pmetric.Metrics
+	if e.routingKey == metricRoutingKey {
+		batches = e.splitByMetric(md)
+	} else {
+		batches = e.splitByResource(md)
+	}
+
+	var errs error
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batch))
+	}
+
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+
+	if err == nil {
+		sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
+		stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// splitByResource groups whole resources by the endpoint of their routing key
+func (e *metricExporterImp) splitByResource(md pmetric.Metrics) map[string]pmetric.Metrics {
+	batches := map[string]pmetric.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+
+		var key bytes.Buffer
+		if e.routingKey == resourceRoutingKey {
+			writeAttributes(&key, rm.Resource().Attributes())
+		} else if svc, ok := rm.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+			key.WriteString(svc.AsString())
+		}
+
+		endpoint := e.loadBalancer.Endpoint(key.Bytes())
+		batch, found := batches[endpoint]
+		if !found {
+			batch = pmetric.NewMetrics()
+			batches[endpoint] = batch
+		}
+		rm.CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitByMetric groups the data points of every series, identified by the metric
+// name and the data point attributes, by the endpoint of the series
+func (e *metricExporterImp) splitByMetric(md pmetric.Metrics) map[string]pmetric.Metrics {
+	batches := map[string]*metricBatch{}
+	endpointFor := func(metric pmetric.Metric, attrs pcommon.Map) string {
+		var key bytes.Buffer
+		key.WriteString(metric.Name())
+		key.WriteByte(0)
+		writeAttributes(&key, attrs)
+		return e.loadBalancer.Endpoint(key.Bytes())
+	}
+	batchFor := func(endpoint string) *metricBatch {
+		batch, found := batches[endpoint]
+		if !found {
+			batch = newMetricBatch()
+			batches[endpoint] = batch
+		}
+		return batch
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				idx := metricIndex{resource: i, scope: j, metric: k}
+
+				switch metric.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest := batchFor(endpointFor(metric, dps.At(l).Attributes())).metric(md, idx)
+						dps.At(l).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest := batchFor(endpointFor(metric, dps.At(l).Attributes())).metric(md, idx)
+						dps.At(l).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest := batchFor(endpointFor(metric, dps.At(l).Attributes())).metric(md, idx)
+						dps.At(l).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest := batchFor(endpointFor(metric, dps.At(l).Attributes())).metric(md, idx)
+						dps.At(l).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest := batchFor(endpointFor(metric, dps.At(l).Attributes())).metric(md, idx)
+						dps.At(l).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+					}
+				}
+			}
+		}
+	}
+
+	res := make(map[string]pmetric.Metrics, len(batches))
+	for endpoint, batch := range batches {
+		res[endpoint] = batch.md
+	}
+	return res
+}
+
+// metricIndex locates a metric within the incoming pmetric.Metrics
+type metricIndex struct {
+	resource, scope, metric int
+}
+
+// metricBatch holds the data points routed to a single endpoint, keeping
+// the resource, scope and metric they were received with
+type metricBatch struct {
+	md        pmetric.Metrics
+	resources map[int]pmetric.ResourceMetrics
+	scopes    map[metricIndex]pmetric.ScopeMetrics
+	metrics   map[metricIndex]pmetric.Metric
+}
+
+func newMetricBatch() *metricBatch {
+	return &metricBatch{
+		md:        pmetric.NewMetrics(),
+		resources: map[int]pmetric.ResourceMetrics{},
+		scopes:    map[metricIndex]pmetric.ScopeMetrics{},
+		metrics:   map[metricIndex]pmetric.Metric{},
+	}
+}
+
+// metric returns the metric of the batch matching the one at idx in src,
+// creating it and its parents without any data point the first time
+func (b *metricBatch) metric(src pmetric.Metrics, idx metricIndex) pmetric.Metric {
+	if metric, found := b.metrics[idx]; found {
+		return metric
+	}
+
+	srcRM := src.ResourceMetrics().At(idx.resource)
+	rm, found := b.resources[idx.resource]
+	if !found {
+		rm = b.md.ResourceMetrics().AppendEmpty()
+		srcRM.Resource().CopyTo(rm.Resource())
+		rm.SetSchemaUrl(srcRM.SchemaUrl())
+		b.resources[idx.resource] = rm
+	}
+
+	srcSM := srcRM.ScopeMetrics().At(idx.scope)
+	scopeIdx := metricIndex{resource: idx.resource, scope: idx.scope}
+	sm, found := b.scopes[scopeIdx]
+	if !found {
+		sm = rm.ScopeMetrics().AppendEmpty()
+		srcSM.Scope().CopyTo(sm.Scope())
+		sm.SetSchemaUrl(srcSM.SchemaUrl())
+		b.scopes[scopeIdx] = sm
+	}
+
+	srcMetric := srcSM.Metrics().At(idx.metric)
+	metric := sm.Metrics().AppendEmpty()
+	metric.SetName(srcMetric.Name())
+	metric.SetDescription(srcMetric.Description())
+	metric.SetUnit(srcMetric.Unit())
+	metric.SetDataType(srcMetric.DataType())
+	switch srcMetric.DataType() {
+	case pmetric.MetricDataTypeSum:
+		metric.Sum().SetAggregationTemporality(srcMetric.Sum().AggregationTemporality())
+		metric.Sum().SetIsMonotonic(srcMetric.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		metric.Histogram().SetAggregationTemporality(srcMetric.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		metric.ExponentialHistogram().SetAggregationTemporality(srcMetric.ExponentialHistogram().AggregationTemporality())
+	}
+	b.metrics[idx] = metric
+	return metric
+}
+
+// writeAttributes writes the attributes sorted by key, so that the same
+// attribute set always results in the same routing key
+func writeAttributes(key *bytes.Buffer, attrs pcommon.Map) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		key.WriteString(k)
+		key.WriteByte('=')
+		key.WriteString(v.AsString())
+		key.WriteByte(0)
+	}
+}
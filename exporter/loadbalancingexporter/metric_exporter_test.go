@@ -0,0 +1,355 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:errcheck
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+			},
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterStart(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		me   *metricExporterImp
+		err  error
+	}{
+		{
+			"ok",
+			func() *metricExporterImp {
+				p, _ := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+				return p
+			}(),
+			nil,
+		},
+		{
+			"error",
+			func() *metricExporterImp {
+				// prepare
+				lb, _ := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), nil)
+				p, _ := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+
+				lb.res = &mockResolver{
+					onStart: func(context.Context) error {
+						return errors.New("some expected err")
+					},
+				}
+				p.loadBalancer = lb
+
+				return p
+			}(),
+			errors.New("some expected err"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := tt.me
+
+			// test
+			res := p.Start(context.Background(), componenttest.NewNopHost())
+			defer p.Shutdown(context.Background())
+
+			// verify
+			require.Equal(t, tt.err, res)
+		})
+	}
+}
+
+func TestMetricsExporterShutdown(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockMetricsExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockMetricsExporter()
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics("service-1"))
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockExporter()
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics("service-1"))
+
+	// verify
+	assert.Error(t, res)
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func TestMetricsRoutingKeys(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		batches    int
+	}{
+		{serviceRoutingKey, 2},
+		{resourceRoutingKey, 3},
+		// the series of the first two resources are the same
+		{metricRoutingKey, 2},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				Resolver: ResolverSettings{
+					Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2", "endpoint-3"}},
+				},
+				RoutingKey: tt.routingKey,
+			}
+
+			p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			// use the routing key itself as the endpoint, so that each key gets its own batch
+			lb := &mockLoadBalancer{
+				endpointFn: func(identifier []byte) string {
+					return string(identifier)
+				},
+				sinks: map[string]*consumertest.MetricsSink{},
+			}
+			p.loadBalancer = lb
+
+			md := simpleMetrics("service-1")
+			simpleMetrics("service-1").ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+			md.ResourceMetrics().At(1).Resource().Attributes().InsertString("host.name", "host-2")
+			simpleMetrics("service-2").ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+			// a second data point of the same series goes along with the first one
+			dps := md.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+			dps.At(0).CopyTo(dps.AppendEmpty())
+
+			// test
+			err = p.ConsumeMetrics(context.Background(), md)
+			require.NoError(t, err)
+
+			// verify
+			assert.Len(t, lb.sinks, tt.batches)
+			total := 0
+			for _, sink := range lb.sinks {
+				total += sink.DataPointCount()
+			}
+			assert.Equal(t, 4, total)
+		})
+	}
+}
+
+func TestMetricsSplitByMetric(t *testing.T) {
+	p := &metricExporterImp{
+		routingKey: metricRoutingKey,
+		loadBalancer: &mockLoadBalancer{
+			endpointFn: func(identifier []byte) string {
+				return string(identifier)
+			},
+		},
+	}
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "service-1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetUnit("1")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	first := m.Sum().DataPoints().AppendEmpty()
+	first.Attributes().InsertString("method", "GET")
+	first.SetIntVal(1)
+	second := m.Sum().DataPoints().AppendEmpty()
+	second.Attributes().InsertString("method", "POST")
+	second.SetIntVal(2)
+
+	// test
+	batches := p.splitByMetric(md)
+
+	// verify
+	require.Len(t, batches, 2)
+	for _, batch := range batches {
+		require.Equal(t, 1, batch.DataPointCount())
+		rm := batch.ResourceMetrics().At(0)
+		svc, _ := rm.Resource().Attributes().Get("service.name")
+		assert.Equal(t, "service-1", svc.StringVal())
+		sm := rm.ScopeMetrics().At(0)
+		assert.Equal(t, "scope", sm.Scope().Name())
+		m := sm.Metrics().At(0)
+		assert.Equal(t, "requests", m.Name())
+		assert.Equal(t, "1", m.Unit())
+		assert.True(t, m.Sum().IsMonotonic())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	}
+}
+
+func simpleMetrics(serviceName string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", serviceName)
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(serviceName + ".requests")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().DataPoints().AppendEmpty().SetIntVal(1)
+
+	return md
+}
+
+type mockLoadBalancer struct {
+	endpointFn func(identifier []byte) string
+	sinks      map[string]*consumertest.MetricsSink
+}
+
+func (lb *mockLoadBalancer) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (lb *mockLoadBalancer) Shutdown(context.Context) error {
+	return nil
+}
+
+func (lb *mockLoadBalancer) Endpoint(identifier []byte) string {
+	return lb.endpointFn(identifier)
+}
+
+func (lb *mockLoadBalancer) Exporter(endpoint string) (component.Exporter, error) {
+	sink, found := lb.sinks[endpoint]
+	if !found {
+		sink = new(consumertest.MetricsSink)
+		lb.sinks[endpoint] = sink
+	}
+	return newMockMetricsExporter(sink.ConsumeMetrics), nil
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumemetricsfn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumemetricsfn == nil {
+		return nil
+	}
+	return e.consumemetricsfn(ctx, md)
+}
+
+func newMockMetricsExporter(consumemetricsfn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumemetricsfn: consumemetricsfn,
+	}
+}
+
+func newNopMockMetricsExporter() component.MetricsExporter {
+	return newMockMetricsExporter(func(ctx context.Context, md pmetric.Metrics) error {
+		return nil
+	})
+}
@@ -101,7 +101,8 @@ func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) e
 		return errNoTracesInBatch
 	}
 
-	endpoint := e.loadBalancer.Endpoint(traceID)
+	balancingKey := traceID.Bytes()
+	endpoint := e.loadBalancer.Endpoint(balancingKey[:])
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
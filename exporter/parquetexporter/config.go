@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the directory the Parquet files are written to. Every table
+	// (traces, logs and one per metric data point type) gets its own sub-directory.
+	Path string `mapstructure:"path"`
+
+	// MaxFileSizeMiB is the approximate size in MiB at which a file is closed
+	// and a new one is started. Default is 128.
+	MaxFileSizeMiB int64 `mapstructure:"max_file_size_mib"`
+
+	// MaxFileAge is the longest time a file is kept open before it is closed
+	// and a new one is started, even when it is below MaxFileSizeMiB. Default is 5m.
+	MaxFileAge time.Duration `mapstructure:"max_file_age"`
+
+	// RowGroupSizeMiB is the size in MiB of the row groups buffered in memory
+	// before they are written out. Default is 16.
+	RowGroupSizeMiB int64 `mapstructure:"row_group_size_mib"`
+
+	// Compression is the codec used for the column chunks, one of
+	// "none", "snappy", "gzip" or "zstd". Default is "snappy".
+	Compression string `mapstructure:"compression"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+var (
+	errConfigNoPath           = errors.New("path must be non-empty")
+	errConfigMaxFileSize      = errors.New("max_file_size_mib must be positive")
+	errConfigMaxFileAge       = errors.New("max_file_age must be positive")
+	errConfigRowGroupSize     = errors.New("row_group_size_mib must be positive")
+	errConfigRowGroupTooLarge = errors.New("row_group_size_mib must not be larger than max_file_size_mib")
+)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() (err error) {
+	if cfg.Path == "" {
+		err = multierr.Append(err, errConfigNoPath)
+	}
+	if cfg.MaxFileSizeMiB <= 0 {
+		err = multierr.Append(err, errConfigMaxFileSize)
+	}
+	if cfg.MaxFileAge <= 0 {
+		err = multierr.Append(err, errConfigMaxFileAge)
+	}
+	if cfg.RowGroupSizeMiB <= 0 {
+		err = multierr.Append(err, errConfigRowGroupSize)
+	} else if cfg.RowGroupSizeMiB > cfg.MaxFileSizeMiB {
+		err = multierr.Append(err, errConfigRowGroupTooLarge)
+	}
+	if _, codecErr := cfg.compressionCodec(); codecErr != nil {
+		err = multierr.Append(err, codecErr)
+	}
+	return err
+}
+
+func (cfg *Config) compressionCodec() (parquet.CompressionCodec, error) {
+	switch cfg.Compression {
+	case compressionNone:
+		return parquet.CompressionCodec_UNCOMPRESSED, nil
+	case compressionSnappy, "":
+		return parquet.CompressionCodec_SNAPPY, nil
+	case compressionGzip:
+		return parquet.CompressionCodec_GZIP, nil
+	case compressionZstd:
+		return parquet.CompressionCodec_ZSTD, nil
+	}
+	return parquet.CompressionCodec_UNCOMPRESSED, fmt.Errorf("unsupported compression %q", cfg.Compression)
+}
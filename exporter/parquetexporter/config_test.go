@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := servicetest.LoadConfig(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters[config.NewComponentID(typeStr)].(*Config)
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Path = "/var/output/otel"
+	assert.Equal(t, defaultCfg, e0)
+	assert.NoError(t, e0.Validate())
+
+	e1 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "2")]
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
+			Path:             "/var/output/otel",
+			MaxFileSizeMiB:   256,
+			MaxFileAge:       time.Hour,
+			RowGroupSizeMiB:  32,
+			Compression:      compressionZstd,
+		}, e1)
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "invalid")].(*Config)
+	assert.EqualError(t, e2.Validate(), `unsupported compression "lzo"`)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.ErrorIs(t, cfg.Validate(), errConfigNoPath)
+
+	cfg.Path = "/var/output/otel"
+	cfg.RowGroupSizeMiB = cfg.MaxFileSizeMiB + 1
+	assert.ErrorIs(t, cfg.Validate(), errConfigRowGroupTooLarge)
+
+	cfg.RowGroupSizeMiB = 0
+	cfg.MaxFileAge = 0
+	err := cfg.Validate()
+	assert.ErrorIs(t, err, errConfigRowGroupSize)
+	assert.ErrorIs(t, err, errConfigMaxFileAge)
+}
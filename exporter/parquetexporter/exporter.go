@@ -16,33 +16,429 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 )
 
+// minRollCheckInterval bounds how often open files are checked against max_file_age.
+const minRollCheckInterval = 100 * time.Millisecond
+
+var tableSchemas = map[string]interface{}{
+	tableSpans:                new(spanRow),
+	tableLogs:                 new(logRow),
+	tableGauge:                new(gaugeRow),
+	tableSum:                  new(sumRow),
+	tableHistogram:            new(histogramRow),
+	tableExponentialHistogram: new(exponentialHistogramRow),
+	tableSummary:              new(summaryRow),
+}
+
 type parquetExporter struct {
-	path string
+	cfg    *Config
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	tables map[string]*tableWriter
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{
+		cfg:    cfg,
+		logger: logger,
+		tables: map[string]*tableWriter{},
+		stopCh: make(chan struct{}),
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(_ context.Context, _ component.Host) error {
+	interval := e.cfg.MaxFileAge / 2
+	if interval < minRollCheckInterval {
+		interval = minRollCheckInterval
+	}
+	e.wg.Add(1)
+	go e.rollExpiredFiles(interval)
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func (e *parquetExporter) shutdown(_ context.Context) error {
+	close(e.stopCh)
+	e.wg.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var errs error
+	for _, tw := range e.tables {
+		errs = multierr.Append(errs, tw.close())
+	}
+	return errs
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+// rollExpiredFiles makes sure files of idle tables are closed once they reach
+// max_file_age, so they become readable without waiting for new data.
+func (e *parquetExporter) rollExpiredFiles(interval time.Duration) {
+	defer e.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			for _, tw := range e.tableWriters() {
+				if err := tw.rollExpired(); err != nil {
+					e.logger.Error("Failed to roll over Parquet file", zap.String("table", tw.table), zap.Error(err))
+				}
+			}
+		}
+	}
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) tableWriters() []*tableWriter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	writers := make([]*tableWriter, 0, len(e.tables))
+	for _, tw := range e.tables {
+		writers = append(writers, tw)
+	}
+	return writers
+}
+
+func (e *parquetExporter) tableWriter(table string) (*tableWriter, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if tw, ok := e.tables[table]; ok {
+		return tw, nil
+	}
+	tw, err := newTableWriter(e.cfg, table, tableSchemas[table])
+	if err != nil {
+		return nil, err
+	}
+	e.tables[table] = tw
+	return tw, nil
+}
+
+func (e *parquetExporter) writeRows(table string, rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	tw, err := e.tableWriter(table)
+	if err != nil {
+		return err
+	}
+	return tw.write(rows)
+}
+
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	var rows []interface{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resAttrs := toAttributes(rs.Resource().Attributes())
+		svcName := serviceName(rs.Resource())
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			scope := ilss.At(j).Scope()
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				rows = append(rows, &spanRow{
+					Timestamp:          int64(span.StartTimestamp()),
+					EndTimestamp:       int64(span.EndTimestamp()),
+					DurationNanos:      int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					TraceID:            traceIDString(span.TraceID()),
+					SpanID:             spanIDString(span.SpanID()),
+					ParentSpanID:       spanIDString(span.ParentSpanID()),
+					TraceState:         string(span.TraceState()),
+					Name:               span.Name(),
+					Kind:               span.Kind().String(),
+					StatusCode:         span.Status().Code().String(),
+					StatusMessage:      span.Status().Message(),
+					ServiceName:        svcName,
+					ResourceAttributes: resAttrs,
+					ScopeName:          scope.Name(),
+					ScopeVersion:       scope.Version(),
+					SpanAttributes:     toAttributes(span.Attributes()),
+					Events:             toSpanEvents(span.Events()),
+					Links:              toSpanLinks(span.Links()),
+				})
+			}
+		}
+	}
+	return e.writeRows(tableSpans, rows)
+}
+
+func toSpanEvents(events ptrace.SpanEventSlice) []spanEvent {
+	out := make([]spanEvent, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		out[i] = spanEvent{
+			Timestamp:  int64(event.Timestamp()),
+			Name:       event.Name(),
+			Attributes: toAttributes(event.Attributes()),
+		}
+	}
+	return out
+}
+
+func toSpanLinks(links ptrace.SpanLinkSlice) []spanLink {
+	out := make([]spanLink, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		out[i] = spanLink{
+			TraceID:    traceIDString(link.TraceID()),
+			SpanID:     spanIDString(link.SpanID()),
+			TraceState: string(link.TraceState()),
+			Attributes: toAttributes(link.Attributes()),
+		}
+	}
+	return out
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	var rows []interface{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resAttrs := toAttributes(rl.Resource().Attributes())
+		svcName := serviceName(rl.Resource())
+		slls := rl.ScopeLogs()
+		for j := 0; j < slls.Len(); j++ {
+			scope := slls.At(j).Scope()
+			logs := slls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				rows = append(rows, &logRow{
+					Timestamp:          int64(lr.Timestamp()),
+					ObservedTimestamp:  int64(lr.ObservedTimestamp()),
+					TraceID:            traceIDString(lr.TraceID()),
+					SpanID:             spanIDString(lr.SpanID()),
+					TraceFlags:         int32(lr.Flags()),
+					SeverityText:       lr.SeverityText(),
+					SeverityNumber:     int32(lr.SeverityNumber()),
+					Body:               lr.Body().AsString(),
+					ServiceName:        svcName,
+					ResourceAttributes: resAttrs,
+					ScopeName:          scope.Name(),
+					ScopeVersion:       scope.Version(),
+					LogAttributes:      toAttributes(lr.Attributes()),
+				})
+			}
+		}
+	}
+	return e.writeRows(tableLogs, rows)
+}
+
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	rows := map[string][]interface{}{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resAttrs := toAttributes(rm.Resource().Attributes())
+		svcName := serviceName(rm.Resource())
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			scope := ilms.At(j).Scope()
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				base := metricRow{
+					ServiceName:        svcName,
+					ResourceAttributes: resAttrs,
+					ScopeName:          scope.Name(),
+					ScopeVersion:       scope.Version(),
+					MetricName:         metric.Name(),
+					MetricDescription:  metric.Description(),
+					MetricUnit:         metric.Unit(),
+				}
+				switch metric.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					rows[tableGauge] = appendGaugeRows(rows[tableGauge], base, metric.Gauge())
+				case pmetric.MetricDataTypeSum:
+					rows[tableSum] = appendSumRows(rows[tableSum], base, metric.Sum())
+				case pmetric.MetricDataTypeHistogram:
+					rows[tableHistogram] = appendHistogramRows(rows[tableHistogram], base, metric.Histogram())
+				case pmetric.MetricDataTypeExponentialHistogram:
+					rows[tableExponentialHistogram] = appendExponentialHistogramRows(rows[tableExponentialHistogram], base, metric.ExponentialHistogram())
+				case pmetric.MetricDataTypeSummary:
+					rows[tableSummary] = appendSummaryRows(rows[tableSummary], base, metric.Summary())
+				default:
+					e.logger.Debug("Unsupported metric type", zap.String("metric", metric.Name()), zap.String("type", metric.DataType().String()))
+				}
+			}
+		}
+	}
+
+	var errs error
+	for table, tableRows := range rows {
+		errs = multierr.Append(errs, e.writeRows(table, tableRows))
+	}
+	return errs
+}
+
+func withPoint(base metricRow, attrs pcommon.Map, start, ts pcommon.Timestamp, flags pmetric.MetricDataPointFlags) metricRow {
+	base.Attributes = toAttributes(attrs)
+	base.StartTimestamp = int64(start)
+	base.Timestamp = int64(ts)
+	if flags.HasFlag(pmetric.MetricDataPointFlagNoRecordedValue) {
+		base.Flags = int32(pmetric.MetricDataPointFlagNoRecordedValue)
+	}
+	return base
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+func appendGaugeRows(rows []interface{}, base metricRow, gauge pmetric.Gauge) []interface{} {
+	dps := gauge.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		b := withPoint(base, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+		rows = append(rows, &gaugeRow{
+			ServiceName:        b.ServiceName,
+			ResourceAttributes: b.ResourceAttributes,
+			ScopeName:          b.ScopeName,
+			ScopeVersion:       b.ScopeVersion,
+			MetricName:         b.MetricName,
+			MetricDescription:  b.MetricDescription,
+			MetricUnit:         b.MetricUnit,
+			Attributes:         b.Attributes,
+			StartTimestamp:     b.StartTimestamp,
+			Timestamp:          b.Timestamp,
+			Flags:              b.Flags,
+			Value:              numberValue(dp),
+		})
+	}
+	return rows
+}
+
+func appendSumRows(rows []interface{}, base metricRow, sum pmetric.Sum) []interface{} {
+	dps := sum.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		b := withPoint(base, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+		rows = append(rows, &sumRow{
+			ServiceName:            b.ServiceName,
+			ResourceAttributes:     b.ResourceAttributes,
+			ScopeName:              b.ScopeName,
+			ScopeVersion:           b.ScopeVersion,
+			MetricName:             b.MetricName,
+			MetricDescription:      b.MetricDescription,
+			MetricUnit:             b.MetricUnit,
+			Attributes:             b.Attributes,
+			StartTimestamp:         b.StartTimestamp,
+			Timestamp:              b.Timestamp,
+			Flags:                  b.Flags,
+			Value:                  numberValue(dp),
+			AggregationTemporality: sum.AggregationTemporality().String(),
+			IsMonotonic:            sum.IsMonotonic(),
+		})
+	}
+	return rows
+}
+
+func appendHistogramRows(rows []interface{}, base metricRow, histogram pmetric.Histogram) []interface{} {
+	dps := histogram.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		b := withPoint(base, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+		rows = append(rows, &histogramRow{
+			ServiceName:            b.ServiceName,
+			ResourceAttributes:     b.ResourceAttributes,
+			ScopeName:              b.ScopeName,
+			ScopeVersion:           b.ScopeVersion,
+			MetricName:             b.MetricName,
+			MetricDescription:      b.MetricDescription,
+			MetricUnit:             b.MetricUnit,
+			Attributes:             b.Attributes,
+			StartTimestamp:         b.StartTimestamp,
+			Timestamp:              b.Timestamp,
+			Flags:                  b.Flags,
+			Count:                  int64(dp.Count()),
+			Sum:                    optionalFloat(dp.Sum(), dp.HasSum()),
+			Min:                    optionalFloat(dp.Min(), dp.HasMin()),
+			Max:                    optionalFloat(dp.Max(), dp.HasMax()),
+			BucketCounts:           toInt64Slice(dp.BucketCounts()),
+			ExplicitBounds:         dp.ExplicitBounds().AsRaw(),
+			AggregationTemporality: histogram.AggregationTemporality().String(),
+		})
+	}
+	return rows
+}
+
+func appendExponentialHistogramRows(rows []interface{}, base metricRow, histogram pmetric.ExponentialHistogram) []interface{} {
+	dps := histogram.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		b := withPoint(base, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+		rows = append(rows, &exponentialHistogramRow{
+			ServiceName:            b.ServiceName,
+			ResourceAttributes:     b.ResourceAttributes,
+			ScopeName:              b.ScopeName,
+			ScopeVersion:           b.ScopeVersion,
+			MetricName:             b.MetricName,
+			MetricDescription:      b.MetricDescription,
+			MetricUnit:             b.MetricUnit,
+			Attributes:             b.Attributes,
+			StartTimestamp:         b.StartTimestamp,
+			Timestamp:              b.Timestamp,
+			Flags:                  b.Flags,
+			Count:                  int64(dp.Count()),
+			Sum:                    optionalFloat(dp.Sum(), dp.HasSum()),
+			Min:                    optionalFloat(dp.Min(), dp.HasMin()),
+			Max:                    optionalFloat(dp.Max(), dp.HasMax()),
+			Scale:                  dp.Scale(),
+			ZeroCount:              int64(dp.ZeroCount()),
+			PositiveOffset:         dp.Positive().Offset(),
+			PositiveBucketCounts:   toInt64Slice(dp.Positive().BucketCounts()),
+			NegativeOffset:         dp.Negative().Offset(),
+			NegativeBucketCounts:   toInt64Slice(dp.Negative().BucketCounts()),
+			AggregationTemporality: histogram.AggregationTemporality().String(),
+		})
+	}
+	return rows
+}
+
+func appendSummaryRows(rows []interface{}, base metricRow, summary pmetric.Summary) []interface{} {
+	dps := summary.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		b := withPoint(base, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+		qvs := dp.QuantileValues()
+		quantiles := make([]quantileValue, qvs.Len())
+		for q := 0; q < qvs.Len(); q++ {
+			quantiles[q] = quantileValue{Quantile: qvs.At(q).Quantile(), Value: qvs.At(q).Value()}
+		}
+		rows = append(rows, &summaryRow{
+			ServiceName:        b.ServiceName,
+			ResourceAttributes: b.ResourceAttributes,
+			ScopeName:          b.ScopeName,
+			ScopeVersion:       b.ScopeVersion,
+			MetricName:         b.MetricName,
+			MetricDescription:  b.MetricDescription,
+			MetricUnit:         b.MetricUnit,
+			Attributes:         b.Attributes,
+			StartTimestamp:     b.StartTimestamp,
+			Timestamp:          b.Timestamp,
+			Flags:              b.Flags,
+			Count:              int64(dp.Count()),
+			Sum:                dp.Sum(),
+			Quantiles:          quantiles,
+		})
+	}
+	return rows
 }
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func testConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return cfg
+}
+
+func readRows(t *testing.T, file string, schema interface{}, rows interface{}) {
+	fr, err := local.NewLocalFileReader(file)
+	require.NoError(t, err)
+	defer fr.Close()
+	pr, err := reader.NewParquetReader(fr, schema, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.NoError(t, pr.Read(rows))
+}
+
+func tableFiles(t *testing.T, cfg *Config, table string) []string {
+	files, err := filepath.Glob(filepath.Join(cfg.Path, table, "*"+fileExtension))
+	require.NoError(t, err)
+	return files
+}
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := testConfig(t)
+	exp := newParquetExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("operation")
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.Timestamp(1000))
+	span.SetEndTimestamp(pcommon.Timestamp(3000))
+	span.Attributes().InsertInt("http.status_code", 200)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().InsertString("exception.type", "Error")
+	link := span.Links().AppendEmpty()
+	link.SetSpanID(pcommon.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+
+	require.NoError(t, exp.consumeTraces(context.Background(), td))
+	assert.Empty(t, tableFiles(t, cfg, tableSpans), "file must not be visible before it is closed")
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := tableFiles(t, cfg, tableSpans)
+	require.Len(t, files, 1)
+	rows := make([]spanRow, 1)
+	readRows(t, files[0], new(spanRow), &rows)
+	row := rows[0]
+	assert.Equal(t, "operation", row.Name)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row.TraceID)
+	assert.Equal(t, "0102030405060708", row.SpanID)
+	assert.Equal(t, "", row.ParentSpanID)
+	assert.Equal(t, "SPAN_KIND_SERVER", row.Kind)
+	assert.Equal(t, int64(2000), row.DurationNanos)
+	assert.Equal(t, "svc", row.ServiceName)
+	assert.Equal(t, "scope", row.ScopeName)
+	assert.Equal(t, attributes{"service.name": "svc"}, row.ResourceAttributes)
+	assert.Equal(t, attributes{"http.status_code": "200"}, row.SpanAttributes)
+	require.Len(t, row.Events, 1)
+	assert.Equal(t, "exception", row.Events[0].Name)
+	assert.Equal(t, attributes{"exception.type": "Error"}, row.Events[0].Attributes)
+	require.Len(t, row.Links, 1)
+	assert.Equal(t, "0807060504030201", row.Links[0].SpanID)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	cfg := testConfig(t)
+	exp := newParquetExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "svc")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStringVal("hello")
+	lr.SetSeverityNumber(plog.SeverityNumberWARN)
+	lr.SetSeverityText("WARN")
+	lr.Attributes().InsertBool("retry", true)
+
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := tableFiles(t, cfg, tableLogs)
+	require.Len(t, files, 1)
+	rows := make([]logRow, 1)
+	readRows(t, files[0], new(logRow), &rows)
+	assert.Equal(t, "hello", rows[0].Body)
+	assert.Equal(t, "WARN", rows[0].SeverityText)
+	assert.Equal(t, int32(plog.SeverityNumberWARN), rows[0].SeverityNumber)
+	assert.Equal(t, "svc", rows[0].ServiceName)
+	assert.Equal(t, attributes{"retry": "true"}, rows[0].LogAttributes)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	cfg := testConfig(t)
+	exp := newParquetExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gdp := gauge.Gauge().DataPoints().AppendEmpty()
+	gdp.SetIntVal(42)
+	gdp.Attributes().InsertString("host", "a")
+
+	sum := ms.AppendEmpty()
+	sum.SetName("sum")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetDoubleVal(1.5)
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(6)
+	hdp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+	hdp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{2}))
+
+	expHistogram := ms.AppendEmpty()
+	expHistogram.SetName("exp_histogram")
+	expHistogram.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	edp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetCount(4)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+
+	summary := ms.AppendEmpty()
+	summary.SetName("summary")
+	summary.SetDataType(pmetric.MetricDataTypeSummary)
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetCount(10)
+	sdp.SetSum(100)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(20)
+
+	require.NoError(t, exp.consumeMetrics(context.Background(), md))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	gaugeFiles := tableFiles(t, cfg, tableGauge)
+	require.Len(t, gaugeFiles, 1)
+	gaugeRows := make([]gaugeRow, 1)
+	readRows(t, gaugeFiles[0], new(gaugeRow), &gaugeRows)
+	assert.Equal(t, "gauge", gaugeRows[0].MetricName)
+	assert.Equal(t, float64(42), gaugeRows[0].Value)
+	assert.Equal(t, attributes{"host": "a"}, gaugeRows[0].Attributes)
+
+	sumFiles := tableFiles(t, cfg, tableSum)
+	require.Len(t, sumFiles, 1)
+	sumRows := make([]sumRow, 1)
+	readRows(t, sumFiles[0], new(sumRow), &sumRows)
+	assert.Equal(t, 1.5, sumRows[0].Value)
+	assert.True(t, sumRows[0].IsMonotonic)
+	assert.Equal(t, "AGGREGATION_TEMPORALITY_CUMULATIVE", sumRows[0].AggregationTemporality)
+
+	histogramFiles := tableFiles(t, cfg, tableHistogram)
+	require.Len(t, histogramFiles, 1)
+	histogramRows := make([]histogramRow, 1)
+	readRows(t, histogramFiles[0], new(histogramRow), &histogramRows)
+	assert.Equal(t, int64(3), histogramRows[0].Count)
+	require.NotNil(t, histogramRows[0].Sum)
+	assert.Equal(t, float64(6), *histogramRows[0].Sum)
+	assert.Nil(t, histogramRows[0].Min)
+	assert.Equal(t, []int64{1, 2}, histogramRows[0].BucketCounts)
+	assert.Equal(t, []float64{2}, histogramRows[0].ExplicitBounds)
+
+	expHistogramFiles := tableFiles(t, cfg, tableExponentialHistogram)
+	require.Len(t, expHistogramFiles, 1)
+	expHistogramRows := make([]exponentialHistogramRow, 1)
+	readRows(t, expHistogramFiles[0], new(exponentialHistogramRow), &expHistogramRows)
+	assert.Equal(t, int32(2), expHistogramRows[0].Scale)
+	assert.Equal(t, int32(-1), expHistogramRows[0].PositiveOffset)
+	assert.Equal(t, []int64{1, 2}, expHistogramRows[0].PositiveBucketCounts)
+
+	summaryFiles := tableFiles(t, cfg, tableSummary)
+	require.Len(t, summaryFiles, 1)
+	summaryRows := make([]summaryRow, 1)
+	readRows(t, summaryFiles[0], new(summaryRow), &summaryRows)
+	assert.Equal(t, int64(10), summaryRows[0].Count)
+	assert.Equal(t, []quantileValue{{Quantile: 0.99, Value: 20}}, summaryRows[0].Quantiles)
+}
+
+func TestRollOverBySize(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.MaxFileSizeMiB = 1
+	cfg.RowGroupSizeMiB = 1
+	exp := newParquetExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 1000; i++ {
+		logs.AppendEmpty().Body().SetStringVal(string(make([]byte, 1024)))
+	}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.consumeLogs(context.Background(), ld))
+	}
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	assert.Greater(t, len(tableFiles(t, cfg, tableLogs)), 1)
+}
+
+func TestRollOverByAge(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.MaxFileAge = 50 * time.Millisecond
+	exp := newParquetExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, exp.shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal("hello")
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+
+	assert.Eventually(t, func() bool {
+		return len(tableFiles(t, cfg, tableLogs)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
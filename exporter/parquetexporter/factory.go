@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -29,11 +30,6 @@ const (
 	stability = component.StabilityLevelInDevelopment
 )
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
-
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
 	return component.NewExporterFactory(
@@ -47,6 +43,10 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		MaxFileSizeMiB:   128,
+		MaxFileAge:       5 * time.Minute,
+		RowGroupSizeMiB:  16,
+		Compression:      compressionSnappy,
 	}
 }
 
@@ -55,7 +55,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewTracesExporter(
 		cfg,
 		set,
@@ -70,7 +70,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewMetricsExporter(
 		cfg,
 		set,
@@ -85,7 +85,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewLogsExporter(
 		cfg,
 		set,
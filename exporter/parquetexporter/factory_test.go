@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	set := componenttest.NewNopExporterCreateSettings()
+
+	te, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, te)
+
+	me, err := createMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, me)
+
+	le, err := createLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, le)
+
+	host := componenttest.NewNopHost()
+	for _, exp := range []interface {
+		Start(context.Context, component.Host) error
+		Shutdown(context.Context) error
+	}{te, me, le} {
+		require.NoError(t, exp.Start(context.Background(), host))
+		require.NoError(t, exp.Shutdown(context.Background()))
+	}
+}
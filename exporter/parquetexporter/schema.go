@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// Table names, also used as the sub-directory the files of each table are written to.
+const (
+	tableSpans                = "spans"
+	tableLogs                 = "logs"
+	tableGauge                = "metrics_gauge"
+	tableSum                  = "metrics_sum"
+	tableHistogram            = "metrics_histogram"
+	tableExponentialHistogram = "metrics_exponential_histogram"
+	tableSummary              = "metrics_summary"
+)
+
+// attributes are stored as MAP<STRING, STRING>, non-string values are
+// converted with pcommon.Value.AsString (maps and slices become JSON).
+type attributes = map[string]string
+
+type spanRow struct {
+	Timestamp          int64       `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	EndTimestamp       int64       `parquet:"name=end_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DurationNanos      int64       `parquet:"name=duration_nanos, type=INT64"`
+	TraceID            string      `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID             string      `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID       string      `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState         string      `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name               string      `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Kind               string      `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	StatusCode         string      `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	StatusMessage      string      `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ServiceName        string      `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes attributes  `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string      `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion       string      `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	SpanAttributes     attributes  `parquet:"name=span_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Events             []spanEvent `parquet:"name=events, type=LIST"`
+	Links              []spanLink  `parquet:"name=links, type=LIST"`
+}
+
+type spanEvent struct {
+	Timestamp  int64      `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Name       string     `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes attributes `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type spanLink struct {
+	TraceID    string     `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID     string     `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState string     `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes attributes `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type logRow struct {
+	Timestamp          int64      `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ObservedTimestamp  int64      `parquet:"name=observed_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TraceID            string     `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID             string     `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceFlags         int32      `parquet:"name=trace_flags, type=INT32"`
+	SeverityText       string     `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	SeverityNumber     int32      `parquet:"name=severity_number, type=INT32"`
+	Body               string     `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ServiceName        string     `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes attributes `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string     `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion       string     `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	LogAttributes      attributes `parquet:"name=log_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+// metricRow holds the values of the columns shared by every metric data point
+// table. parquet-go does not support embedded structs, so every table repeats
+// these columns and copies them with its own constructor.
+type metricRow struct {
+	ServiceName        string
+	ResourceAttributes attributes
+	ScopeName          string
+	ScopeVersion       string
+	MetricName         string
+	MetricDescription  string
+	MetricUnit         string
+	Attributes         attributes
+	StartTimestamp     int64
+	Timestamp          int64
+	Flags              int32
+}
+
+type gaugeRow struct {
+	ServiceName        string     `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes attributes `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string     `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion       string     `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricName         string     `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricDescription  string     `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricUnit         string     `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Attributes         attributes `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimestamp     int64      `parquet:"name=start_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Timestamp          int64      `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags              int32      `parquet:"name=flags, type=INT32"`
+	Value              float64    `parquet:"name=value, type=DOUBLE"`
+}
+
+type sumRow struct {
+	ServiceName            string     `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes     attributes `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string     `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion           string     `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricName             string     `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricDescription      string     `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricUnit             string     `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Attributes             attributes `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimestamp         int64      `parquet:"name=start_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Timestamp              int64      `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int32      `parquet:"name=flags, type=INT32"`
+	Value                  float64    `parquet:"name=value, type=DOUBLE"`
+	AggregationTemporality string     `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	IsMonotonic            bool       `parquet:"name=is_monotonic, type=BOOLEAN"`
+}
+
+type histogramRow struct {
+	ServiceName            string     `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes     attributes `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string     `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion           string     `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricName             string     `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricDescription      string     `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricUnit             string     `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Attributes             attributes `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimestamp         int64      `parquet:"name=start_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Timestamp              int64      `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int32      `parquet:"name=flags, type=INT32"`
+	Count                  int64      `parquet:"name=count, type=INT64"`
+	Sum                    *float64   `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min                    *float64   `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max                    *float64   `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+	BucketCounts           []int64    `parquet:"name=bucket_counts, type=LIST, valuetype=INT64"`
+	ExplicitBounds         []float64  `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+	AggregationTemporality string     `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+}
+
+type exponentialHistogramRow struct {
+	ServiceName            string     `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes     attributes `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string     `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion           string     `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricName             string     `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricDescription      string     `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricUnit             string     `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Attributes             attributes `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimestamp         int64      `parquet:"name=start_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Timestamp              int64      `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int32      `parquet:"name=flags, type=INT32"`
+	Count                  int64      `parquet:"name=count, type=INT64"`
+	Sum                    *float64   `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min                    *float64   `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max                    *float64   `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Scale                  int32      `parquet:"name=scale, type=INT32"`
+	ZeroCount              int64      `parquet:"name=zero_count, type=INT64"`
+	PositiveOffset         int32      `parquet:"name=positive_offset, type=INT32"`
+	PositiveBucketCounts   []int64    `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64"`
+	NegativeOffset         int32      `parquet:"name=negative_offset, type=INT32"`
+	NegativeBucketCounts   []int64    `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64"`
+	AggregationTemporality string     `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+}
+
+type summaryRow struct {
+	ServiceName        string          `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes attributes      `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string          `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion       string          `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricName         string          `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricDescription  string          `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricUnit         string          `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Attributes         attributes      `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimestamp     int64           `parquet:"name=start_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Timestamp          int64           `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags              int32           `parquet:"name=flags, type=INT32"`
+	Count              int64           `parquet:"name=count, type=INT64"`
+	Sum                float64         `parquet:"name=sum, type=DOUBLE"`
+	Quantiles          []quantileValue `parquet:"name=quantiles, type=LIST"`
+}
+
+type quantileValue struct {
+	Quantile float64 `parquet:"name=quantile, type=DOUBLE"`
+	Value    float64 `parquet:"name=value, type=DOUBLE"`
+}
+
+func toAttributes(m pcommon.Map) attributes {
+	attrs := make(attributes, m.Len())
+	m.Range(func(k string, v pcommon.Value) bool {
+		attrs[k] = v.AsString()
+		return true
+	})
+	return attrs
+}
+
+func serviceName(resource pcommon.Resource) string {
+	if v, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+func traceIDString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.HexString()
+}
+
+func spanIDString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.HexString()
+}
+
+func toInt64Slice(s pcommon.ImmutableUInt64Slice) []int64 {
+	out := make([]int64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		out[i] = int64(s.At(i))
+	}
+	return out
+}
+
+func optionalFloat(v float64, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	return &v
+}
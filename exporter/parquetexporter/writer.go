@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/atomic"
+	"go.uber.org/multierr"
+)
+
+const (
+	fileExtension = ".parquet"
+	// In-progress files carry this suffix so that query engines globbing on
+	// "*.parquet" never pick up a file without a footer.
+	inProgressSuffix = ".tmp"
+	// parallelism used by the parquet writer when marshalling a row group.
+	writerParallelism = 4
+	mib               = 1024 * 1024
+)
+
+// fileSequence makes file names unique when several files are opened within
+// the same nanosecond, e.g. by different exporter instances sharing a path.
+var fileSequence atomic.Uint64
+
+// tableWriter appends rows of one table to a Parquet file and rolls over to a
+// new file once the current one reaches the configured size or age.
+type tableWriter struct {
+	dir         string
+	table       string
+	schema      interface{}
+	maxSize     int64
+	maxAge      time.Duration
+	rowGroup    int64
+	compression parquet.CompressionCodec
+
+	mu       sync.Mutex
+	file     *os.File
+	counter  *countingWriter
+	pw       *writer.ParquetWriter
+	openedAt time.Time
+	now      func() time.Time
+}
+
+func newTableWriter(cfg *Config, table string, schema interface{}) (*tableWriter, error) {
+	codec, err := cfg.compressionCodec()
+	if err != nil {
+		return nil, err
+	}
+	return &tableWriter{
+		dir:         filepath.Join(cfg.Path, table),
+		table:       table,
+		schema:      schema,
+		maxSize:     cfg.MaxFileSizeMiB * mib,
+		maxAge:      cfg.MaxFileAge,
+		rowGroup:    cfg.RowGroupSizeMiB * mib,
+		compression: codec,
+		now:         time.Now,
+	}, nil
+}
+
+// write appends the rows to the current file, opening a new one if needed.
+func (w *tableWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rollIfNeeded(); err != nil {
+		return err
+	}
+	if w.pw == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := w.pw.Write(row); err != nil {
+			return fmt.Errorf("failed to write %s row: %w", w.table, err)
+		}
+	}
+	return w.rollIfNeeded()
+}
+
+// rollExpired closes the current file if it is older than the max age.
+func (w *tableWriter) rollExpired() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pw == nil || w.now().Sub(w.openedAt) < w.maxAge {
+		return nil
+	}
+	return w.closeFile()
+}
+
+// close flushes and closes the current file, if any.
+func (w *tableWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeFile()
+}
+
+func (w *tableWriter) rollIfNeeded() error {
+	if w.pw == nil {
+		return nil
+	}
+	if w.now().Sub(w.openedAt) >= w.maxAge || w.estimatedSize() >= w.maxSize {
+		return w.closeFile()
+	}
+	return nil
+}
+
+// estimatedSize is the number of bytes already written plus what the parquet
+// writer is still buffering for the current row group.
+func (w *tableWriter) estimatedSize() int64 {
+	return w.counter.n + w.pw.Size + w.pw.ObjsSize
+}
+
+func (w *tableWriter) open() error {
+	if err := os.MkdirAll(w.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", w.table, err)
+	}
+
+	now := w.now()
+	name := fmt.Sprintf("%s-%s-%d%s%s",
+		w.table, now.UTC().Format("20060102T150405.000000000Z"), fileSequence.Inc(), fileExtension, inProgressSuffix)
+	f, err := os.OpenFile(filepath.Join(w.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create file for %s: %w", w.table, err)
+	}
+
+	counter := &countingWriter{w: f}
+	pw, err := writer.NewParquetWriterFromWriter(counter, w.schema, writerParallelism)
+	if err != nil {
+		return multierr.Append(fmt.Errorf("failed to create parquet writer for %s: %w", w.table, err),
+			multierr.Append(f.Close(), os.Remove(f.Name())))
+	}
+	pw.RowGroupSize = w.rowGroup
+	pw.CompressionType = w.compression
+
+	w.file = f
+	w.counter = counter
+	w.pw = pw
+	w.openedAt = now
+	return nil
+}
+
+// closeFile writes the footer of the current file and renames it so that it
+// becomes visible to readers.
+func (w *tableWriter) closeFile() error {
+	if w.pw == nil {
+		return nil
+	}
+	pw, f := w.pw, w.file
+	w.pw, w.file, w.counter = nil, nil, nil
+
+	if err := pw.WriteStop(); err != nil {
+		return multierr.Append(fmt.Errorf("failed to flush %s file: %w", w.table, err), f.Close())
+	}
+	if err := f.Sync(); err != nil {
+		return multierr.Append(fmt.Errorf("failed to sync %s file: %w", w.table, err), f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s file: %w", w.table, err)
+	}
+	return os.Rename(f.Name(), strings.TrimSuffix(f.Name(), inProgressSuffix))
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
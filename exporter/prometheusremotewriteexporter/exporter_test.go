@@ -372,6 +372,8 @@ func Test_PushMetrics(t *testing.T) {
 
 	summaryBatch := getMetricsFromMetricList(validMetrics1[validSummary], validMetrics2[validSummary])
 
+	exponentialHistogramBatch := getMetricsFromMetricList(validMetrics1[validExponentialHistogram], validMetrics2[validExponentialHistogram])
+
 	// len(BucketCount) > len(ExplicitBounds)
 	unmatchedBoundBucketHistBatch := getMetricsFromMetricList(validMetrics2[unmatchedBoundBucketHist])
 
@@ -478,6 +480,13 @@ func Test_PushMetrics(t *testing.T) {
 			expectedTimeSeries: 10,
 			httpResponseCode:   http.StatusAccepted,
 		},
+		{
+			name:               "exponentialHistogram_case",
+			metrics:            &exponentialHistogramBatch,
+			reqTestFunc:        checkFunc,
+			expectedTimeSeries: 2,
+			httpResponseCode:   http.StatusAccepted,
+		},
 		{
 			name:               "unmatchedBoundBucketHist_case",
 			metrics:            &unmatchedBoundBucketHistBatch,
@@ -64,6 +64,8 @@ var (
 	validSummary     = "valid_Summary"
 	suffixedCounter  = "valid_IntSum_total"
 
+	validExponentialHistogram = "valid_ExponentialHistogram"
+
 	validIntGaugeDirty = "*valid_IntGauge$"
 
 	unmatchedBoundBucketHist = "unmatchedBoundBucketHist"
@@ -77,6 +79,8 @@ var (
 		validSum:         getSumMetric(validSum, lbs1, floatVal1, time1),
 		validHistogram:   getHistogramMetric(validHistogram, lbs1, time1, floatVal1, uint64(intVal1), bounds, buckets),
 		validSummary:     getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
+
+		validExponentialHistogram: getExponentialHistogramMetric(validExponentialHistogram, lbs1, time1, floatVal1, uint64(intVal1), []uint64{1, 2, 3}),
 	}
 	validMetrics2 = map[string]pmetric.Metric{
 		validIntGauge:      getIntGaugeMetric(validIntGauge, lbs2, intVal2, time2),
@@ -89,6 +93,8 @@ var (
 		unmatchedBoundBucketHist: getHistogramMetric(unmatchedBoundBucketHist, pcommon.NewMap(), 0, 0, 0,
 			pcommon.NewImmutableFloat64Slice([]float64{0.1, 0.2, 0.3}),
 			pcommon.NewImmutableUInt64Slice([]uint64{1, 2})),
+
+		validExponentialHistogram: getExponentialHistogramMetric(validExponentialHistogram, lbs2, time2, floatVal2, uint64(intVal2), []uint64{4, 5}),
 	}
 
 	empty = "empty"
@@ -313,6 +319,22 @@ func getHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum floa
 	return metric
 }
 
+func getExponentialHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum float64, count uint64, positiveBuckets []uint64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	metric.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetScale(1)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(positiveBuckets))
+	attributes.CopyTo(dp.Attributes())
+
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	return metric
+}
+
 func getEmptySummaryMetric(name string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(name)
@@ -33,7 +33,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
@@ -119,10 +119,8 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver: "filelog",
 			getConfigFn: func() config.Receiver {
 				cfg := rcvrFactories["filelog"].CreateDefaultConfig().(*filelogreceiver.FileLogConfig)
-				cfg.Input = adapter.InputConfig{
-					"include": []string{
-						filepath.Join(t.TempDir(), "*"),
-					},
+				cfg.InputConfig.Include = []string{
+					filepath.Join(t.TempDir(), "*"),
 				}
 				return cfg
 			},
@@ -255,6 +253,9 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "skywalking",
 		},
+		{
+			receiver: "spanmetrics",
+		},
 		{
 			receiver: "splunk_hec",
 		},
@@ -290,12 +291,10 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver: "syslog",
 			getConfigFn: func() config.Receiver {
 				cfg := rcvrFactories["syslog"].CreateDefaultConfig().(*syslogreceiver.SysLogConfig)
-				cfg.Input = adapter.InputConfig{
-					"tcp": map[string]interface{}{
-						"listen_address": "0.0.0.0:0",
-					},
-					"protocol": "rfc5424",
+				cfg.InputConfig.TCP = &tcp.BaseConfig{
+					ListenAddress: "0.0.0.0:0",
 				}
+				cfg.InputConfig.Protocol = "rfc5424"
 				return cfg
 			},
 		},
@@ -303,9 +302,7 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver: "tcplog",
 			getConfigFn: func() config.Receiver {
 				cfg := rcvrFactories["tcplog"].CreateDefaultConfig().(*tcplogreceiver.TCPLogConfig)
-				cfg.Input = adapter.InputConfig{
-					"listen_address": "0.0.0.0:0",
-				}
+				cfg.InputConfig.ListenAddress = "0.0.0.0:0"
 				return cfg
 			},
 		},
@@ -313,9 +310,7 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver: "udplog",
 			getConfigFn: func() config.Receiver {
 				cfg := rcvrFactories["udplog"].CreateDefaultConfig().(*udplogreceiver.UDPLogConfig)
-				cfg.Input = adapter.InputConfig{
-					"listen_address": "0.0.0.0:0",
-				}
+				cfg.InputConfig.ListenAddress = "0.0.0.0:0"
 				return cfg
 			},
 		},
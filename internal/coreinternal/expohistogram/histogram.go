@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expohistogram // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohistogram"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// MaxScale is the scale the histograms start with, before they are downscaled to fit their
+	// values in the maximum number of buckets.
+	MaxScale int32 = 20
+	// MinScale is the lowest scale of the histograms, at which every float64 falls in one of a
+	// handful of buckets. The scale is never lowered further, even when the maximum size is exceeded.
+	MinScale int32 = -10
+	// DefaultMaxSize is the default maximum number of buckets of the positive and the negative
+	// ranges of the histograms.
+	DefaultMaxSize int32 = 160
+	// MinMaxSize is the smallest supported maximum number of buckets: with a single bucket, the
+	// indexes -1 and 0 never merge however much the scale is lowered.
+	MinMaxSize int32 = 2
+)
+
+// Histogram aggregates values into a base-2 exponential histogram. The scale starts at MaxScale
+// and is lowered whenever the values of the positive or the negative range don't fit in the
+// maximum number of buckets.
+type Histogram struct {
+	maxSize   int32
+	scale     int32
+	count     uint64
+	sum       float64
+	min       float64
+	max       float64
+	zeroCount uint64
+	positive  buckets
+	negative  buckets
+}
+
+// buckets are the counts of consecutive buckets, starting at the bucket index offset.
+type buckets struct {
+	offset int32
+	counts []uint64
+}
+
+// New creates a histogram with at most maxSize buckets in each range, or DefaultMaxSize when
+// maxSize is zero.
+func New(maxSize int32) *Histogram {
+	if maxSize == 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Histogram{
+		maxSize: maxSize,
+		scale:   MaxScale,
+		min:     math.Inf(1),
+		max:     math.Inf(-1),
+	}
+}
+
+// Update records the value incr times. NaN and infinite values are ignored.
+func (h *Histogram) Update(value float64, incr uint64) {
+	if incr == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	h.count += incr
+	h.sum += value * float64(incr)
+	h.min = math.Min(h.min, value)
+	h.max = math.Max(h.max, value)
+
+	switch {
+	case value == 0:
+		h.zeroCount += incr
+	case value > 0:
+		h.updateBuckets(&h.positive, value, incr)
+	default:
+		h.updateBuckets(&h.negative, -value, incr)
+	}
+}
+
+func (h *Histogram) updateBuckets(b *buckets, value float64, incr uint64) {
+	index := MapToIndex(value, h.scale)
+	if len(b.counts) != 0 {
+		low, high := b.offset, b.offset+int32(len(b.counts))-1
+		if index < low {
+			low = index
+		}
+		if index > high {
+			high = index
+		}
+		if change := h.scaleChange(low, high); change > 0 {
+			h.downscale(change)
+			index >>= change
+		}
+	}
+	b.increment(index, incr)
+}
+
+// scaleChange returns by how much the scale must be lowered for the bucket indexes low to high to
+// fit in maxSize buckets, without going below MinScale.
+func (h *Histogram) scaleChange(low, high int32) int32 {
+	var change int32
+	for high-low >= h.maxSize && h.scale-change > MinScale {
+		low >>= 1
+		high >>= 1
+		change++
+	}
+	return change
+}
+
+// downscale lowers the scale of the histogram by change, merging the buckets of both ranges.
+func (h *Histogram) downscale(change int32) {
+	h.positive.downscale(change)
+	h.negative.downscale(change)
+	h.scale -= change
+}
+
+func (b *buckets) downscale(change int32) {
+	if len(b.counts) == 0 {
+		return
+	}
+	offset := b.offset >> change
+	counts := make([]uint64, (b.offset+int32(len(b.counts))-1)>>change-offset+1)
+	for i, count := range b.counts {
+		counts[(b.offset+int32(i))>>change-offset] += count
+	}
+	b.offset = offset
+	b.counts = counts
+}
+
+// increment adds incr to the bucket at index, growing the buckets to include it.
+func (b *buckets) increment(index int32, incr uint64) {
+	switch {
+	case len(b.counts) == 0:
+		b.offset = index
+		b.counts = []uint64{incr}
+		return
+	case index < b.offset:
+		counts := make([]uint64, b.offset-index+int32(len(b.counts)))
+		copy(counts[b.offset-index:], b.counts)
+		b.offset = index
+		b.counts = counts
+	case index >= b.offset+int32(len(b.counts)):
+		b.counts = append(b.counts, make([]uint64, index-b.offset-int32(len(b.counts))+1)...)
+	}
+	b.counts[index-b.offset] += incr
+}
+
+// CopyTo sets the data point fields from the aggregated values.
+func (h *Histogram) CopyTo(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetCount(h.count)
+	dp.SetSum(h.sum)
+	if h.count > 0 {
+		dp.SetMin(h.min)
+		dp.SetMax(h.max)
+	}
+	dp.SetScale(h.scale)
+	dp.SetZeroCount(h.zeroCount)
+	dp.Positive().SetOffset(h.positive.offset)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(h.positive.counts))
+	dp.Negative().SetOffset(h.negative.offset)
+	dp.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice(h.negative.counts))
+}
+
+// MapToIndex returns the index of the bucket of the positive value at the given scale. Buckets
+// are upper-inclusive: the bucket at index i covers (base^i, base^(i+1)] with base 2^(2^-scale).
+func MapToIndex(value float64, scale int32) int32 {
+	frac, exp := math.Frexp(value)
+	// value is an exact power of two, i.e. the upper boundary of a bucket.
+	if frac == 0.5 {
+		if scale <= 0 {
+			return (int32(exp) - 2) >> -scale
+		}
+		return (int32(exp-1) << scale) - 1
+	}
+	if scale <= 0 {
+		return (int32(exp) - 1) >> -scale
+	}
+	return int32(math.Ceil(math.Log2(value)*math.Ldexp(1, int(scale)))) - 1
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expohistogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMapToIndex(t *testing.T) {
+	tests := []struct {
+		value float64
+		scale int32
+		index int32
+	}{
+		{value: 1, scale: 0, index: -1},
+		{value: 1.5, scale: 0, index: 0},
+		{value: 2, scale: 0, index: 0},
+		{value: 3, scale: 0, index: 1},
+		{value: 4, scale: 0, index: 1},
+		{value: 0.5, scale: 0, index: -2},
+		{value: 0.25, scale: 0, index: -3},
+		{value: 4, scale: -1, index: 0},
+		{value: 5, scale: -1, index: 1},
+		{value: 16, scale: -1, index: 1},
+		{value: 17, scale: -1, index: 2},
+		{value: 2, scale: 1, index: 1},
+		{value: 1.2, scale: 1, index: 0},
+		{value: 1.4, scale: 1, index: 0},
+		{value: 1.5, scale: 1, index: 1},
+		{value: 4, scale: 2, index: 7},
+		{value: math.MaxFloat64, scale: MinScale, index: 0},
+		{value: math.SmallestNonzeroFloat64, scale: MinScale, index: -2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.index, MapToIndex(tt.value, tt.scale), "value %v at scale %d", tt.value, tt.scale)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	h := New(4)
+	h.Update(1, 1)
+	assert.Equal(t, MaxScale, h.scale)
+
+	h.Update(2, 1)
+	h.Update(4, 2)
+	h.Update(8, 1)
+	h.Update(-1, 1)
+	h.Update(0, 3)
+	h.Update(math.NaN(), 1)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, float64(1+2+8+8-1), dp.Sum())
+	assert.Equal(t, float64(-1), dp.Min())
+	assert.Equal(t, float64(8), dp.Max())
+	assert.Equal(t, uint64(3), dp.ZeroCount())
+	// 1, 2, 4 and 8 are the upper boundaries of 4 consecutive buckets at scale 0.
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1, 2, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+
+	h.Update(16, 1)
+	h.CopyTo(dp)
+	assert.Equal(t, int32(-1), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestHistogramDownscale(t *testing.T) {
+	h := New(4)
+	for _, v := range []float64{0, 1, 2, 3, 4, 100} {
+		h.Update(v, 1)
+	}
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, float64(110), dp.Sum())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+
+	// 1 to 100 span indexes -1 to 6 at scale 0, which only fit in 4 buckets at scale -2.
+	assert.Equal(t, int32(-2), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestHistogramDefaultMaxSize(t *testing.T) {
+	h := New(0)
+	for v := 0.001; v < 1e6; v *= 1.01 {
+		h.Update(v, 1)
+	}
+
+	assert.LessOrEqual(t, int32(len(h.positive.counts)), DefaultMaxSize)
+	assert.Less(t, h.scale, MaxScale)
+	var total uint64
+	for _, c := range h.positive.counts {
+		total += c
+	}
+	assert.Equal(t, h.count, total)
+
+	// Every recorded value must fall in the bucket range of the final scale.
+	assert.LessOrEqual(t, h.positive.offset, MapToIndex(0.001, h.scale))
+	assert.GreaterOrEqual(t, h.positive.offset+int32(len(h.positive.counts))-1, MapToIndex(1e6/1.01, h.scale))
+}
+
+func TestHistogramMinScale(t *testing.T) {
+	h := New(MinMaxSize)
+	for _, v := range []float64{1.5, 0.7, math.SmallestNonzeroFloat64, math.MaxFloat64} {
+		h.Update(v, 1)
+	}
+
+	assert.Equal(t, MinScale, h.scale)
+	assert.Equal(t, uint64(4), h.count)
+	var total uint64
+	for _, c := range h.positive.counts {
+		total += c
+	}
+	assert.Equal(t, uint64(4), total)
+}
+
+func TestHistogramCopyIsIndependent(t *testing.T) {
+	h := New(0)
+	h.Update(1, 1)
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+
+	h.Update(1, 1)
+
+	assert.Equal(t, []uint64{1}, dp.Positive().BucketCounts().AsRaw())
+}
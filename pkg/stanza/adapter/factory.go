@@ -31,7 +31,7 @@ type LogReceiverType interface {
 	Type() config.Type
 	CreateDefaultConfig() config.Receiver
 	BaseConfig(config.Receiver) BaseConfig
-	DecodeInputConfig(config.Receiver) (*operator.Config, error)
+	InputConfig(config.Receiver) operator.Config
 }
 
 // NewFactory creates a factory for a Stanza-based receiver
@@ -50,18 +50,9 @@ func createLogsReceiver(logReceiverType LogReceiverType) component.CreateLogsRec
 		cfg config.Receiver,
 		nextConsumer consumer.Logs,
 	) (component.LogsReceiver, error) {
-		inputCfg, err := logReceiverType.DecodeInputConfig(cfg)
-		if err != nil {
-			return nil, err
-		}
-
+		inputCfg := logReceiverType.InputConfig(cfg)
 		baseCfg := logReceiverType.BaseConfig(cfg)
-		operatorCfgs, err := baseCfg.DecodeOperatorConfigs()
-		if err != nil {
-			return nil, err
-		}
-
-		operators := append([]operator.Config{*inputCfg}, operatorCfgs...)
+		operators := append([]operator.Config{inputCfg}, baseCfg.Operators...)
 
 		emitterOpts := []LogEmitterOption{
 			LogEmitterWithLogger(params.Logger.Sugar()),
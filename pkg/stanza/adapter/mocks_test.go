@@ -49,10 +49,6 @@ type UnstartableOperator struct {
 	helper.OutputOperator
 }
 
-func newUnstartableParams() map[string]interface{} {
-	return map[string]interface{}{"type": "unstartable_operator"}
-}
-
 // NewUnstartableConfig creates new output config
 func NewUnstartableConfig() *UnstartableConfig {
 	return &UnstartableConfig{
@@ -89,7 +85,7 @@ const testType = "test"
 
 type TestConfig struct {
 	BaseConfig `mapstructure:",squash"`
-	Input      InputConfig `mapstructure:",remain"`
+	Input      operator.Config `mapstructure:"input"`
 }
 type TestReceiverType struct{}
 
@@ -101,13 +97,13 @@ func (f TestReceiverType) CreateDefaultConfig() config.Receiver {
 	return &TestConfig{
 		BaseConfig: BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(testType)),
-			Operators:        OperatorConfigs{},
+			Operators:        []operator.Config{},
 			Converter: ConverterConfig{
 				MaxFlushCount: 1,
 				FlushInterval: 100 * time.Millisecond,
 			},
 		},
-		Input: InputConfig{},
+		Input: operator.Config{Builder: noop.NewConfig("nop")},
 	}
 }
 
@@ -115,19 +111,8 @@ func (f TestReceiverType) BaseConfig(cfg config.Receiver) BaseConfig {
 	return cfg.(*TestConfig).BaseConfig
 }
 
-func (f TestReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
-	testConfig := cfg.(*TestConfig)
-
-	// Allow tests to run without implementing input config
-	if testConfig.Input["type"] == nil {
-		return &operator.Config{Builder: noop.NewConfig("nop")}, nil
-	}
-
-	// Allow tests to explicitly prompt a failure
-	if testConfig.Input["type"] == "unknown" {
-		return nil, errors.New("unknown input type")
-	}
-	return &operator.Config{Builder: NewUnstartableConfig()}, nil
+func (f TestReceiverType) InputConfig(cfg config.Receiver) operator.Config {
+	return cfg.(*TestConfig).Input
 }
 
 func newMockPersister() *persister {
@@ -25,6 +25,8 @@ type FileAttributes struct {
 	Path         string
 	NameResolved string
 	PathResolved string
+	// Header holds the metadata captured from the header lines of the file
+	Header map[string]string
 }
 
 // resolveFileAttributes resolves file attributes
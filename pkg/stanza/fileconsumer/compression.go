@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported values of the compression setting
+const (
+	compressionAuto = "auto"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// decompressor wraps a compressed stream into a reader of its decompressed content
+type decompressor func(io.Reader) (io.ReadCloser, error)
+
+func newGzipReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func newZstdReader(r io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+func validateCompression(compression string) error {
+	switch compression {
+	case "", compressionAuto, compressionGzip, compressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// detectDecompressor returns the decompressor of a file according to the compression setting.
+// With auto compression, the format is detected from the magic number of the file.
+// A nil decompressor is returned for plain text files.
+func detectDecompressor(compression string, file *os.File) (decompressor, error) {
+	switch compression {
+	case "":
+		return nil, nil
+	case compressionGzip:
+		return newGzipReader, nil
+	case compressionZstd:
+		return newZstdReader, nil
+	}
+
+	magic := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(magic, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading magic number: %w", err)
+	}
+	switch {
+	case bytes.HasPrefix(magic[:n], gzipMagic):
+		return newGzipReader, nil
+	case bytes.HasPrefix(magic[:n], zstdMagic):
+		return newZstdReader, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func writeGzip(t testing.TB, path string, content string) {
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	w := gzip.NewWriter(file)
+	_, err = io.WriteString(w, content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+func writeZstd(t testing.TB, path string, content string) {
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	w, err := zstd.NewWriter(file)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		compression string
+		fileName    string
+		write       func(testing.TB, string, string)
+	}{
+		{"Gzip", compressionGzip, "app.log.gz", writeGzip},
+		{"Zstd", compressionZstd, "app.log.zst", writeZstd},
+		{"AutoGzip", compressionAuto, "app.log.gz", writeGzip},
+		{"AutoZstd", compressionAuto, "app.log.zst", writeZstd},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+				cfg.Compression = tc.compression
+			})
+			persister := testutil.NewMockPersister("test")
+
+			tc.write(t, filepath.Join(tempDir, tc.fileName), "testlog1\ntestlog2\n")
+
+			require.NoError(t, operator.Start(persister))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+			waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+			// A fully read file is not read again on later polls
+			expectNoTokens(t, emitCalls)
+
+			// Nor after a restart
+			require.NoError(t, operator.Stop())
+			require.NoError(t, operator.Start(persister))
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+func TestReadAutoCompressionPlainFile(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.Compression = compressionAuto
+	})
+	persister := testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	require.NoError(t, operator.Start(persister))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	writeString(t, temp, "testlog2\n")
+	waitForToken(t, emitCalls, []byte("testlog2"))
+}
+
+// TestCompressedRotatedFile tests that a file rotated and compressed while the
+// operator is stopped is read from the offset of the original file
+func TestCompressedRotatedFile(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Moving files while open is unsupported on Windows")
+	}
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.Compression = compressionAuto
+	})
+	persister := testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "app.log")
+	temp := openFile(t, path)
+	writeString(t, temp, "testlog1\n")
+
+	require.NoError(t, operator.Start(persister))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.NoError(t, operator.Stop())
+
+	writeString(t, temp, "testlog2\n")
+	require.NoError(t, temp.Close())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	writeGzip(t, filepath.Join(tempDir, "app.log.1.gz"), string(content))
+	require.NoError(t, os.Remove(path))
+
+	require.NoError(t, operator.Start(persister))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+}
+
+// TestCompressedReadFailure tests that a compressed file failing to be read is only decompressed
+// again once its size changes, and that the failure is only logged as an error once
+func TestCompressedReadFailure(t *testing.T) {
+	t.Parallel()
+	f, emitChan := testReaderFactory(t)
+	f.compression = compressionGzip
+	core, logs := observer.New(zapcore.DebugLevel)
+	f.SugaredLogger = zap.New(core).Sugar()
+
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	writeGzip(t, path, "testlog1\ntestlog2\n")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// Drop the trailer of the gzip stream, as if the file was still being compressed
+	require.NoError(t, os.WriteFile(path, content[:len(content)-8], 0600))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	r, err := f.newReaderBuilder().withFile(file).build()
+	require.NoError(t, err)
+	decompress := r.decompress
+	var decompressions int
+	r.decompress = func(reader io.Reader) (io.ReadCloser, error) {
+		decompressions++
+		return decompress(reader)
+	}
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+	require.Equal(t, []byte("testlog2"), readToken(t, emitChan))
+	require.False(t, r.eof)
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 1, decompressions)
+	require.False(t, r.eof)
+
+	require.NoError(t, os.WriteFile(path, content[:len(content)-4], 0600))
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 2, decompressions)
+	require.False(t, r.eof)
+	require.Equal(t, 1, logs.FilterLevelExact(zapcore.ErrorLevel).Len())
+
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 3, decompressions)
+	require.True(t, r.eof)
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 3, decompressions)
+	require.Equal(t, 1, logs.FilterLevelExact(zapcore.ErrorLevel).Len())
+}
+
+// TestCompressedFileWithoutNewline tests that a compressed file whose last line is waiting to be
+// flushed is only decompressed again once the line is due to be flushed
+func TestCompressedFileWithoutNewline(t *testing.T) {
+	t.Parallel()
+	f, emitChan := testReaderFactory(t)
+	f.compression = compressionGzip
+	f.splitterConfig = helper.NewSplitterConfig()
+	f.splitterConfig.Flusher.Period.Duration = 100 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	writeGzip(t, path, "testlog1\ntestlog2")
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	r, err := f.newReaderBuilder().withFile(file).build()
+	require.NoError(t, err)
+	decompress := r.decompress
+	var decompressions int
+	r.decompress = func(reader io.Reader) (io.ReadCloser, error) {
+		decompressions++
+		return decompress(reader)
+	}
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+	require.False(t, r.eof)
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 1, decompressions)
+
+	time.Sleep(150 * time.Millisecond)
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 2, decompressions)
+	require.Equal(t, []byte("testlog2"), readToken(t, emitChan))
+	require.True(t, r.eof)
+}
@@ -57,6 +57,9 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"               json:"fingerprint_size,omitempty"              yaml:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"                   json:"max_log_size,omitempty"                  yaml:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"           json:"max_concurrent_files,omitempty"          yaml:"max_concurrent_files,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"                    json:"compression,omitempty"                   yaml:"compression,omitempty"`
+	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"              json:"delete_after_read,omitempty"             yaml:"delete_after_read,omitempty"`
+	Header                  HeaderConfig          `mapstructure:"header,omitempty"                         json:"header,omitempty"                        yaml:"header,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"                        json:",inline,omitempty"                       yaml:",inline,omitempty"`
 }
 
@@ -100,8 +103,22 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error)
 		return nil, fmt.Errorf("`fingerprint_size` must be at least %d bytes", MinFingerprintSize)
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return nil, err
+	}
+
+	ordering, err := c.OrderingCriteria.build()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := c.Header.build()
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure that splitter is buildable
-	_, err := c.Splitter.Build(false, int(c.MaxLogSize))
+	_, err = c.Splitter.Build(false, int(c.MaxLogSize))
 	if err != nil {
 		return nil, err
 	}
@@ -116,9 +133,16 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error)
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	// Files are deleted once read, so those which exist at startup must be read from the beginning
+	if c.DeleteAfterRead && !startAtBeginning {
+		return nil, fmt.Errorf("`delete_after_read` requires `start_at` to be 'beginning'")
+	}
+
 	return &Input{
 		SugaredLogger:      logger.With("component", "fileconsumer"),
 		finder:             c.Finder,
+		ordering:           ordering,
+		deleteAfterRead:    c.DeleteAfterRead,
 		PollInterval:       c.PollInterval.Raw(),
 		queuedMatches:      make([]string, 0),
 		firstCheck:         true,
@@ -133,9 +157,11 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error)
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
 				emit:            emit,
+				header:          header,
 			},
 			fromBeginning:  startAtBeginning,
 			splitterConfig: c.Splitter,
+			compression:    c.Compression,
 		},
 	}, nil
 }
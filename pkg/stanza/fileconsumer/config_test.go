@@ -320,6 +320,62 @@ func TestUnmarshal(t *testing.T) {
 				return cfg
 			}(),
 		},
+		{
+			Name:      "compression_gzip",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Compression = "gzip"
+				return cfg
+			}(),
+		},
+		{
+			Name:      "ordering_criteria",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.OrderingCriteria = OrderingCriteria{
+					Regex: `app-(?P<ts>\d{8}-\d{4})-(?P<num>\d+)\.log`,
+					TopN:  12,
+					SortBy: []SortRule{
+						{
+							RegexKey: "ts",
+							SortType: "timestamp",
+							Layout:   "%Y%m%d-%H%M",
+							Location: "UTC",
+						},
+						{
+							RegexKey:  "num",
+							SortType:  "numeric",
+							Ascending: true,
+						},
+					},
+				}
+				return cfg
+			}(),
+		},
+		{
+			Name:      "delete_after_read",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.StartAt = "beginning"
+				cfg.DeleteAfterRead = true
+				return cfg
+			}(),
+		},
+		{
+			Name:      "header_pattern",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Header = HeaderConfig{
+					Pattern:   `^#(?P<key>\w+): (?P<value>.*)$`,
+					Attribute: "w3c",
+				}
+				return cfg
+			}(),
+		},
 		{
 			Name:      "max_concurrent_large",
 			ExpectErr: false,
@@ -426,6 +482,50 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "bz2"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria.Regex = `(?P<num>\d+)`
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterReadStartAtEnd",
+			func(f *Config) {
+				f.DeleteAfterRead = true
+				f.StartAt = "end"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterReadStartAtBeginning",
+			func(f *Config) {
+				f.DeleteAfterRead = true
+				f.StartAt = "beginning"
+			},
+			require.NoError,
+			func(t *testing.T, f *Input) {
+				require.True(t, f.deleteAfterRead)
+			},
+		},
+		{
+			"InvalidHeader",
+			func(f *Config) {
+				f.Header = HeaderConfig{LineCount: 1, Pattern: "^#"}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"BadExcludeGlob",
 			func(f *Config) {
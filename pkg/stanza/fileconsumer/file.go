@@ -34,8 +34,11 @@ type EmitFunc func(ctx context.Context, attrs *FileAttributes, token []byte)
 type Input struct {
 	*zap.SugaredLogger
 	finder       Finder
+	ordering     *fileOrdering
 	PollInterval time.Duration
 
+	deleteAfterRead bool
+
 	MaxConcurrentFiles int
 	SeenPaths          map[string]struct{}
 
@@ -123,6 +126,9 @@ func (f *Input) poll(ctx context.Context) {
 
 			// Get the list of paths on disk
 			matches = f.finder.FindFiles()
+			if f.ordering != nil {
+				matches = f.ordering.apply(matches)
+			}
 			if f.firstCheck && len(matches) == 0 {
 				f.Warnw("no files match the configured include patterns",
 					"include", f.finder.Include,
@@ -154,6 +160,14 @@ func (f *Input) poll(ctx context.Context) {
 	}
 	wg.Wait()
 
+	if f.deleteAfterRead {
+		// Files are not tailed in this mode, so there is nothing to roll
+		f.saveCurrent(readers)
+		f.syncLastPollFiles(ctx)
+		f.deleteReadFiles(readers)
+		return
+	}
+
 	f.roller.roll(ctx, readers)
 	f.saveCurrent(readers)
 	f.syncLastPollFiles(ctx)
@@ -254,6 +268,22 @@ func (f *Input) saveCurrent(readers []*Reader) {
 	}
 }
 
+// deleteReadFiles closes the readers, and deletes the files they have consumed to the end.
+// Files whose last entry is still waiting to be flushed are deleted by a later poll.
+// It must be called after the offsets of the readers have been synced, so that
+// the files are not read again if the operator is stopped before deleting them.
+func (f *Input) deleteReadFiles(readers []*Reader) {
+	for _, reader := range readers {
+		reader.Close()
+		if !reader.eof {
+			continue
+		}
+		if err := os.Remove(reader.file.Name()); err != nil {
+			f.Errorw("Failed to delete file", "path", reader.file.Name(), zap.Error(err))
+		}
+	}
+}
+
 func (f *Input) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
 	// Check if the new path has the same fingerprint as an old path
 	if oldReader, ok := f.findFingerprintMatch(fp); ok {
@@ -1121,3 +1121,209 @@ func TestEncodings(t *testing.T) {
 		})
 	}
 }
+
+// TestOrderingCriteriaTopN tests that only the newest files are read
+func TestOrderingCriteriaTopN(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.OrderingCriteria = OrderingCriteria{
+			Regex: `app-(?P<ts>\d{8}-\d{4})\.log`,
+			TopN:  2,
+			SortBy: []SortRule{
+				{RegexKey: "ts", SortType: sortTypeTimestamp, Layout: "%Y%m%d-%H%M"},
+			},
+		}
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	for _, name := range []string{"app-20221018-1100.log", "app-20221018-1200.log", "app-20221018-1300.log"} {
+		file := openFile(t, filepath.Join(tempDir, name))
+		writeString(t, file, name+"\n")
+	}
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("app-20221018-1300.log"), []byte("app-20221018-1200.log")})
+	expectNoTokens(t, emitCalls)
+}
+
+func TestDeleteAfterRead(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+	})
+	persister := testutil.NewMockPersister("test")
+
+	temp1 := openTemp(t, tempDir)
+	writeString(t, temp1, "testlog1\n")
+	temp2 := openTemp(t, tempDir)
+	writeString(t, temp2, "testlog2\n")
+
+	operator.persister = persister
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+	for _, file := range []*os.File{temp1, temp2} {
+		_, err := os.Stat(file.Name())
+		require.True(t, os.IsNotExist(err))
+	}
+
+	// The offsets of the deleted files were synced before deleting them
+	encoded, err := persister.Get(context.Background(), knownFilesKey)
+	require.NoError(t, err)
+	require.NotNil(t, encoded)
+
+	// Files dropped into the directory later are read and deleted as well
+	temp3 := openTemp(t, tempDir)
+	writeString(t, temp3, "testlog3\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	_, err = os.Stat(temp3.Name())
+	require.True(t, os.IsNotExist(err))
+}
+
+// TestDeleteAfterReadWithoutNewline tests that files are only deleted once their last line has been flushed
+func TestDeleteAfterReadWithoutNewline(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		compression string
+		fileName    string
+		write       func(testing.TB, string, string)
+	}{
+		{"Plain", "", "app.log", func(t testing.TB, path string, content string) {
+			require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		}},
+		{"Gzip", compressionGzip, "app.log.gz", writeGzip},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+				cfg.DeleteAfterRead = true
+				cfg.Compression = tc.compression
+				cfg.Splitter = helper.NewSplitterConfig()
+				cfg.Splitter.Flusher.Period.Duration = 100 * time.Millisecond
+			})
+			operator.persister = testutil.NewMockPersister("test")
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			path := filepath.Join(tempDir, tc.fileName)
+			tc.write(t, path, "testlog1\ntestlog2")
+
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("testlog1"))
+			_, err := os.Stat(path)
+			require.NoError(t, err, "the file was deleted before its last line was emitted")
+
+			// The last line is flushed by a later poll, which then deletes the file
+			time.Sleep(150 * time.Millisecond)
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("testlog2"))
+			_, err = os.Stat(path)
+			require.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+// TestHeaderLineCount tests that the first lines of each file are captured as its header, including after a restart
+func TestHeaderLineCount(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.Header.LineCount = 1
+	})
+	persister := testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "a,b,c\n1,2,3\n")
+
+	require.NoError(t, operator.Start(persister))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	call := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("1,2,3"), call.token)
+	require.Equal(t, map[string]string{"log.file.header": "a,b,c"}, call.attrs.Header)
+
+	// The header is restored from the persisted offsets
+	require.NoError(t, operator.Stop())
+	require.NoError(t, operator.Start(persister))
+
+	writeString(t, temp, "4,5,6\n")
+	call = waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("4,5,6"), call.token)
+	require.Equal(t, map[string]string{"log.file.header": "a,b,c"}, call.attrs.Header)
+}
+
+// TestHeaderStartAtEnd tests that the header of a file is captured when it is read from the end
+func TestHeaderStartAtEnd(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.StartAt = "end"
+		cfg.Header.Pattern = `^#Fields: (?P<value>.*)$`
+		cfg.Header.Attribute = "fields"
+	})
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: date time\n2022-10-18 12:00:00\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	// Wait until the file has been read from the end
+	time.Sleep(2 * operator.PollInterval)
+	expectNoTokens(t, emitCalls)
+
+	writeString(t, temp, "2022-10-18 13:00:00\n")
+	call := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("2022-10-18 13:00:00"), call.token)
+	require.Equal(t, map[string]string{"fields": "date time"}, call.attrs.Header)
+}
+
+// TestHeaderDirectivesChange tests that directives found in the middle of a file replace those found before
+func TestHeaderDirectivesChange(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := newDefaultConfig(tempDir)
+	cfg.Header.Pattern = `^#(?P<key>\w+): (?P<value>.*)$`
+
+	headers := make(chan map[string]string, 10)
+	operator, err := cfg.Build(testutil.Logger(t), func(_ context.Context, attrs *FileAttributes, _ []byte) {
+		header := make(map[string]string, len(attrs.Header))
+		for k, v := range attrs.Header {
+			header[k] = v
+		}
+		headers <- header
+	})
+	require.NoError(t, err)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: a b\n1 2\n#Fields: a b c\n1 2 3\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	for _, expected := range []string{"a b", "a b c"} {
+		select {
+		case header := <-headers:
+			require.Equal(t, map[string]string{"log.file.header.Fields": expected}, header)
+		case <-time.After(3 * time.Second):
+			require.FailNow(t, "Timed out waiting for message")
+		}
+	}
+}
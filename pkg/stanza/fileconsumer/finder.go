@@ -21,6 +21,8 @@ import (
 type Finder struct {
 	Include []string `mapstructure:"include,omitempty" json:"include,omitempty" yaml:"include,omitempty"`
 	Exclude []string `mapstructure:"exclude,omitempty" json:"exclude,omitempty" yaml:"exclude,omitempty"`
+
+	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty" json:"ordering_criteria,omitempty" yaml:"ordering_criteria,omitempty"`
 }
 
 // FindFiles gets a list of paths given an array of glob patterns to include and exclude
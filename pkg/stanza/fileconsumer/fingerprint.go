@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 )
 
@@ -47,6 +48,31 @@ func NewFingerprint(file *os.File, size int) (*Fingerprint, error) {
 	return fp, nil
 }
 
+// newDecompressedFingerprint creates a new fingerprint from the decompressed content of an open file
+func newDecompressedFingerprint(file *os.File, size int, decompress decompressor) (*Fingerprint, error) {
+	stream, err := decompress(io.NewSectionReader(file, 0, math.MaxInt64))
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			// The file is still empty
+			return &Fingerprint{FirstBytes: []byte{}}, nil
+		}
+		return nil, fmt.Errorf("decompressing fingerprint bytes: %w", err)
+	}
+	defer stream.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(stream, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	fp := &Fingerprint{
+		FirstBytes: buf[:n],
+	}
+
+	return fp, nil
+}
+
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.FirstBytes), cap(f.FirstBytes))
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	defaultHeaderAttribute = "log.file.header"
+
+	headerKeyGroup   = "key"
+	headerValueGroup = "value"
+)
+
+// HeaderConfig configures the lines of a file which are captured as metadata of the file instead of being emitted
+type HeaderConfig struct {
+	LineCount int    `mapstructure:"line_count,omitempty" json:"line_count,omitempty" yaml:"line_count,omitempty"`
+	Pattern   string `mapstructure:"pattern,omitempty"    json:"pattern,omitempty"    yaml:"pattern,omitempty"`
+	Attribute string `mapstructure:"attribute,omitempty"  json:"attribute,omitempty"  yaml:"attribute,omitempty"`
+}
+
+// headerParser is the compiled form of HeaderConfig
+type headerParser struct {
+	lineCount  int
+	pattern    *regexp.Regexp
+	keyIndex   int
+	valueIndex int
+	attribute  string
+}
+
+// build validates the header config and compiles it. It returns nil if no header is configured.
+func (c HeaderConfig) build() (*headerParser, error) {
+	if c.LineCount == 0 && c.Pattern == "" {
+		return nil, nil
+	}
+
+	if c.LineCount != 0 && c.Pattern != "" {
+		return nil, fmt.Errorf("only one of `header.line_count` and `header.pattern` can be set")
+	}
+
+	if c.LineCount < 0 {
+		return nil, fmt.Errorf("`header.line_count` must be positive")
+	}
+
+	h := &headerParser{
+		lineCount:  c.LineCount,
+		keyIndex:   -1,
+		valueIndex: -1,
+		attribute:  c.Attribute,
+	}
+	if h.attribute == "" {
+		h.attribute = defaultHeaderAttribute
+	}
+
+	if c.Pattern != "" {
+		pattern, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile `header.pattern`: %w", err)
+		}
+		h.pattern = pattern
+		h.keyIndex = pattern.SubexpIndex(headerKeyGroup)
+		h.valueIndex = pattern.SubexpIndex(headerValueGroup)
+		if h.keyIndex >= 0 && h.valueIndex < 0 {
+			return nil, fmt.Errorf("`header.pattern` with a '%s' capture group must have a '%s' capture group", headerKeyGroup, headerValueGroup)
+		}
+	}
+
+	return h, nil
+}
+
+// parse records the token in the metadata if it is a header line, given the number of header lines read before,
+// and returns true if it is
+func (h *headerParser) parse(token []byte, linesRead int, metadata map[string]string) bool {
+	if h.pattern == nil {
+		if linesRead >= h.lineCount {
+			return false
+		}
+		if linesRead == 0 {
+			metadata[h.attribute] = string(token)
+		} else {
+			metadata[h.attribute] += "\n" + string(token)
+		}
+		return true
+	}
+
+	match := h.pattern.FindSubmatch(token)
+	if match == nil {
+		return false
+	}
+	switch {
+	case h.keyIndex >= 0:
+		metadata[h.attribute+"."+string(match[h.keyIndex])] = string(match[h.valueIndex])
+	case h.valueIndex >= 0:
+		metadata[h.attribute] = string(match[h.valueIndex])
+	default:
+		metadata[h.attribute] = string(token)
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderParser(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		config   HeaderConfig
+		lines    []string
+		emitted  []string
+		expected map[string]string
+	}{
+		{
+			name:     "LineCount",
+			config:   HeaderConfig{LineCount: 1},
+			lines:    []string{"a,b,c", "1,2,3", "4,5,6"},
+			emitted:  []string{"1,2,3", "4,5,6"},
+			expected: map[string]string{"log.file.header": "a,b,c"},
+		},
+		{
+			name:     "MultipleLines",
+			config:   HeaderConfig{LineCount: 2, Attribute: "header"},
+			lines:    []string{"title", "a,b,c", "1,2,3"},
+			emitted:  []string{"1,2,3"},
+			expected: map[string]string{"header": "title\na,b,c"},
+		},
+		{
+			name:     "Pattern",
+			config:   HeaderConfig{Pattern: "^#"},
+			lines:    []string{"#Fields: a b", "1 2"},
+			emitted:  []string{"1 2"},
+			expected: map[string]string{"log.file.header": "#Fields: a b"},
+		},
+		{
+			name:     "PatternValue",
+			config:   HeaderConfig{Pattern: `^#Fields: (?P<value>.*)$`},
+			lines:    []string{"#Version: 1.0", "#Fields: a b", "1 2"},
+			emitted:  []string{"#Version: 1.0", "1 2"},
+			expected: map[string]string{"log.file.header": "a b"},
+		},
+		{
+			name:    "PatternKeyValue",
+			config:  HeaderConfig{Pattern: `^#(?P<key>\w+): (?P<value>.*)$`},
+			lines:   []string{"#Version: 1.0", "#Fields: a b", "1 2", "#Fields: a b c", "1 2 3"},
+			emitted: []string{"1 2", "1 2 3"},
+			expected: map[string]string{
+				"log.file.header.Version": "1.0",
+				"log.file.header.Fields":  "a b c",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h, err := tc.config.build()
+			require.NoError(t, err)
+
+			metadata := make(map[string]string)
+			emitted := make([]string, 0, len(tc.emitted))
+			linesRead := 0
+			for _, line := range tc.lines {
+				if h.parse([]byte(line), linesRead, metadata) {
+					linesRead++
+					continue
+				}
+				emitted = append(emitted, line)
+			}
+			require.Equal(t, tc.emitted, emitted)
+			require.Equal(t, tc.expected, metadata)
+		})
+	}
+}
+
+func TestHeaderConfigBuild(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		config      HeaderConfig
+		expectedErr string
+	}{
+		{
+			name:   "Empty",
+			config: HeaderConfig{},
+		},
+		{
+			name:        "LineCountAndPattern",
+			config:      HeaderConfig{LineCount: 1, Pattern: "^#"},
+			expectedErr: "only one of `header.line_count` and `header.pattern` can be set",
+		},
+		{
+			name:        "NegativeLineCount",
+			config:      HeaderConfig{LineCount: -1},
+			expectedErr: "`header.line_count` must be positive",
+		},
+		{
+			name:        "InvalidPattern",
+			config:      HeaderConfig{Pattern: "("},
+			expectedErr: "compile `header.pattern`",
+		},
+		{
+			name:        "KeyWithoutValue",
+			config:      HeaderConfig{Pattern: `^#(?P<key>\w+)`},
+			expectedErr: "`header.pattern` with a 'key' capture group must have a 'value' capture group",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h, err := tc.config.build()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				require.Nil(t, h)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
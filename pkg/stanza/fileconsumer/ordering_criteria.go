@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeAlphabetical = "alphabetical"
+
+	defaultOrderingCriteriaTopN = 1
+)
+
+// OrderingCriteria selects the files to read by sorting them on values extracted from their names
+type OrderingCriteria struct {
+	Regex  string     `mapstructure:"regex,omitempty"   json:"regex,omitempty"   yaml:"regex,omitempty"`
+	TopN   int        `mapstructure:"top_n,omitempty"   json:"top_n,omitempty"   yaml:"top_n,omitempty"`
+	SortBy []SortRule `mapstructure:"sort_by,omitempty" json:"sort_by,omitempty" yaml:"sort_by,omitempty"`
+}
+
+// SortRule sorts files on the value of a capture group of the ordering criteria regex
+type SortRule struct {
+	RegexKey  string `mapstructure:"regex_key,omitempty" json:"regex_key,omitempty" yaml:"regex_key,omitempty"`
+	SortType  string `mapstructure:"sort_type,omitempty" json:"sort_type,omitempty" yaml:"sort_type,omitempty"`
+	Layout    string `mapstructure:"layout,omitempty"    json:"layout,omitempty"    yaml:"layout,omitempty"`
+	Location  string `mapstructure:"location,omitempty"  json:"location,omitempty"  yaml:"location,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty" json:"ascending,omitempty" yaml:"ascending,omitempty"`
+}
+
+// fileOrdering is the compiled form of OrderingCriteria
+type fileOrdering struct {
+	regex *regexp.Regexp
+	topN  int
+	rules []sortRule
+}
+
+type sortRule struct {
+	SortRule
+	index    int
+	layout   string
+	location *time.Location
+}
+
+// sortValue holds the value extracted for a sort rule, in the field matching its sort type
+type sortValue struct {
+	num int64
+	ts  time.Time
+	str string
+}
+
+type orderedFile struct {
+	path   string
+	values []sortValue
+}
+
+// build validates the ordering criteria and compiles them. It returns nil if no ordering criteria are configured.
+func (c OrderingCriteria) build() (*fileOrdering, error) {
+	if c.Regex == "" {
+		if len(c.SortBy) != 0 {
+			return nil, fmt.Errorf("`ordering_criteria.regex` is required with `ordering_criteria.sort_by`")
+		}
+		return nil, nil
+	}
+
+	regex, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("compile `ordering_criteria.regex`: %w", err)
+	}
+
+	topN := c.TopN
+	if topN == 0 {
+		topN = defaultOrderingCriteriaTopN
+	} else if topN < 0 {
+		return nil, fmt.Errorf("`ordering_criteria.top_n` must be positive")
+	}
+
+	if len(c.SortBy) == 0 {
+		return nil, fmt.Errorf("`ordering_criteria.sort_by` is required with `ordering_criteria.regex`")
+	}
+
+	rules := make([]sortRule, 0, len(c.SortBy))
+	for _, rule := range c.SortBy {
+		r := sortRule{SortRule: rule}
+		if r.index = regex.SubexpIndex(rule.RegexKey); r.index < 0 {
+			return nil, fmt.Errorf("`ordering_criteria.regex` has no capture group named '%s'", rule.RegexKey)
+		}
+
+		switch rule.SortType {
+		case sortTypeNumeric, sortTypeAlphabetical:
+		case sortTypeTimestamp:
+			if rule.Layout == "" {
+				return nil, fmt.Errorf("`layout` is required to sort on timestamp '%s'", rule.RegexKey)
+			}
+			if r.layout, err = strptime.ToNative(rule.Layout); err != nil {
+				return nil, fmt.Errorf("parse strptime layout of '%s': %w", rule.RegexKey, err)
+			}
+			r.location = time.UTC
+			if rule.Location != "" {
+				if r.location, err = time.LoadLocation(rule.Location); err != nil {
+					return nil, fmt.Errorf("load location of '%s': %w", rule.RegexKey, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid sort_type '%s', must be one of '%s', '%s' or '%s'",
+				rule.SortType, sortTypeNumeric, sortTypeTimestamp, sortTypeAlphabetical)
+		}
+		rules = append(rules, r)
+	}
+
+	return &fileOrdering{
+		regex: regex,
+		topN:  topN,
+		rules: rules,
+	}, nil
+}
+
+// apply sorts the paths by the sort rules, the first rule taking precedence, and returns the first topN of them.
+// Paths whose file name does not match the regex, or whose values cannot be parsed, are left out.
+func (o *fileOrdering) apply(paths []string) []string {
+	files := make([]orderedFile, 0, len(paths))
+PATHS:
+	for _, path := range paths {
+		match := o.regex.FindStringSubmatch(filepath.Base(path))
+		if match == nil {
+			continue
+		}
+
+		values := make([]sortValue, len(o.rules))
+		for i, rule := range o.rules {
+			value, err := rule.parse(match[rule.index])
+			if err != nil {
+				continue PATHS
+			}
+			values[i] = value
+		}
+		files = append(files, orderedFile{path: path, values: values})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		for k, rule := range o.rules {
+			if c := rule.compare(files[i].values[k], files[j].values[k]); c != 0 {
+				if rule.Ascending {
+					return c < 0
+				}
+				return c > 0
+			}
+		}
+		return false
+	})
+
+	if len(files) > o.topN {
+		files = files[:o.topN]
+	}
+
+	sorted := make([]string, 0, len(files))
+	for _, file := range files {
+		sorted = append(sorted, file.path)
+	}
+	return sorted
+}
+
+func (r sortRule) parse(raw string) (sortValue, error) {
+	switch r.SortType {
+	case sortTypeNumeric:
+		num, err := strconv.ParseInt(raw, 10, 64)
+		return sortValue{num: num}, err
+	case sortTypeTimestamp:
+		ts, err := time.ParseInLocation(r.layout, raw, r.location)
+		return sortValue{ts: ts}, err
+	default:
+		return sortValue{str: raw}, nil
+	}
+}
+
+func (r sortRule) compare(a, b sortValue) int {
+	switch r.SortType {
+	case sortTypeNumeric:
+		switch {
+		case a.num < b.num:
+			return -1
+		case a.num > b.num:
+			return 1
+		}
+		return 0
+	case sortTypeTimestamp:
+		switch {
+		case a.ts.Before(b.ts):
+			return -1
+		case a.ts.After(b.ts):
+			return 1
+		}
+		return 0
+	default:
+		return strings.Compare(a.str, b.str)
+	}
+}
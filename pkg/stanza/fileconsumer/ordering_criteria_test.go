@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderingCriteria(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		criteria OrderingCriteria
+		files    []string
+		expected []string
+	}{
+		{
+			name: "TimestampDescending",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<ts>\d{8}-\d{4})\.log`,
+				TopN:  2,
+				SortBy: []SortRule{
+					{RegexKey: "ts", SortType: sortTypeTimestamp, Layout: "%Y%m%d-%H%M"},
+				},
+			},
+			files:    []string{"/logs/app-20221018-1200.log", "/logs/app-20221018-1300.log", "/logs/app-20221017-2300.log"},
+			expected: []string{"/logs/app-20221018-1300.log", "/logs/app-20221018-1200.log"},
+		},
+		{
+			name: "TimestampWithLocation",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<ts>\d{8}-\d{4})\.log`,
+				TopN:  1,
+				SortBy: []SortRule{
+					{RegexKey: "ts", SortType: sortTypeTimestamp, Layout: "%Y%m%d-%H%M", Location: "America/New_York"},
+				},
+			},
+			files:    []string{"/logs/app-20221018-1200.log", "/logs/app-20221018-1300.log"},
+			expected: []string{"/logs/app-20221018-1300.log"},
+		},
+		{
+			name: "NumericAscending",
+			criteria: OrderingCriteria{
+				Regex: `batch-(?P<num>\d+)\.log`,
+				TopN:  3,
+				SortBy: []SortRule{
+					{RegexKey: "num", SortType: sortTypeNumeric, Ascending: true},
+				},
+			},
+			files:    []string{"/logs/batch-10.log", "/logs/batch-9.log", "/logs/batch-100.log", "/logs/batch-1.log"},
+			expected: []string{"/logs/batch-1.log", "/logs/batch-9.log", "/logs/batch-10.log"},
+		},
+		{
+			name: "Alphabetical",
+			criteria: OrderingCriteria{
+				Regex: `(?P<name>[a-z]+)\.log`,
+				TopN:  2,
+				SortBy: []SortRule{
+					{RegexKey: "name", SortType: sortTypeAlphabetical},
+				},
+			},
+			files:    []string{"/logs/b.log", "/logs/c.log", "/logs/a.log"},
+			expected: []string{"/logs/c.log", "/logs/b.log"},
+		},
+		{
+			name: "FirstRuleTakesPrecedence",
+			criteria: OrderingCriteria{
+				Regex: `(?P<host>[a-z]+)-(?P<num>\d+)\.log`,
+				TopN:  4,
+				SortBy: []SortRule{
+					{RegexKey: "host", SortType: sortTypeAlphabetical, Ascending: true},
+					{RegexKey: "num", SortType: sortTypeNumeric},
+				},
+			},
+			files:    []string{"/logs/b-1.log", "/logs/a-1.log", "/logs/b-2.log", "/logs/a-2.log"},
+			expected: []string{"/logs/a-2.log", "/logs/a-1.log", "/logs/b-2.log", "/logs/b-1.log"},
+		},
+		{
+			name: "MatchesFileNameDefaultTopN",
+			criteria: OrderingCriteria{
+				Regex: `^(?P<num>\d+)\.log$`,
+				SortBy: []SortRule{
+					{RegexKey: "num", SortType: sortTypeNumeric},
+				},
+			},
+			files:    []string{"/logs/1/1.log", "/logs/2/1.log", "/logs/1/3.log"},
+			expected: []string{"/logs/1/3.log"},
+		},
+		{
+			name: "SkipsUnmatchedAndUnparsable",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<ts>\d+)\.log`,
+				TopN:  5,
+				SortBy: []SortRule{
+					{RegexKey: "ts", SortType: sortTypeTimestamp, Layout: "%Y%m%d"},
+				},
+			},
+			files:    []string{"/logs/app-20221018.log", "/logs/app-20221399.log", "/logs/other.log"},
+			expected: []string{"/logs/app-20221018.log"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ordering, err := tc.criteria.build()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ordering.apply(tc.files))
+		})
+	}
+}
+
+func TestOrderingCriteriaBuild(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		criteria    OrderingCriteria
+		expectedErr string
+	}{
+		{
+			name:     "Empty",
+			criteria: OrderingCriteria{},
+		},
+		{
+			name: "SortByWithoutRegex",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			expectedErr: "`ordering_criteria.regex` is required with `ordering_criteria.sort_by`",
+		},
+		{
+			name:        "InvalidRegex",
+			criteria:    OrderingCriteria{Regex: "("},
+			expectedErr: "compile `ordering_criteria.regex`",
+		},
+		{
+			name: "InvalidTopN",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				TopN:   -1,
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			expectedErr: "`ordering_criteria.top_n` must be positive",
+		},
+		{
+			name:        "RegexWithoutSortBy",
+			criteria:    OrderingCriteria{Regex: `(?P<num>\d+)`},
+			expectedErr: "`ordering_criteria.sort_by` is required with `ordering_criteria.regex`",
+		},
+		{
+			name: "MissingCaptureGroup",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				SortBy: []SortRule{{RegexKey: "ts", SortType: sortTypeNumeric}},
+			},
+			expectedErr: "`ordering_criteria.regex` has no capture group named 'ts'",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				SortBy: []SortRule{{RegexKey: "num", SortType: "size"}},
+			},
+			expectedErr: "invalid sort_type 'size'",
+		},
+		{
+			name: "TimestampWithoutLayout",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<ts>\d+)`,
+				SortBy: []SortRule{{RegexKey: "ts", SortType: sortTypeTimestamp}},
+			},
+			expectedErr: "`layout` is required to sort on timestamp 'ts'",
+		},
+		{
+			name: "InvalidLocation",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<ts>\d+)`,
+				SortBy: []SortRule{{RegexKey: "ts", SortType: sortTypeTimestamp, Layout: "%Y%m%d", Location: "Nowhere/Nothing"}},
+			},
+			expectedErr: "load location of 'ts'",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ordering, err := tc.criteria.build()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				require.Nil(t, ordering)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -28,6 +29,7 @@ type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
 	emit            EmitFunc
+	header          *headerParser
 }
 
 // Reader manages a single file
@@ -36,11 +38,28 @@ type Reader struct {
 	*readerConfig
 	splitter *helper.Splitter
 
-	Fingerprint    *Fingerprint
-	Offset         int64
+	Fingerprint *Fingerprint
+	Offset      int64
+	// CompressedSize is the size of a compressed file once it has been read in full.
+	// The offset of a compressed file is a position in its decompressed content.
+	CompressedSize int64
+	// Header holds the metadata captured from the header lines of the file, and HeaderLines the number of them
+	Header      map[string]string `json:",omitempty"`
+	HeaderLines int               `json:",omitempty"`
+
 	generation     int
+	eof            bool
 	file           *os.File
 	fileAttributes *FileAttributes
+	decompress     decompressor
+	// failedCompressedSize is the size of a compressed file that failed to be read,
+	// the file isn't decompressed again until its size changes
+	failedCompressedSize int64
+	// unflushedCompressedSize is the size of a compressed file read up to its last entry, the file
+	// isn't decompressed again until its size changes or the entry is due to be flushed
+	unflushedCompressedSize int64
+	// src is the file, or the decompressed content of a compressed file
+	src io.Reader
 }
 
 // offsetToEnd sets the starting offset
@@ -49,39 +68,180 @@ func (r *Reader) offsetToEnd() error {
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
 	}
+	if r.decompress != nil {
+		// The decompressed size is unknown without reading the file, so it is marked as read in full instead
+		r.CompressedSize = info.Size()
+		return nil
+	}
+	if r.header != nil {
+		// The entries read from the end still need the metadata of the header
+		if err = r.readHeaderFromStart(); err != nil {
+			return err
+		}
+	}
 	r.Offset = info.Size()
 	return nil
 }
 
+// readHeaderFromStart captures the header lines at the start of the file, without emitting anything
+func (r *Reader) readHeaderFromStart() error {
+	if _, err := r.file.Seek(0, 0); err != nil {
+		return fmt.Errorf("seek: %w", err)
+	}
+	scanner := NewPositionalScanner(r.file, r.maxLogSize, 0, r.splitter.SplitFunc)
+	for scanner.Scan() {
+		token, err := r.splitter.Encoding.Decode(scanner.Bytes())
+		if err != nil || !r.readHeader(token) {
+			break
+		}
+	}
+	return nil
+}
+
+// readHeader captures the token in the metadata of the file if it is a header line, and returns true if it is
+func (r *Reader) readHeader(token []byte) bool {
+	if r.header == nil || !r.header.parse(token, r.HeaderLines, r.Header) {
+		return false
+	}
+	r.HeaderLines++
+	return true
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	if r.decompress != nil {
+		r.eof = r.readCompressedToEnd(ctx)
+		return
+	}
+
+	r.eof = false
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
+	r.src = r.file
+	ok, err := r.scan(ctx)
+	if err != nil {
+		r.Errorw("Failed during scan", zap.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+	info, err := r.file.Stat()
+	if err != nil {
+		r.Errorw("Failed to stat", zap.Error(err))
+		return
+	}
+	// The end of the file is not consumed yet when it is waiting to be flushed, e.g. a last line without a newline
+	r.eof = r.Offset == info.Size()
+}
+
+// readCompressedToEnd reads the decompressed content of a compressed file from the offset,
+// unless the file has already been read in full, and returns true once all of it has been consumed.
+// Decompressing the file again starts over from its beginning, so it is only done once the file
+// has changed, or once its last entry is due to be flushed.
+func (r *Reader) readCompressedToEnd(ctx context.Context) bool {
+	info, err := r.file.Stat()
+	if err != nil {
+		r.Errorw("Failed to stat", zap.Error(err))
+		return false
+	}
+	switch info.Size() {
+	case r.CompressedSize:
+		return true
+	case r.failedCompressedSize:
+		return false
+	case r.unflushedCompressedSize:
+		if !r.splitter.Flusher.ShouldFlush() {
+			return false
+		}
+	}
 
+	if _, err = r.file.Seek(0, 0); err != nil {
+		r.failCompressedRead(info.Size(), "Failed to seek", err)
+		return false
+	}
+	stream, err := r.decompress(r.file)
+	if err != nil {
+		r.failCompressedRead(info.Size(), "Failed to decompress", err)
+		return false
+	}
+	defer stream.Close()
+
+	// Skip the content read before, e.g. before the file was rotated and compressed
+	counter := &countingReader{reader: stream}
+	if _, err = io.CopyN(io.Discard, counter, r.Offset); err != nil {
+		r.failCompressedRead(info.Size(), "Failed to skip to offset", err)
+		return false
+	}
+	r.src = counter
+	ok, err := r.scan(ctx)
+	if err != nil {
+		r.failCompressedRead(info.Size(), "Failed during scan", err)
+		return false
+	}
+	r.failedCompressedSize = 0
+	if !ok {
+		return false
+	}
+	// The decompressed content is read again once its last entry is due to be flushed, e.g. a last line without a newline
+	if r.Offset != counter.count {
+		r.unflushedCompressedSize = info.Size()
+		return false
+	}
+	r.CompressedSize = info.Size()
+	return true
+}
+
+// failCompressedRead records that the compressed file failed to be read at its current size,
+// the failure is only logged as an error until the file is read successfully again
+func (r *Reader) failCompressedRead(size int64, msg string, err error) {
+	if r.failedCompressedSize == 0 {
+		r.Errorw(msg, zap.Error(err))
+	} else {
+		r.Debugw(msg, zap.Error(err))
+	}
+	r.failedCompressedSize = size
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+// scan emits the tokens read from the offset, and returns true once the end of the content is reached.
+// The content after the last token might still be waiting for more data or for the splitter to flush it.
+func (r *Reader) scan(ctx context.Context) (bool, error) {
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitter.SplitFunc)
 
 	// Iterate over the tokenized file, emitting entries as we go
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return false, nil
 		default:
 		}
 
 		ok := scanner.Scan()
 		if !ok {
 			if err := scanner.getError(); err != nil {
-				r.Errorw("Failed during scan", zap.Error(err))
+				return false, err
 			}
-			break
+			return true, nil
 		}
 
 		token, err := r.splitter.Encoding.Decode(scanner.Bytes())
 		if err != nil {
 			r.Errorw("decode: %w", zap.Error(err))
-		} else {
+		} else if !r.readHeader(token) {
 			r.emit(ctx, r.fileAttributes, token)
 		}
 
@@ -103,9 +263,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
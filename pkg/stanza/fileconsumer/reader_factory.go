@@ -27,6 +27,7 @@ type readerFactory struct {
 	readerConfig   *readerConfig
 	fromBeginning  bool
 	splitterConfig helper.SplitterConfig
+	compression    string
 }
 
 func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
@@ -42,6 +43,9 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
+		withCompressedSize(old.CompressedSize).
+		withUnreadCompressedSizes(old.failedCompressedSize, old.unflushedCompressedSize).
+		withHeader(old.Header, old.HeaderLines).
 		withSplitter(old.splitter).
 		build()
 }
@@ -51,15 +55,27 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	decompress, err := detectDecompressor(f.compression, file)
+	if err != nil {
+		return nil, err
+	}
+	if decompress != nil {
+		return newDecompressedFingerprint(file, f.readerConfig.fingerprintSize, decompress)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
 type readerBuilder struct {
 	*readerFactory
-	file     *os.File
-	fp       *Fingerprint
-	offset   int64
-	splitter *helper.Splitter
+	file                    *os.File
+	fp                      *Fingerprint
+	offset                  int64
+	compressedSize          int64
+	failedCompressedSize    int64
+	unflushedCompressedSize int64
+	header                  map[string]string
+	headerLines             int
+	splitter                *helper.Splitter
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -86,10 +102,38 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withCompressedSize(compressedSize int64) *readerBuilder {
+	b.compressedSize = compressedSize
+	return b
+}
+
+func (b *readerBuilder) withUnreadCompressedSizes(failedCompressedSize int64, unflushedCompressedSize int64) *readerBuilder {
+	b.failedCompressedSize = failedCompressedSize
+	b.unflushedCompressedSize = unflushedCompressedSize
+	return b
+}
+
+func (b *readerBuilder) withHeader(header map[string]string, headerLines int) *readerBuilder {
+	b.header = make(map[string]string, len(header))
+	for k, v := range header {
+		b.header[k] = v
+	}
+	b.headerLines = headerLines
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:            b.readerConfig,
+		Offset:                  b.offset,
+		CompressedSize:          b.compressedSize,
+		failedCompressedSize:    b.failedCompressedSize,
+		unflushedCompressedSize: b.unflushedCompressedSize,
+		Header:                  b.header,
+		HeaderLines:             b.headerLines,
+	}
+	if r.Header == nil {
+		r.Header = make(map[string]string)
 	}
 
 	if b.splitter != nil {
@@ -108,6 +152,11 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
+		r.fileAttributes.Header = r.Header
+		r.decompress, err = detectDecompressor(b.compression, b.file)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		r.SugaredLogger = b.SugaredLogger.With("path", "uninitialized")
 	}
@@ -26,6 +26,7 @@ require (
 require (
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/influxdata/go-syslog/v3 v3.0.1-0.20210608084020-ac565dc76ba6
+	github.com/klauspost/compress v1.15.8
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.55.0
 	go.opentelemetry.io/collector/pdata v0.55.1-0.20220711160057-6133c820fd50
 	go.uber.org/atomic v1.9.0
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.uber.org/zap"
 )
 
@@ -62,6 +63,42 @@ func (c *Config) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// Unmarshal will unmarshal a config from a confmap.Conf, decoding it through mapstructure.
+func (c *Config) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return fmt.Errorf("missing required field 'type'")
+	}
+	return c.unmarshalMap(component.ToStringMap())
+}
+
+func (c *Config) unmarshalMap(rawConfig map[string]interface{}) error {
+	typeInterface, ok := rawConfig["type"]
+	if !ok {
+		return fmt.Errorf("missing required field 'type'")
+	}
+
+	typeString, ok := typeInterface.(string)
+	if !ok {
+		return fmt.Errorf("non-string type %T for field 'type'", typeInterface)
+	}
+
+	builderFunc, ok := DefaultRegistry.Lookup(typeString)
+	if !ok {
+		return fmt.Errorf("unsupported type '%s'", typeString)
+	}
+
+	builder := builderFunc()
+	if err := UnmarshalMapstructure(rawConfig, builder); err != nil {
+		if id, ok := rawConfig["id"].(string); ok && id != "" {
+			return fmt.Errorf("unmarshal to %s with id '%s': %w", typeString, id, err)
+		}
+		return fmt.Errorf("unmarshal to %s: %w", typeString, err)
+	}
+
+	c.Builder = builder
+	return nil
+}
+
 // MarshalJSON will marshal a config to JSON.
 func (c Config) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.Builder)
@@ -17,16 +17,18 @@ package operator
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
 	"go.uber.org/zap"
 	yaml "gopkg.in/yaml.v2"
 )
 
 type FakeBuilder struct {
-	OperatorID   string   `json:"id" yaml:"id"`
-	OperatorType string   `json:"type" yaml:"type"`
-	Array        []string `json:"array" yaml:"array"`
+	OperatorID   string   `mapstructure:"id" json:"id" yaml:"id"`
+	OperatorType string   `mapstructure:"type" json:"type" yaml:"type"`
+	Array        []string `mapstructure:"array" json:"array" yaml:"array"`
 }
 
 func (f *FakeBuilder) Build(_ *zap.SugaredLogger) (Operator, error) {
@@ -150,6 +152,117 @@ func TestUnmarshalYAMLErrors(t *testing.T) {
 	})
 }
 
+func TestUnmarshalConfmapErrors(t *testing.T) {
+	t.Cleanup(func() {
+		DefaultRegistry = NewRegistry()
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		Register("fake_operator", func() Builder { return &FakeBuilder{} })
+		raw := map[string]interface{}{"type": "fake_operator", "array": []interface{}{"test"}}
+		var cfg Config
+		err := cfg.Unmarshal(confmap.NewFromStringMap(raw))
+		require.NoError(t, err)
+		require.Equal(t, &FakeBuilder{OperatorType: "fake_operator", Array: []string{"test"}}, cfg.Builder)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var cfg Config
+		err := cfg.Unmarshal(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing required field")
+	})
+
+	t.Run("MissingType", func(t *testing.T) {
+		raw := map[string]interface{}{"id": "operator"}
+		var cfg Config
+		err := cfg.Unmarshal(confmap.NewFromStringMap(raw))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing required field")
+	})
+
+	t.Run("NonStringType", func(t *testing.T) {
+		raw := map[string]interface{}{"id": "operator", "type": 123}
+		var cfg Config
+		err := cfg.Unmarshal(confmap.NewFromStringMap(raw))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "non-string type")
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		raw := map[string]interface{}{"id": "operator", "type": "unknown"}
+		var cfg Config
+		err := cfg.Unmarshal(confmap.NewFromStringMap(raw))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported type")
+	})
+
+	t.Run("TypeSpecificUnmarshal", func(t *testing.T) {
+		Register("operator", func() Builder { return &FakeBuilder{} })
+		raw := map[string]interface{}{"id": "operator", "type": "operator", "array": map[string]interface{}{"key": "value"}}
+		var cfg Config
+		err := cfg.Unmarshal(confmap.NewFromStringMap(raw))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal to operator with id 'operator'")
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		Register("operator", func() Builder { return &FakeBuilder{} })
+		raw := map[string]interface{}{"id": "operator", "type": "operator", "unknown": "value"}
+		var cfg Config
+		err := cfg.Unmarshal(confmap.NewFromStringMap(raw))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid keys: unknown")
+	})
+}
+
+func TestUnmarshalMapstructureOperators(t *testing.T) {
+	t.Cleanup(func() {
+		DefaultRegistry = NewRegistry()
+	})
+	Register("fake_operator", func() Builder { return &FakeBuilder{} })
+
+	var cfg struct {
+		Operators []Config `mapstructure:"operators"`
+	}
+	raw := map[string]interface{}{
+		"operators": []interface{}{
+			map[string]interface{}{"type": "fake_operator", "id": "first"},
+			map[string]interface{}{"type": "fake_operator", "id": "second"},
+		},
+	}
+	require.NoError(t, UnmarshalMapstructure(raw, &cfg))
+	require.Equal(t, []Config{
+		{Builder: &FakeBuilder{OperatorID: "first", OperatorType: "fake_operator"}},
+		{Builder: &FakeBuilder{OperatorID: "second", OperatorType: "fake_operator"}},
+	}, cfg.Operators)
+
+	raw["operators"] = []interface{}{map[string]interface{}{"id": "first"}}
+	err := UnmarshalMapstructure(raw, &cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing required field")
+}
+
+func TestUnmarshalMapstructureWeaklyTyped(t *testing.T) {
+	var cfg struct {
+		MaxFlushCount uint          `mapstructure:"max_flush_count"`
+		Enabled       bool          `mapstructure:"enabled"`
+		Interval      time.Duration `mapstructure:"interval"`
+		Include       []string      `mapstructure:"include"`
+	}
+	raw := map[string]interface{}{
+		"max_flush_count": "100",
+		"enabled":         "true",
+		"interval":        "5s",
+		"include":         "a.log,b.log",
+	}
+	require.NoError(t, UnmarshalMapstructure(raw, &cfg))
+	require.Equal(t, uint(100), cfg.MaxFlushCount)
+	require.True(t, cfg.Enabled)
+	require.Equal(t, 5*time.Second, cfg.Interval)
+	require.Equal(t, []string{"a.log", "b.log"}, cfg.Include)
+}
+
 func TestMarshalYAML(t *testing.T) {
 	cfg := Config{
 		Builder: &FakeBuilder{
@@ -36,8 +36,8 @@ func NewHostIdentifierConfig() HostIdentifierConfig {
 
 // HostIdentifierConfig is the configuration of a host identifier
 type HostIdentifierConfig struct {
-	IncludeHostname bool `json:"include_hostname,omitempty"     yaml:"include_hostname,omitempty"`
-	IncludeIP       bool `json:"include_ip,omitempty"     yaml:"include_ip,omitempty"`
+	IncludeHostname bool `mapstructure:"include_hostname,omitempty" json:"include_hostname,omitempty"     yaml:"include_hostname,omitempty"`
+	IncludeIP       bool `mapstructure:"include_ip,omitempty" json:"include_ip,omitempty"     yaml:"include_ip,omitempty"`
 	getHostname     func() (string, error)
 	getIP           func() (string, error)
 }
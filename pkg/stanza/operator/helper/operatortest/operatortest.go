@@ -18,13 +18,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path"
+	"reflect"
 	"testing"
 
-	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 // ConfigUnmarshalTest is used for testing golden configs
@@ -39,7 +39,7 @@ func configFromFileViaYaml(file string, config interface{}) error {
 	if err != nil {
 		return fmt.Errorf("could not find config file: %w", err)
 	}
-	if err := yaml.Unmarshal(bytes, config); err != nil {
+	if err := yaml.UnmarshalStrict(bytes, config); err != nil {
 		return fmt.Errorf("failed to read config file as yaml: %w", err)
 	}
 
@@ -58,22 +58,14 @@ func configFromFileViaMapstructure(file string, config interface{}) error {
 		return fmt.Errorf("failed to read data from yaml: %w", err)
 	}
 
-	dc := &mapstructure.DecoderConfig{Result: config, DecodeHook: helper.JSONUnmarshalerHook()}
-	ms, err := mapstructure.NewDecoder(dc)
-	if err != nil {
-		return err
-	}
-	err = ms.Decode(raw)
-	if err != nil {
-		return err
-	}
-	return nil
+	return operator.UnmarshalMapstructure(raw, config)
 }
 
 // Run Unmarshalls yaml files and compares them against the expected.
 func (c ConfigUnmarshalTest) Run(t *testing.T, config interface{}) {
-	mapConfig := config
-	yamlConfig := config
+	// Decode into independent copies of the default config
+	yamlConfig := copyConfig(config)
+	mapConfig := copyConfig(config)
 	yamlErr := configFromFileViaYaml(path.Join(".", "testdata", fmt.Sprintf("%s.yaml", c.Name)), yamlConfig)
 	mapErr := configFromFileViaMapstructure(path.Join(".", "testdata", fmt.Sprintf("%s.yaml", c.Name)), mapConfig)
 
@@ -87,3 +79,13 @@ func (c ConfigUnmarshalTest) Run(t *testing.T, config interface{}) {
 		require.Equal(t, c.Expect, mapConfig)
 	}
 }
+
+func copyConfig(config interface{}) interface{} {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Ptr {
+		return config
+	}
+	copied := reflect.New(value.Elem().Type())
+	copied.Elem().Set(value.Elem())
+	return copied.Interface()
+}
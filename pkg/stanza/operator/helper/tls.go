@@ -20,12 +20,12 @@ import (
 )
 
 type TLSServerConfig struct {
-	*configtls.TLSServerSetting `mapstructure:",squash" json:",inline" yaml:",inline"`
+	configtls.TLSServerSetting `mapstructure:",squash" json:",inline" yaml:",inline"`
 }
 
 func NewTLSServerConfig(setting *configtls.TLSServerSetting) *TLSServerConfig {
 	return &TLSServerConfig{
-		TLSServerSetting: setting,
+		TLSServerSetting: *setting,
 	}
 }
 
@@ -39,7 +39,7 @@ func TestUnmarshal(t *testing.T) {
 		{
 
 			Name:      "extra_field",
-			ExpectErr: false,
+			ExpectErr: true,
 			Expect:    defaultCfg(),
 		},
 		{
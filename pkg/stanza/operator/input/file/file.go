@@ -82,3 +82,12 @@ func setFileNameResolved(attrs *fileconsumer.FileAttributes, ent *entry.Entry) e
 func setFilePathResolved(attrs *fileconsumer.FileAttributes, ent *entry.Entry) error {
 	return ent.Set(entry.NewAttributeField("log.file.path_resolved"), attrs.PathResolved)
 }
+
+func setHeader(attrs *fileconsumer.FileAttributes, ent *entry.Entry) error {
+	for key, value := range attrs.Header {
+		if err := ent.Set(entry.NewAttributeField(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -69,6 +69,29 @@ func TestAddFileFields(t *testing.T) {
 	require.Equal(t, temp.Name(), e.Attributes["log.file.path"])
 }
 
+// TestAddHeaderFields tests that the metadata of the header lines is added to the entries of the file
+func TestAddHeaderFields(t *testing.T) {
+	t.Parallel()
+	operator, logReceived, tempDir := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Header.Pattern = `^#(?P<key>\w+):\s*(?P<value>.*)$`
+	}, nil)
+
+	// Create a file, then start
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Version: 1.0\n#Fields: date time\n2022-10-18 12:00:00\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, "2022-10-18 12:00:00", e.Body)
+	require.Equal(t, "1.0", e.Attributes["log.file.header.Version"])
+	require.Equal(t, "date time", e.Attributes["log.file.header.Fields"])
+	expectNoMessages(t, logReceived)
+}
+
 // AddFileResolvedFields tests that the `log.file.name_resolved` and `log.file.path_resolved` fields are included
 // when IncludeFileNameResolved and IncludeFilePathResolved are set to true
 func TestAddFileResolvedFields(t *testing.T) {
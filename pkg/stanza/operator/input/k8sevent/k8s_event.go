@@ -50,10 +50,10 @@ func NewConfig(operatorID string) *Config {
 
 // Config is the configuration of Input operator
 type Config struct {
-	helper.InputConfig `yaml:",inline"`
-	Namespaces         []string        `json:"namespaces" yaml:"namespaces"`
-	DiscoverNamespaces bool            `json:"discover_namespaces" yaml:"discover_namespaces"`
-	DiscoveryInterval  helper.Duration `json:"discovery_interval" yaml:"discovery_interval"`
+	helper.InputConfig `mapstructure:",squash" yaml:",inline"`
+	Namespaces         []string        `mapstructure:"namespaces" json:"namespaces" yaml:"namespaces"`
+	DiscoverNamespaces bool            `mapstructure:"discover_namespaces" json:"discover_namespaces" yaml:"discover_namespaces"`
+	DiscoveryInterval  helper.Duration `mapstructure:"discovery_interval" json:"discovery_interval" yaml:"discovery_interval"`
 }
 
 // Build will build a k8s_event_input operator from the supplied configuration
@@ -177,3 +177,26 @@ tcp:
 	require.Equal(t, "localhost:1234", cfg.TCP.ListenAddress)
 	require.Equal(t, "/tmp/test.ca", cfg.TCP.TLS.CAFile)
 }
+
+func TestConfigMapstructureUnmarshalTCP(t *testing.T) {
+	raw := map[string]interface{}{
+		"type":     "syslog_input",
+		"protocol": "rfc5424",
+		"tcp": map[string]interface{}{
+			"listen_address": "localhost:1234",
+			"tls": map[string]interface{}{
+				"ca_file":         "/tmp/test.ca",
+				"reload_interval": "5s",
+			},
+		},
+	}
+	var cfg Config
+	err := operator.UnmarshalMapstructure(raw, &cfg)
+	require.NoError(t, err)
+	require.Equal(t, syslog.RFC5424, cfg.Protocol)
+	require.Nil(t, cfg.UDP)
+	require.NotNil(t, cfg.TCP)
+	require.Equal(t, "localhost:1234", cfg.TCP.ListenAddress)
+	require.Equal(t, "/tmp/test.ca", cfg.TCP.TLS.CAFile)
+	require.Equal(t, 5*time.Second, cfg.TCP.TLS.ReloadInterval)
+}
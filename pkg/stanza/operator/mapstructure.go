@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+var configType = reflect.TypeOf(Config{})
+
+// UnmarshalMapstructure decodes the input into the result through mapstructure, with the weak typing and
+// decode hooks of the collector's confmap, so that e.g. "100" can be decoded into an int and "a,b" into a []string.
+// Operator configs are decoded into the builder registered for their type, and the other values
+// which implement json.Unmarshaler, such as durations and fields, are decoded through UnmarshalJSON.
+// Keys which do not match any field of the result are reported as errors, and null values reset
+// their field to its zero value, like they do when decoding YAML.
+func UnmarshalMapstructure(input interface{}, result interface{}) error {
+	dc := &mapstructure.DecoderConfig{
+		Result:           result,
+		ErrorUnused:      true,
+		ZeroFields:       true,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			decodeHook,
+			mapstructure.StringToSliceHookFunc(","),
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc(),
+		),
+	}
+	ms, err := mapstructure.NewDecoder(dc)
+	if err != nil {
+		return err
+	}
+	return ms.Decode(input)
+}
+
+func decodeHook(from reflect.Value, to reflect.Value) (interface{}, error) {
+	if to.Type() == configType {
+		raw := map[string]interface{}{}
+		if err := mapstructure.Decode(from.Interface(), &raw); err != nil {
+			return nil, err
+		}
+		cfg := Config{}
+		if err := cfg.unmarshalMap(raw); err != nil {
+			return nil, err
+		}
+		return cfg, nil
+	}
+
+	if to.CanAddr() {
+		to = to.Addr()
+	}
+
+	// If the destination implements the unmarshaling interface
+	u, ok := to.Interface().(json.Unmarshaler)
+	if !ok {
+		return from.Interface(), nil
+	}
+
+	// If it is nil and a pointer, create and assign the target value first
+	if to.IsNil() && to.Type().Kind() == reflect.Ptr {
+		to.Set(reflect.New(to.Type().Elem()))
+		u = to.Interface().(json.Unmarshaler)
+	}
+	bytes, err := json.Marshal(from.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.UnmarshalJSON(bytes); err != nil {
+		return to.Interface(), err
+	}
+	return to.Interface(), nil
+}
@@ -45,7 +45,7 @@ type Config struct {
 	Regex string `mapstructure:"regex" json:"regex" yaml:"regex"`
 
 	Cache struct {
-		Size uint16 `json:"size" yaml:"size"`
+		Size uint16 `mapstructure:"size" json:"size" yaml:"size"`
 	} `mapstructure:"cache" json:"cache" yaml:"cache"`
 }
 
@@ -14,6 +14,8 @@
 
 package operator // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 
+import "sort"
+
 // DefaultRegistry is a global registry of operator types to operator builders.
 var DefaultRegistry = NewRegistry()
 
@@ -45,6 +47,16 @@ func (r *Registry) Lookup(configType string) (func() Builder, bool) {
 	return nil, false
 }
 
+// Types returns the sorted list of registered operator types
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.operators))
+	for operatorType := range r.operators {
+		types = append(types, operatorType)
+	}
+	sort.Strings(types)
+	return types
+}
+
 // Register will register an operator in the default registry
 func Register(operatorType string, newBuilder func() Builder) {
 	DefaultRegistry.Register(operatorType, newBuilder)
@@ -55,3 +67,8 @@ func Register(operatorType string, newBuilder func() Builder) {
 func Lookup(configType string) (func() Builder, bool) {
 	return DefaultRegistry.Lookup(configType)
 }
+
+// Types returns the sorted list of operator types registered in the default registry.
+func Types() []string {
+	return DefaultRegistry.Types()
+}
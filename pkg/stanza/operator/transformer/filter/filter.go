@@ -47,9 +47,9 @@ func NewConfig(operatorID string) *Config {
 
 // Config is the configuration of a filter operator
 type Config struct {
-	helper.TransformerConfig `yaml:",inline"`
-	Expression               string  `json:"expr"   yaml:"expr"`
-	DropRatio                float64 `json:"drop_ratio"   yaml:"drop_ratio"`
+	helper.TransformerConfig `mapstructure:",squash" yaml:",inline"`
+	Expression               string  `mapstructure:"expr" json:"expr"   yaml:"expr"`
+	DropRatio                float64 `mapstructure:"drop_ratio" json:"drop_ratio"   yaml:"drop_ratio"`
 }
 
 // Build will build a filter operator from the supplied configuration
@@ -37,7 +37,7 @@ func NewConfig(operatorID string) *Config {
 
 // Config is the configuration of a noop operator.
 type Config struct {
-	helper.TransformerConfig `yaml:",inline"`
+	helper.TransformerConfig `mapstructure:",squash" yaml:",inline"`
 }
 
 // Build will build a noop operator.
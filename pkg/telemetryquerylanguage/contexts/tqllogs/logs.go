@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
+package tqllogs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
 
 import (
 	"fmt"
@@ -21,40 +21,49 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
-type logTransformContext struct {
+// TransformContext is the tql.TransformContext of a log record, giving access to the log record, its instrumentation scope and its resource.
+type TransformContext struct {
 	log      plog.LogRecord
 	il       pcommon.InstrumentationScope
 	resource pcommon.Resource
 }
 
-func (ctx logTransformContext) GetItem() interface{} {
+func NewTransformContext(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		log:      log,
+		il:       il,
+		resource: resource,
+	}
+}
+
+func (ctx TransformContext) GetItem() interface{} {
 	return ctx.log
 }
 
-func (ctx logTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+func (ctx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
 	return ctx.il
 }
 
-func (ctx logTransformContext) GetResource() pcommon.Resource {
+func (ctx TransformContext) GetResource() pcommon.Resource {
 	return ctx.resource
 }
 
 // pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
 type pathGetSetter struct {
 	getter tql.ExprFunc
-	setter func(ctx tql.TransformContext, val interface{})
+	setter func(ctx tql.TransformContext, val interface{}) error
 }
 
-func (path pathGetSetter) Get(ctx tql.TransformContext) interface{} {
+func (path pathGetSetter) Get(ctx tql.TransformContext) (interface{}, error) {
 	return path.getter(ctx)
 }
 
-func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) {
-	path.setter(ctx, val)
+func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) error {
+	return path.setter(ctx, val)
 }
 
 var symbolTable = map[tql.EnumSymbol]tql.Enum{
@@ -110,11 +119,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 		}
 
 		if path[1].Name == "attributes" {
-			mapKey := path[1].MapKey
-			if mapKey == nil {
+			keys := path[1].Keys
+			if len(keys) == 0 {
 				return accessResourceAttributes(), nil
 			}
-			return accessResourceAttributesKey(mapKey), nil
+			return accessResourceAttributesKey(keys), nil
 		}
 	case "instrumentation_scope":
 		if len(path) == 1 {
@@ -135,13 +144,17 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	case "severity_text":
 		return accessSeverityText(), nil
 	case "body":
-		return accessBody(), nil
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessBody(), nil
+		}
+		return accessBodyKey(keys), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "flags":
@@ -167,258 +180,299 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 
 func accessResource() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newRes, ok := val.(pcommon.Resource); ok {
 				ctx.GetResource().Attributes().Clear()
 				newRes.CopyTo(ctx.GetResource())
 			}
+			return nil
 		},
 	}
 }
 
 func accessResourceAttributes() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource().Attributes()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource().Attributes(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if attrs, ok := val.(pcommon.Map); ok {
 				ctx.GetResource().Attributes().Clear()
 				attrs.CopyTo(ctx.GetResource().Attributes())
 			}
+			return nil
 		},
 	}
 }
 
-func accessResourceAttributesKey(mapKey *string) pathGetSetter {
+func accessResourceAttributesKey(keys []tql.Key) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetResource().Attributes(), keys)
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetResource().Attributes(), keys, val)
 		},
 	}
 }
 
 func accessInstrumentationScope() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
 				newIl.CopyTo(ctx.GetInstrumentationScope())
 			}
+			return nil
 		},
 	}
 }
 
 func accessInstrumentationScopeName() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Name()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Name(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetInstrumentationScope().SetName(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessInstrumentationScopeVersion() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Version()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Version(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetInstrumentationScope().SetVersion(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessTimeUnixNano() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).Timestamp().AsTime().UnixNano()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).Timestamp().AsTime().UnixNano(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
 			}
+			return nil
 		},
 	}
 }
 
 func accessObservedTimeUnixNano() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).ObservedTimestamp().AsTime().UnixNano()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).ObservedTimestamp().AsTime().UnixNano(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
 			}
+			return nil
 		},
 	}
 }
 
 func accessSeverityNumber() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(plog.LogRecord).SeverityNumber())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(plog.LogRecord).SeverityNumber()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetSeverityNumber(plog.SeverityNumber(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessSeverityText() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).SeverityText()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).SeverityText(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if s, ok := val.(string); ok {
 				ctx.GetItem().(plog.LogRecord).SetSeverityText(s)
 			}
+			return nil
 		},
 	}
 }
 
 func accessBody() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getValue(ctx.GetItem().(plog.LogRecord).Body())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getValue(ctx.GetItem().(plog.LogRecord).Body()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			setValue(ctx.GetItem().(plog.LogRecord).Body(), val)
+			return nil
+		},
+	}
+}
+
+func accessBodyKey(keys []tql.Key) pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			val, ok, err := tql.IndexValue(ctx.GetItem().(plog.LogRecord).Body(), keys)
+			if err != nil || !ok {
+				return nil, err
+			}
+			return getValue(val), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			newVal, ok := newValue(val)
+			if !ok {
+				return nil
+			}
+			target, err := tql.UpsertValue(ctx.GetItem().(plog.LogRecord).Body(), keys)
+			if err != nil {
+				return err
+			}
+			newVal.CopyTo(target)
+			return nil
 		},
 	}
 }
 
 func accessAttributes() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).Attributes()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).Attributes(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if attrs, ok := val.(pcommon.Map); ok {
 				ctx.GetItem().(plog.LogRecord).Attributes().Clear()
 				attrs.CopyTo(ctx.GetItem().(plog.LogRecord).Attributes())
 			}
+			return nil
 		},
 	}
 }
 
-func accessAttributesKey(mapKey *string) pathGetSetter {
+func accessAttributesKey(keys []tql.Key) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey)
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetItem().(plog.LogRecord).Attributes(), keys)
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey, val)
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetItem().(plog.LogRecord).Attributes(), keys, val)
 		},
 	}
 }
 
 func accessDroppedAttributesCount() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(plog.LogRecord).DroppedAttributesCount())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(plog.LogRecord).DroppedAttributesCount()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetDroppedAttributesCount(uint32(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessFlags() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(plog.LogRecord).Flags())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(plog.LogRecord).Flags()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetFlags(uint32(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessTraceID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).TraceID()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).TraceID(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newTraceID, ok := val.(pcommon.TraceID); ok {
 				ctx.GetItem().(plog.LogRecord).SetTraceID(newTraceID)
 			}
+			return nil
 		},
 	}
 }
 
 func accessStringTraceID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).TraceID().HexString()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).TraceID().HexString(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				if traceID, err := common.ParseTraceID(str); err == nil {
+				if traceID, err := tqlcommon.ParseTraceID(str); err == nil {
 					ctx.GetItem().(plog.LogRecord).SetTraceID(traceID)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessSpanID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).SpanID()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).SpanID(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newSpanID, ok := val.(pcommon.SpanID); ok {
 				ctx.GetItem().(plog.LogRecord).SetSpanID(newSpanID)
 			}
+			return nil
 		},
 	}
 }
 
 func accessStringSpanID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(plog.LogRecord).SpanID().HexString()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(plog.LogRecord).SpanID().HexString(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				if spanID, err := common.ParseSpanID(str); err == nil {
+				if spanID, err := tqlcommon.ParseSpanID(str); err == nil {
 					ctx.GetItem().(plog.LogRecord).SetSpanID(spanID)
 				}
 			}
+			return nil
 		},
 	}
 }
 
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
-	if !ok {
-		return nil
+func getAttr(attrs pcommon.Map, keys []tql.Key) (interface{}, error) {
+	val, ok, err := tql.IndexMap(attrs, keys)
+	if err != nil || !ok {
+		return nil, err
 	}
-	return getValue(val)
+	return getValue(val), nil
 }
 
 func getValue(val pcommon.Value) interface{} {
@@ -441,101 +495,73 @@ func getValue(val pcommon.Value) interface{} {
 	return nil
 }
 
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
+func setAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) error {
+	newVal, ok := newValue(val)
+	if !ok {
+		return nil
+	}
+	target, err := tql.UpsertMapValue(attrs, keys)
+	if err != nil {
+		return err
+	}
+	newVal.CopyTo(target)
+	return nil
+}
+
+// newValue converts a value produced by a query into a pcommon.Value. ok is false for unsupported types.
+func newValue(val interface{}) (value pcommon.Value, ok bool) {
 	switch v := val.(type) {
 	case string:
-		attrs.UpsertString(mapKey, v)
+		return pcommon.NewValueString(v), true
 	case bool:
-		attrs.UpsertBool(mapKey, v)
+		return pcommon.NewValueBool(v), true
 	case int64:
-		attrs.UpsertInt(mapKey, v)
+		return pcommon.NewValueInt(v), true
 	case float64:
-		attrs.UpsertDouble(mapKey, v)
+		return pcommon.NewValueDouble(v), true
 	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
+		return pcommon.NewValueBytes(pcommon.NewImmutableByteSlice(v)), true
 	case []string:
 		arr := pcommon.NewValueSlice()
 		for _, str := range v {
 			arr.SliceVal().AppendEmpty().SetStringVal(str)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []bool:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBoolVal(b)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []int64:
 		arr := pcommon.NewValueSlice()
 		for _, i := range v {
 			arr.SliceVal().AppendEmpty().SetIntVal(i)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []float64:
 		arr := pcommon.NewValueSlice()
 		for _, f := range v {
 			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case [][]byte:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		return m, true
 	default:
-		// TODO(anuraaga): Support set of map type.
+		return pcommon.Value{}, false
 	}
 }
 
 func setValue(value pcommon.Value, val interface{}) {
-	switch v := val.(type) {
-	case string:
-		value.SetStringVal(v)
-	case bool:
-		value.SetBoolVal(v)
-	case int64:
-		value.SetIntVal(v)
-	case float64:
-		value.SetDoubleVal(v)
-	case []byte:
-		value.SetBytesVal(pcommon.NewImmutableByteSlice(v))
-	case []string:
-		value.SliceVal().RemoveIf(func(_ pcommon.Value) bool {
-			return true
-		})
-		for _, str := range v {
-			value.SliceVal().AppendEmpty().SetStringVal(str)
-		}
-	case []bool:
-		value.SliceVal().RemoveIf(func(_ pcommon.Value) bool {
-			return true
-		})
-		for _, b := range v {
-			value.SliceVal().AppendEmpty().SetBoolVal(b)
-		}
-	case []int64:
-		value.SliceVal().RemoveIf(func(_ pcommon.Value) bool {
-			return true
-		})
-		for _, i := range v {
-			value.SliceVal().AppendEmpty().SetIntVal(i)
-		}
-	case []float64:
-		value.SliceVal().RemoveIf(func(_ pcommon.Value) bool {
-			return true
-		})
-		for _, f := range v {
-			value.SliceVal().AppendEmpty().SetDoubleVal(f)
-		}
-	case [][]byte:
-		value.SliceVal().RemoveIf(func(_ pcommon.Value) bool {
-			return true
-		})
-		for _, b := range v {
-			value.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
-		}
-	default:
-		// TODO(anuraaga): Support set of map type.
+	if newVal, ok := newValue(val); ok {
+		newVal.CopyTo(value)
 	}
 }
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package logs
+package tqllogs
 
 import (
 	"encoding/hex"
@@ -216,8 +216,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -230,8 +230,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -244,8 +244,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -258,8 +258,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: float64(1.2),
@@ -272,8 +272,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -286,8 +286,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -303,8 +303,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -320,8 +320,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -337,8 +337,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -354,8 +354,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -436,8 +436,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -453,8 +453,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -470,8 +470,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -487,8 +487,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: float64(1.2),
@@ -504,8 +504,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -521,8 +521,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -541,8 +541,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -561,8 +561,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -581,8 +581,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -601,8 +601,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -622,18 +622,20 @@ func Test_newPathGetSetter(t *testing.T) {
 
 			log, il, resource := createTelemetry()
 
-			got := accessor.Get(logTransformContext{
+			got, err := accessor.Get(TransformContext{
 				log:      log,
 				il:       il,
 				resource: resource,
 			})
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(logTransformContext{
+			err = accessor.Set(TransformContext{
 				log:      log,
 				il:       il,
 				resource: resource,
 			}, tt.new)
+			assert.NoError(t, err)
 
 			exSpan, exIl, exRes := createTelemetry()
 			tt.modified(exSpan, exIl, exRes)
@@ -700,6 +702,141 @@ func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Re
 	return log, il, resource
 }
 
+func Test_newPathGetSetter_keys(t *testing.T) {
+	createLog := func() plog.LogRecord {
+		log := plog.NewLogRecord()
+		body := pcommon.NewValueMap()
+		body.MapVal().InsertString("str", "val")
+		arr := pcommon.NewValueSlice()
+		arr.SliceVal().AppendEmpty().SetIntVal(1)
+		arr.SliceVal().AppendEmpty().SetIntVal(2)
+		body.MapVal().Insert("arr", arr)
+		body.CopyTo(log.Body())
+		nested := pcommon.NewValueMap()
+		nested.MapVal().InsertBool("bool", true)
+		log.Attributes().Insert("nested", nested)
+		return log
+	}
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		new      interface{}
+		modified func(log plog.LogRecord)
+	}{
+		{
+			name: "body map key",
+			path: []tql.Field{
+				{
+					Name: "body",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
+				},
+			},
+			orig: "val",
+			new:  "newVal",
+			modified: func(log plog.LogRecord) {
+				log.Body().MapVal().UpdateString("str", "newVal")
+			},
+		},
+		{
+			name: "body slice index",
+			path: []tql.Field{
+				{
+					Name: "body",
+					Keys: []tql.Key{{String: tqltest.Strp("arr")}, {Int: tqltest.Intp(1)}},
+				},
+			},
+			orig: int64(2),
+			new:  int64(5),
+			modified: func(log plog.LogRecord) {
+				arr, _ := log.Body().MapVal().Get("arr")
+				arr.SliceVal().At(1).SetIntVal(5)
+			},
+		},
+		{
+			name: "nested attribute",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("nested")}, {String: tqltest.Strp("bool")}},
+				},
+			},
+			orig: true,
+			new:  false,
+			modified: func(log plog.LogRecord) {
+				nested, _ := log.Attributes().Get("nested")
+				nested.MapVal().UpdateBool("bool", false)
+			},
+		},
+		{
+			name: "missing nested attribute",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("missing")}, {String: tqltest.Strp("str")}},
+				},
+			},
+			orig: nil,
+			new:  "inserted",
+			modified: func(log plog.LogRecord) {
+				missing := pcommon.NewValueMap()
+				missing.MapVal().InsertString("str", "inserted")
+				log.Attributes().Insert("missing", missing)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			log := createLog()
+			ctx := TransformContext{
+				log:      log,
+				il:       pcommon.NewInstrumentationScope(),
+				resource: pcommon.NewResource(),
+			}
+
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
+
+			exLog := createLog()
+			tt.modified(exLog)
+
+			assert.Equal(t, exLog, log)
+		})
+	}
+}
+
+func Test_newPathGetSetter_keys_error(t *testing.T) {
+	log := plog.NewLogRecord()
+	log.Body().SetStringVal("body")
+	ctx := TransformContext{
+		log:      log,
+		il:       pcommon.NewInstrumentationScope(),
+		resource: pcommon.NewResource(),
+	}
+
+	accessor, err := newPathGetSetter([]tql.Field{
+		{
+			Name: "body",
+			Keys: []tql.Key{{String: tqltest.Strp("str")}},
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = accessor.Get(ctx)
+	assert.Error(t, err)
+
+	err = accessor.Set(ctx, "val")
+	assert.Error(t, err)
+}
+
 func Test_ParseEnum(t *testing.T) {
 	tests := []struct {
 		name string
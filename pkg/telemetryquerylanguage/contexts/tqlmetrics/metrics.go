@@ -13,7 +13,7 @@
 // limitations under the License.
 
 // nolint:gocritic
-package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+package tqlmetrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
 import (
 	"fmt"
 	"time"
@@ -24,7 +24,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
-type metricTransformContext struct {
+// TransformContext is the tql.TransformContext of a metric data point, giving access to the data point, its metric,
+// the metric slice the metric belongs to, and their instrumentation scope and resource. The data point may be nil
+// to evaluate queries on the metric itself.
+type TransformContext struct {
 	dataPoint interface{}
 	metric    pmetric.Metric
 	metrics   pmetric.MetricSlice
@@ -32,38 +35,48 @@ type metricTransformContext struct {
 	resource  pcommon.Resource
 }
 
-func (ctx metricTransformContext) GetItem() interface{} {
+func NewTransformContext(dataPoint interface{}, metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		dataPoint: dataPoint,
+		metric:    metric,
+		metrics:   metrics,
+		il:        il,
+		resource:  resource,
+	}
+}
+
+func (ctx TransformContext) GetItem() interface{} {
 	return ctx.dataPoint
 }
 
-func (ctx metricTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+func (ctx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
 	return ctx.il
 }
 
-func (ctx metricTransformContext) GetResource() pcommon.Resource {
+func (ctx TransformContext) GetResource() pcommon.Resource {
 	return ctx.resource
 }
 
-func (ctx metricTransformContext) GetMetric() pmetric.Metric {
+func (ctx TransformContext) GetMetric() pmetric.Metric {
 	return ctx.metric
 }
 
-func (ctx metricTransformContext) GetMetrics() pmetric.MetricSlice {
+func (ctx TransformContext) GetMetrics() pmetric.MetricSlice {
 	return ctx.metrics
 }
 
 // pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
 type pathGetSetter struct {
 	getter tql.ExprFunc
-	setter func(ctx tql.TransformContext, val interface{})
+	setter func(ctx tql.TransformContext, val interface{}) error
 }
 
-func (path pathGetSetter) Get(ctx tql.TransformContext) interface{} {
+func (path pathGetSetter) Get(ctx tql.TransformContext) (interface{}, error) {
 	return path.getter(ctx)
 }
 
-func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) {
-	path.setter(ctx, val)
+func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) error {
+	return path.setter(ctx, val)
 }
 
 var symbolTable = map[tql.EnumSymbol]tql.Enum{
@@ -105,11 +118,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 		}
 		switch path[1].Name {
 		case "attributes":
-			mapKey := path[1].MapKey
-			if mapKey == nil {
+			keys := path[1].Keys
+			if len(keys) == 0 {
 				return accessResourceAttributes(), nil
 			}
-			return accessResourceAttributesKey(mapKey), nil
+			return accessResourceAttributesKey(keys), nil
 		}
 	case "instrumentation_scope":
 		if len(path) == 1 {
@@ -140,11 +153,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 			return accessMetricIsMonotonic(), nil
 		}
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "start_time_unix_nano":
 		return accessStartTimeUnixNano(), nil
 	case "time_unix_nano":
@@ -197,163 +210,173 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 
 func accessResource() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newRes, ok := val.(pcommon.Resource); ok {
 				ctx.GetResource().Attributes().Clear()
 				newRes.CopyTo(ctx.GetResource())
 			}
+			return nil
 		},
 	}
 }
 
 func accessResourceAttributes() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource().Attributes()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource().Attributes(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if attrs, ok := val.(pcommon.Map); ok {
 				ctx.GetResource().Attributes().Clear()
 				attrs.CopyTo(ctx.GetResource().Attributes())
 			}
+			return nil
 		},
 	}
 }
 
-func accessResourceAttributesKey(mapKey *string) pathGetSetter {
+func accessResourceAttributesKey(keys []tql.Key) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetResource().Attributes(), keys)
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetResource().Attributes(), keys, val)
 		},
 	}
 }
 
 func accessInstrumentationScope() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
 				newIl.CopyTo(ctx.GetInstrumentationScope())
 			}
+			return nil
 		},
 	}
 }
 
 func accessInstrumentationScopeName() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Name()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Name(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetInstrumentationScope().SetName(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessInstrumentationScopeVersion() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Version()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Version(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetInstrumentationScope().SetVersion(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessMetric() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.(metricTransformContext).GetMetric()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetMetric(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newMetric, ok := val.(pmetric.Metric); ok {
-				newMetric.CopyTo(ctx.(metricTransformContext).GetMetric())
+				newMetric.CopyTo(ctx.(TransformContext).GetMetric())
 			}
+			return nil
 		},
 	}
 }
 
 func accessMetricName() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.(metricTransformContext).GetMetric().Name()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetMetric().Name(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				ctx.(metricTransformContext).GetMetric().SetName(str)
+				ctx.(TransformContext).GetMetric().SetName(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessMetricDescription() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.(metricTransformContext).GetMetric().Description()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetMetric().Description(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				ctx.(metricTransformContext).GetMetric().SetDescription(str)
+				ctx.(TransformContext).GetMetric().SetDescription(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessMetricUnit() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.(metricTransformContext).GetMetric().Unit()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetMetric().Unit(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				ctx.(metricTransformContext).GetMetric().SetUnit(str)
+				ctx.(TransformContext).GetMetric().SetUnit(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessMetricType() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.(metricTransformContext).GetMetric().DataType())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.(TransformContext).GetMetric().DataType()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			// TODO Implement methods so correctly convert data types.
 			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10130
+			return nil
 		},
 	}
 }
 
 func accessMetricAggTemporality() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			metric := ctx.(metricTransformContext).GetMetric()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			metric := ctx.(TransformContext).GetMetric()
 			switch metric.DataType() {
 			case pmetric.MetricDataTypeSum:
-				return int64(metric.Sum().AggregationTemporality())
+				return int64(metric.Sum().AggregationTemporality()), nil
 			case pmetric.MetricDataTypeHistogram:
-				return int64(metric.Histogram().AggregationTemporality())
+				return int64(metric.Histogram().AggregationTemporality()), nil
 			case pmetric.MetricDataTypeExponentialHistogram:
-				return int64(metric.ExponentialHistogram().AggregationTemporality())
+				return int64(metric.ExponentialHistogram().AggregationTemporality()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newAggTemporality, ok := val.(int64); ok {
-				metric := ctx.(metricTransformContext).GetMetric()
+				metric := ctx.(TransformContext).GetMetric()
 				switch metric.DataType() {
 				case pmetric.MetricDataTypeSum:
 					metric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporality(newAggTemporality))
@@ -363,48 +386,50 @@ func accessMetricAggTemporality() pathGetSetter {
 					metric.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporality(newAggTemporality))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessMetricIsMonotonic() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			metric := ctx.(metricTransformContext).GetMetric()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			metric := ctx.(TransformContext).GetMetric()
 			switch metric.DataType() {
 			case pmetric.MetricDataTypeSum:
-				return metric.Sum().IsMonotonic()
+				return metric.Sum().IsMonotonic(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newIsMonotonic, ok := val.(bool); ok {
-				metric := ctx.(metricTransformContext).GetMetric()
+				metric := ctx.(TransformContext).GetMetric()
 				switch metric.DataType() {
 				case pmetric.MetricDataTypeSum:
 					metric.Sum().SetIsMonotonic(newIsMonotonic)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessAttributes() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return ctx.GetItem().(pmetric.NumberDataPoint).Attributes()
+				return ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), nil
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).Attributes()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), nil
 			case pmetric.SummaryDataPoint:
-				return ctx.GetItem().(pmetric.SummaryDataPoint).Attributes()
+				return ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				if attrs, ok := val.(pcommon.Map); ok {
@@ -427,56 +452,58 @@ func accessAttributes() pathGetSetter {
 					attrs.CopyTo(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes())
 				}
 			}
+			return nil
 		},
 	}
 }
 
-func accessAttributesKey(mapKey *string) pathGetSetter {
+func accessAttributesKey(keys []tql.Key) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), *mapKey)
+				return getAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), keys)
 			case pmetric.HistogramDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), *mapKey)
+				return getAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), keys)
 			case pmetric.ExponentialHistogramDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), *mapKey)
+				return getAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), keys)
 			case pmetric.SummaryDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), *mapKey)
+				return getAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), keys)
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				setAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), *mapKey, val)
+				return setAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), keys, val)
 			case pmetric.HistogramDataPoint:
-				setAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), *mapKey, val)
+				return setAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), keys, val)
 			case pmetric.ExponentialHistogramDataPoint:
-				setAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), *mapKey, val)
+				return setAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), keys, val)
 			case pmetric.SummaryDataPoint:
-				setAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), *mapKey, val)
+				return setAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), keys, val)
 			}
+			return nil
 		},
 	}
 }
 
 func accessStartTimeUnixNano() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return ctx.GetItem().(pmetric.NumberDataPoint).StartTimestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.NumberDataPoint).StartTimestamp().AsTime().UnixNano(), nil
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).StartTimestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).StartTimestamp().AsTime().UnixNano(), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).StartTimestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).StartTimestamp().AsTime().UnixNano(), nil
 			case pmetric.SummaryDataPoint:
-				return ctx.GetItem().(pmetric.SummaryDataPoint).StartTimestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.SummaryDataPoint).StartTimestamp().AsTime().UnixNano(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newTime, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -489,26 +516,27 @@ func accessStartTimeUnixNano() pathGetSetter {
 					ctx.GetItem().(pmetric.SummaryDataPoint).SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, newTime)))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessTimeUnixNano() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return ctx.GetItem().(pmetric.NumberDataPoint).Timestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.NumberDataPoint).Timestamp().AsTime().UnixNano(), nil
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).Timestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).Timestamp().AsTime().UnixNano(), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Timestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Timestamp().AsTime().UnixNano(), nil
 			case pmetric.SummaryDataPoint:
-				return ctx.GetItem().(pmetric.SummaryDataPoint).Timestamp().AsTime().UnixNano()
+				return ctx.GetItem().(pmetric.SummaryDataPoint).Timestamp().AsTime().UnixNano(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newTime, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -521,64 +549,67 @@ func accessTimeUnixNano() pathGetSetter {
 					ctx.GetItem().(pmetric.SummaryDataPoint).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, newTime)))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessDoubleValue() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return ctx.GetItem().(pmetric.NumberDataPoint).DoubleVal()
+				return ctx.GetItem().(pmetric.NumberDataPoint).DoubleVal(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newDouble, ok := val.(float64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
 					ctx.GetItem().(pmetric.NumberDataPoint).SetDoubleVal(newDouble)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessIntValue() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return ctx.GetItem().(pmetric.NumberDataPoint).IntVal()
+				return ctx.GetItem().(pmetric.NumberDataPoint).IntVal(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newInt, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
 					ctx.GetItem().(pmetric.NumberDataPoint).SetIntVal(newInt)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessExemplars() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return ctx.GetItem().(pmetric.NumberDataPoint).Exemplars()
+				return ctx.GetItem().(pmetric.NumberDataPoint).Exemplars(), nil
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).Exemplars()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).Exemplars(), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Exemplars()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Exemplars(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newExemplars, ok := val.(pmetric.ExemplarSlice); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -589,26 +620,27 @@ func accessExemplars() pathGetSetter {
 					newExemplars.CopyTo(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Exemplars())
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessFlags() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return int64(ctx.GetItem().(pmetric.NumberDataPoint).Flags())
+				return int64(ctx.GetItem().(pmetric.NumberDataPoint).Flags()), nil
 			case pmetric.HistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.HistogramDataPoint).Flags())
+				return int64(ctx.GetItem().(pmetric.HistogramDataPoint).Flags()), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Flags())
+				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Flags()), nil
 			case pmetric.SummaryDataPoint:
-				return int64(ctx.GetItem().(pmetric.SummaryDataPoint).Flags())
+				return int64(ctx.GetItem().(pmetric.SummaryDataPoint).Flags()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newFlags, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -621,24 +653,25 @@ func accessFlags() pathGetSetter {
 					ctx.GetItem().(pmetric.SummaryDataPoint).SetFlags(pmetric.MetricDataPointFlags(newFlags))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessCount() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.HistogramDataPoint).Count())
+				return int64(ctx.GetItem().(pmetric.HistogramDataPoint).Count()), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Count())
+				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Count()), nil
 			case pmetric.SummaryDataPoint:
-				return int64(ctx.GetItem().(pmetric.SummaryDataPoint).Count())
+				return int64(ctx.GetItem().(pmetric.SummaryDataPoint).Count()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newCount, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
@@ -649,24 +682,25 @@ func accessCount() pathGetSetter {
 					ctx.GetItem().(pmetric.SummaryDataPoint).SetCount(uint64(newCount))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessSum() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).Sum()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).Sum(), nil
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Sum()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Sum(), nil
 			case pmetric.SummaryDataPoint:
-				return ctx.GetItem().(pmetric.SummaryDataPoint).Sum()
+				return ctx.GetItem().(pmetric.SummaryDataPoint).Sum(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newSum, ok := val.(float64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
@@ -677,236 +711,248 @@ func accessSum() pathGetSetter {
 					ctx.GetItem().(pmetric.SummaryDataPoint).SetSum(newSum)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessExplicitBounds() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).MExplicitBounds()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).MExplicitBounds(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newExplicitBounds, ok := val.([]float64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
 					ctx.GetItem().(pmetric.HistogramDataPoint).SetMExplicitBounds(newExplicitBounds)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessBucketCounts() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
-				return ctx.GetItem().(pmetric.HistogramDataPoint).MBucketCounts()
+				return ctx.GetItem().(pmetric.HistogramDataPoint).MBucketCounts(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newBucketCount, ok := val.([]uint64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
 					ctx.GetItem().(pmetric.HistogramDataPoint).SetMBucketCounts(newBucketCount)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessScale() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Scale())
+				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Scale()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newScale, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).SetScale(int32(newScale))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessZeroCount() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).ZeroCount())
+				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).ZeroCount()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newZeroCount, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).SetZeroCount(uint64(newZeroCount))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessPositive() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newPositive, ok := val.(pmetric.Buckets); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					newPositive.CopyTo(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive())
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessPositiveOffset() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().Offset())
+				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().Offset()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newPositiveOffset, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().SetOffset(int32(newPositiveOffset))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessPositiveBucketCounts() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().MBucketCounts()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().MBucketCounts(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newPositiveBucketCounts, ok := val.([]uint64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().SetMBucketCounts(newPositiveBucketCounts)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessNegative() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newNegative, ok := val.(pmetric.Buckets); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					newNegative.CopyTo(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative())
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessNegativeOffset() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().Offset())
+				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().Offset()), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newNegativeOffset, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().SetOffset(int32(newNegativeOffset))
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessNegativeBucketCounts() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
-				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().MBucketCounts()
+				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().MBucketCounts(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newNegativeBucketCounts, ok := val.([]uint64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
 					ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().SetMBucketCounts(newNegativeBucketCounts)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessQuantileValues() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			switch ctx.GetItem().(type) {
 			case pmetric.SummaryDataPoint:
-				return ctx.GetItem().(pmetric.SummaryDataPoint).QuantileValues()
+				return ctx.GetItem().(pmetric.SummaryDataPoint).QuantileValues(), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newQuantileValues, ok := val.(pmetric.ValueAtQuantileSlice); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.SummaryDataPoint:
 					newQuantileValues.CopyTo(ctx.GetItem().(pmetric.SummaryDataPoint).QuantileValues())
 				}
 			}
+			return nil
 		},
 	}
 }
 
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
-	if !ok {
-		return nil
+func getAttr(attrs pcommon.Map, keys []tql.Key) (interface{}, error) {
+	val, ok, err := tql.IndexMap(attrs, keys)
+	if err != nil || !ok {
+		return nil, err
 	}
-	return getValue(val)
+	return getValue(val), nil
 }
 
 func getValue(val pcommon.Value) interface{} {
@@ -929,49 +975,67 @@ func getValue(val pcommon.Value) interface{} {
 	return nil
 }
 
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
+func setAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) error {
+	newVal, ok := newValue(val)
+	if !ok {
+		return nil
+	}
+	target, err := tql.UpsertMapValue(attrs, keys)
+	if err != nil {
+		return err
+	}
+	newVal.CopyTo(target)
+	return nil
+}
+
+// newValue converts a value produced by a query into a pcommon.Value. ok is false for unsupported types.
+func newValue(val interface{}) (value pcommon.Value, ok bool) {
 	switch v := val.(type) {
 	case string:
-		attrs.UpsertString(mapKey, v)
+		return pcommon.NewValueString(v), true
 	case bool:
-		attrs.UpsertBool(mapKey, v)
+		return pcommon.NewValueBool(v), true
 	case int64:
-		attrs.UpsertInt(mapKey, v)
+		return pcommon.NewValueInt(v), true
 	case float64:
-		attrs.UpsertDouble(mapKey, v)
+		return pcommon.NewValueDouble(v), true
 	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
+		return pcommon.NewValueBytes(pcommon.NewImmutableByteSlice(v)), true
 	case []string:
 		arr := pcommon.NewValueSlice()
 		for _, str := range v {
 			arr.SliceVal().AppendEmpty().SetStringVal(str)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []bool:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBoolVal(b)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []int64:
 		arr := pcommon.NewValueSlice()
 		for _, i := range v {
 			arr.SliceVal().AppendEmpty().SetIntVal(i)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []float64:
 		arr := pcommon.NewValueSlice()
 		for _, f := range v {
 			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case [][]byte:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		return m, true
 	default:
-		// TODO(anuraaga): Support set of map type.
+		return pcommon.Value{}, false
 	}
 }
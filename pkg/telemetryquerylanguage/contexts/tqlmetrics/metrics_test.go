@@ -12,7 +12,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+package tqlmetrics
+
 import (
 	"testing"
 	"time"
@@ -135,8 +136,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -149,8 +150,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -163,8 +164,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -177,8 +178,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: float64(1.2),
@@ -191,8 +192,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -205,8 +206,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -222,8 +223,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -239,8 +240,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -256,8 +257,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -273,8 +274,8 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -294,17 +295,19 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 
 			numberDataPoint := createNumberDataPointTelemetry(tt.valueType)
 
-			ctx := metricTransformContext{
+			ctx := TransformContext{
 				dataPoint: numberDataPoint,
 				metric:    pmetric.NewMetric(),
 				il:        pcommon.NewInstrumentationScope(),
 				resource:  pcommon.NewResource(),
 			}
 
-			got := accessor.Get(ctx)
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(ctx, tt.new)
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
 
 			exNumberDataPoint := createNumberDataPointTelemetry(tt.valueType)
 			tt.modified(exNumberDataPoint)
@@ -467,8 +470,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -481,8 +484,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -495,8 +498,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -509,8 +512,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: float64(1.2),
@@ -523,8 +526,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -537,8 +540,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -554,8 +557,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -571,8 +574,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -588,8 +591,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -605,8 +608,8 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -626,17 +629,19 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 
 			numberDataPoint := createHistogramDataPointTelemetry()
 
-			ctx := metricTransformContext{
+			ctx := TransformContext{
 				dataPoint: numberDataPoint,
 				metric:    pmetric.NewMetric(),
 				il:        pcommon.NewInstrumentationScope(),
 				resource:  pcommon.NewResource(),
 			}
 
-			got := accessor.Get(ctx)
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(ctx, tt.new)
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
 
 			exNumberDataPoint := createHistogramDataPointTelemetry()
 			tt.modified(exNumberDataPoint)
@@ -895,8 +900,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -909,8 +914,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -923,8 +928,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -937,8 +942,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: 1.2,
@@ -951,8 +956,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -965,8 +970,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -982,8 +987,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -999,8 +1004,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1016,8 +1021,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1033,8 +1038,8 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1054,17 +1059,19 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 
 			numberDataPoint := createExpoHistogramDataPointTelemetry()
 
-			ctx := metricTransformContext{
+			ctx := TransformContext{
 				dataPoint: numberDataPoint,
 				metric:    pmetric.NewMetric(),
 				il:        pcommon.NewInstrumentationScope(),
 				resource:  pcommon.NewResource(),
 			}
 
-			got := accessor.Get(ctx)
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(ctx, tt.new)
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
 
 			exNumberDataPoint := createExpoHistogramDataPointTelemetry()
 			tt.modified(exNumberDataPoint)
@@ -1208,8 +1215,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -1222,8 +1229,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -1236,8 +1243,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -1250,8 +1257,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: 1.2,
@@ -1264,8 +1271,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -1278,8 +1285,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1295,8 +1302,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1312,8 +1319,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1329,8 +1336,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1346,8 +1353,8 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1367,17 +1374,19 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 
 			numberDataPoint := createSummaryDataPointTelemetry()
 
-			ctx := metricTransformContext{
+			ctx := TransformContext{
 				dataPoint: numberDataPoint,
 				metric:    pmetric.NewMetric(),
 				il:        pcommon.NewInstrumentationScope(),
 				resource:  pcommon.NewResource(),
 			}
 
-			got := accessor.Get(ctx)
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(ctx, tt.new)
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
 
 			exNumberDataPoint := createSummaryDataPointTelemetry()
 			tt.modified(exNumberDataPoint)
@@ -1563,17 +1572,19 @@ func Test_newPathGetSetter_Metric(t *testing.T) {
 
 			metric := createMetricTelemetry()
 
-			ctx := metricTransformContext{
+			ctx := TransformContext{
 				dataPoint: pmetric.NewNumberDataPoint(),
 				metric:    metric,
 				il:        pcommon.NewInstrumentationScope(),
 				resource:  pcommon.NewResource(),
 			}
 
-			got := accessor.Get(ctx)
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(ctx, tt.new)
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
 
 			exMetric := createMetricTelemetry()
 			tt.modified(exMetric)
@@ -0,0 +1,526 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:gocritic
+package tqlspanevents // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// TransformContext is the tql.TransformContext of a span event, giving access to the span event, the span it
+// belongs to, its instrumentation scope and its resource.
+type TransformContext struct {
+	spanEvent ptrace.SpanEvent
+	span      ptrace.Span
+	il        pcommon.InstrumentationScope
+	resource  pcommon.Resource
+}
+
+func NewTransformContext(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		spanEvent: spanEvent,
+		span:      span,
+		il:        il,
+		resource:  resource,
+	}
+}
+
+func (ctx TransformContext) GetItem() interface{} {
+	return ctx.spanEvent
+}
+
+func (ctx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.il
+}
+
+func (ctx TransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+func (ctx TransformContext) GetSpan() ptrace.Span {
+	return ctx.span
+}
+
+// pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
+type pathGetSetter struct {
+	getter tql.ExprFunc
+	setter func(ctx tql.TransformContext, val interface{}) error
+}
+
+func (path pathGetSetter) Get(ctx tql.TransformContext) (interface{}, error) {
+	return path.getter(ctx)
+}
+
+func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) error {
+	return path.setter(ctx, val)
+}
+
+var symbolTable = map[tql.EnumSymbol]tql.Enum{
+	"SPAN_KIND_UNSPECIFIED": 0,
+	"SPAN_KIND_INTERNAL":    1,
+	"SPAN_KIND_SERVER":      2,
+	"SPAN_KIND_CLIENT":      3,
+	"SPAN_KIND_PRODUCER":    4,
+	"SPAN_KIND_CONSUMER":    5,
+	"STATUS_CODE_UNSET":     0,
+	"STATUS_CODE_OK":        1,
+	"STATUS_CODE_ERROR":     2,
+}
+
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	if val != nil {
+		if enum, ok := symbolTable[*val]; ok {
+			return &enum, nil
+		}
+		return nil, fmt.Errorf("enum symbol, %s, not found", *val)
+	}
+	return nil, fmt.Errorf("enum symbol not provided")
+}
+
+func ParsePath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource":
+		if len(path) == 1 {
+			return accessResource(), nil
+		}
+		switch path[1].Name {
+		case "attributes":
+			keys := path[1].Keys
+			if len(keys) == 0 {
+				return accessResourceAttributes(), nil
+			}
+			return accessResourceAttributesKey(keys), nil
+		}
+	case "instrumentation_library":
+		if len(path) == 1 {
+			return accessInstrumentationScope(), nil
+		}
+		switch path[1].Name {
+		case "name":
+			return accessInstrumentationScopeName(), nil
+		case "version":
+			return accessInstrumentationScopeVersion(), nil
+		}
+	case "span":
+		if len(path) == 1 {
+			return accessSpan(), nil
+		}
+		switch path[1].Name {
+		case "name":
+			return accessSpanName(), nil
+		case "kind":
+			return accessSpanKind(), nil
+		case "attributes":
+			keys := path[1].Keys
+			if len(keys) == 0 {
+				return accessSpanAttributes(), nil
+			}
+			return accessSpanAttributesKey(keys), nil
+		case "status":
+			if len(path) == 2 {
+				return accessSpanStatus(), nil
+			}
+			switch path[2].Name {
+			case "code":
+				return accessSpanStatusCode(), nil
+			case "message":
+				return accessSpanStatusMessage(), nil
+			}
+		}
+	case "name":
+		return accessName(), nil
+	case "time_unix_nano":
+		return accessTimeUnixNano(), nil
+	case "attributes":
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessAttributes(), nil
+		}
+		return accessAttributesKey(keys), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount(), nil
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+
+	return nil, fmt.Errorf("invalid path expression %v", path)
+}
+
+func accessResource() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if newRes, ok := val.(pcommon.Resource); ok {
+				ctx.GetResource().Attributes().Clear()
+				newRes.CopyTo(ctx.GetResource())
+			}
+			return nil
+		},
+	}
+}
+
+func accessResourceAttributes() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource().Attributes(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				ctx.GetResource().Attributes().Clear()
+				attrs.CopyTo(ctx.GetResource().Attributes())
+			}
+			return nil
+		},
+	}
+}
+
+func accessResourceAttributesKey(keys []tql.Key) pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetResource().Attributes(), keys)
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetResource().Attributes(), keys, val)
+		},
+	}
+}
+
+func accessInstrumentationScope() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
+				newIl.CopyTo(ctx.GetInstrumentationScope())
+			}
+			return nil
+		},
+	}
+}
+
+func accessInstrumentationScopeName() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Name(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				ctx.GetInstrumentationScope().SetName(str)
+			}
+			return nil
+		},
+	}
+}
+
+func accessInstrumentationScopeVersion() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Version(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				ctx.GetInstrumentationScope().SetVersion(str)
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpan() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetSpan(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if newSpan, ok := val.(ptrace.Span); ok {
+				newSpan.CopyTo(ctx.(TransformContext).GetSpan())
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanName() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetSpan().Name(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				ctx.(TransformContext).GetSpan().SetName(str)
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanKind() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.(TransformContext).GetSpan().Kind()), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if i, ok := val.(int64); ok {
+				ctx.(TransformContext).GetSpan().SetKind(ptrace.SpanKind(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanAttributes() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetSpan().Attributes(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				ctx.(TransformContext).GetSpan().Attributes().Clear()
+				attrs.CopyTo(ctx.(TransformContext).GetSpan().Attributes())
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanAttributesKey(keys []tql.Key) pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.(TransformContext).GetSpan().Attributes(), keys)
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.(TransformContext).GetSpan().Attributes(), keys, val)
+		},
+	}
+}
+
+func accessSpanStatus() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetSpan().Status(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if status, ok := val.(ptrace.SpanStatus); ok {
+				status.CopyTo(ctx.(TransformContext).GetSpan().Status())
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanStatusCode() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.(TransformContext).GetSpan().Status().Code()), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if i, ok := val.(int64); ok {
+				ctx.(TransformContext).GetSpan().Status().SetCode(ptrace.StatusCode(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanStatusMessage() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.(TransformContext).GetSpan().Status().Message(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				ctx.(TransformContext).GetSpan().Status().SetMessage(str)
+			}
+			return nil
+		},
+	}
+}
+
+func accessName() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.SpanEvent).Name(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetName(str)
+			}
+			return nil
+		},
+	}
+}
+
+func accessTimeUnixNano() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.SpanEvent).Timestamp().AsTime().UnixNano(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessAttributes() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.SpanEvent).Attributes(), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				ctx.GetItem().(ptrace.SpanEvent).Attributes().Clear()
+				attrs.CopyTo(ctx.GetItem().(ptrace.SpanEvent).Attributes())
+			}
+			return nil
+		},
+	}
+}
+
+func accessAttributesKey(keys []tql.Key) pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetItem().(ptrace.SpanEvent).Attributes(), keys)
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetItem().(ptrace.SpanEvent).Attributes(), keys, val)
+		},
+	}
+}
+
+func accessDroppedAttributesCount() pathGetSetter {
+	return pathGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(ptrace.SpanEvent).DroppedAttributesCount()), nil
+		},
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetDroppedAttributesCount(uint32(i))
+			}
+			return nil
+		},
+	}
+}
+
+func getAttr(attrs pcommon.Map, keys []tql.Key) (interface{}, error) {
+	val, ok, err := tql.IndexMap(attrs, keys)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return getValue(val), nil
+}
+
+func getValue(val pcommon.Value) interface{} {
+	switch val.Type() {
+	case pcommon.ValueTypeString:
+		return val.StringVal()
+	case pcommon.ValueTypeBool:
+		return val.BoolVal()
+	case pcommon.ValueTypeInt:
+		return val.IntVal()
+	case pcommon.ValueTypeDouble:
+		return val.DoubleVal()
+	case pcommon.ValueTypeMap:
+		return val.MapVal()
+	case pcommon.ValueTypeSlice:
+		return val.SliceVal()
+	case pcommon.ValueTypeBytes:
+		return val.MBytesVal()
+	}
+	return nil
+}
+
+func setAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) error {
+	newVal, ok := newValue(val)
+	if !ok {
+		return nil
+	}
+	target, err := tql.UpsertMapValue(attrs, keys)
+	if err != nil {
+		return err
+	}
+	newVal.CopyTo(target)
+	return nil
+}
+
+// newValue converts a value produced by a query into a pcommon.Value. ok is false for unsupported types.
+func newValue(val interface{}) (value pcommon.Value, ok bool) {
+	switch v := val.(type) {
+	case string:
+		return pcommon.NewValueString(v), true
+	case bool:
+		return pcommon.NewValueBool(v), true
+	case int64:
+		return pcommon.NewValueInt(v), true
+	case float64:
+		return pcommon.NewValueDouble(v), true
+	case []byte:
+		return pcommon.NewValueBytes(pcommon.NewImmutableByteSlice(v)), true
+	case []string:
+		arr := pcommon.NewValueSlice()
+		for _, str := range v {
+			arr.SliceVal().AppendEmpty().SetStringVal(str)
+		}
+		return arr, true
+	case []bool:
+		arr := pcommon.NewValueSlice()
+		for _, b := range v {
+			arr.SliceVal().AppendEmpty().SetBoolVal(b)
+		}
+		return arr, true
+	case []int64:
+		arr := pcommon.NewValueSlice()
+		for _, i := range v {
+			arr.SliceVal().AppendEmpty().SetIntVal(i)
+		}
+		return arr, true
+	case []float64:
+		arr := pcommon.NewValueSlice()
+		for _, f := range v {
+			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
+		}
+		return arr, true
+	case [][]byte:
+		arr := pcommon.NewValueSlice()
+		for _, b := range v {
+			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
+		}
+		return arr, true
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		return m, true
+	default:
+		return pcommon.Value{}, false
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlspanevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refSpanEvent, refSpan, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.UpsertString("hello", "world")
+
+	newStatus := ptrace.NewSpanStatus()
+	newStatus.SetMessage("new status")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		new      interface{}
+		modified func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig: "event",
+			new:  "new event",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetName("new event")
+			},
+		},
+		{
+			name: "time_unix_nano",
+			path: []tql.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig: int64(100_000_000),
+			new:  int64(200_000_000),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig: refSpanEvent.Attributes(),
+			new:  newAttrs,
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.Attributes().Clear()
+				newAttrs.CopyTo(spanEvent.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
+				},
+			},
+			orig: "val",
+			new:  "newVal",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.Attributes().UpsertString("str", "newVal")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig: int64(10),
+			new:  int64(20),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "span name",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig: "bear",
+			new:  "cat",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.SetName("cat")
+			},
+		},
+		{
+			name: "span kind",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "kind",
+				},
+			},
+			orig: int64(2),
+			new:  int64(3),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.SetKind(ptrace.SpanKindClient)
+			},
+		},
+		{
+			name: "span attributes",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "attributes",
+				},
+			},
+			orig: refSpan.Attributes(),
+			new:  newAttrs,
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Attributes().Clear()
+				newAttrs.CopyTo(span.Attributes())
+			},
+		},
+		{
+			name: "span attributes string",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("http.method"),
+						},
+					},
+				},
+			},
+			orig: "get",
+			new:  "post",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Attributes().UpsertString("http.method", "post")
+			},
+		},
+		{
+			name: "span status",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "status",
+				},
+			},
+			orig: refSpan.Status(),
+			new:  newStatus,
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newStatus.CopyTo(span.Status())
+			},
+		},
+		{
+			name: "span status code",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "status",
+				},
+				{
+					Name: "code",
+				},
+			},
+			orig: int64(ptrace.StatusCodeOk),
+			new:  int64(ptrace.StatusCodeError),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Status().SetCode(ptrace.StatusCodeError)
+			},
+		},
+		{
+			name: "span status message",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "status",
+				},
+				{
+					Name: "message",
+				},
+			},
+			orig: "good span",
+			new:  "bad span",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Status().SetMessage("bad span")
+			},
+		},
+		{
+			name: "instrumentation_library name",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_library",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig: "library",
+			new:  "park",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("park")
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("host.name"),
+						},
+					},
+				},
+			},
+			orig: "localhost",
+			new:  "remotehost",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().UpsertString("host.name", "remotehost")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			spanEvent, span, il, resource := createTelemetry()
+			ctx := NewTransformContext(spanEvent, span, il, resource)
+
+			got, err := accessor.Get(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(ctx, tt.new)
+			assert.NoError(t, err)
+
+			exSpanEvent, exSpan, exIl, exRes := createTelemetry()
+			tt.modified(exSpanEvent, exSpan, exIl, exRes)
+
+			assert.Equal(t, exSpanEvent, spanEvent)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{
+				{
+					Name: "trace_id",
+				},
+			},
+		},
+		{
+			name: "unknown span field",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "links",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createTelemetry() (ptrace.SpanEvent, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	span := ptrace.NewSpan()
+	span.SetName("bear")
+	span.SetKind(ptrace.SpanKindServer)
+	span.Attributes().UpsertString("http.method", "get")
+	span.Status().SetCode(ptrace.StatusCodeOk)
+	span.Status().SetMessage("good span")
+
+	spanEvent := span.Events().AppendEmpty()
+	spanEvent.SetName("event")
+	spanEvent.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	spanEvent.Attributes().UpsertString("str", "val")
+	spanEvent.Attributes().UpsertInt("int", 10)
+	spanEvent.SetDroppedAttributesCount(10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().UpsertString("host.name", "localhost")
+
+	return spanEvent, span, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want tql.Enum
+	}{
+		{
+			name: "SPAN_KIND_SERVER",
+			want: tql.Enum(ptrace.SpanKindServer),
+		},
+		{
+			name: "STATUS_CODE_ERROR",
+			want: tql.Enum(ptrace.StatusCodeError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseEnum((*tql.EnumSymbol)(tqltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	actual, err := ParseEnum((*tql.EnumSymbol)(tqltest.Strp("SPAN_KIND_UNKNOWN")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
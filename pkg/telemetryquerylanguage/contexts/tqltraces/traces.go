@@ -13,7 +13,7 @@
 // limitations under the License.
 
 // nolint:gocritic
-package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
+package tqltraces // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
 
 import (
 	"fmt"
@@ -23,40 +23,49 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
-type spanTransformContext struct {
+// TransformContext is the tql.TransformContext of a span, giving access to the span, its instrumentation scope and its resource.
+type TransformContext struct {
 	span     ptrace.Span
 	il       pcommon.InstrumentationScope
 	resource pcommon.Resource
 }
 
-func (ctx spanTransformContext) GetItem() interface{} {
+func NewTransformContext(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		span:     span,
+		il:       il,
+		resource: resource,
+	}
+}
+
+func (ctx TransformContext) GetItem() interface{} {
 	return ctx.span
 }
 
-func (ctx spanTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+func (ctx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
 	return ctx.il
 }
 
-func (ctx spanTransformContext) GetResource() pcommon.Resource {
+func (ctx TransformContext) GetResource() pcommon.Resource {
 	return ctx.resource
 }
 
 // pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
 type pathGetSetter struct {
 	getter tql.ExprFunc
-	setter func(ctx tql.TransformContext, val interface{})
+	setter func(ctx tql.TransformContext, val interface{}) error
 }
 
-func (path pathGetSetter) Get(ctx tql.TransformContext) interface{} {
+func (path pathGetSetter) Get(ctx tql.TransformContext) (interface{}, error) {
 	return path.getter(ctx)
 }
 
-func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) {
-	path.setter(ctx, val)
+func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) error {
+	return path.setter(ctx, val)
 }
 
 var symbolTable = map[tql.EnumSymbol]tql.Enum{
@@ -96,11 +105,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 		}
 		switch path[1].Name {
 		case "attributes":
-			mapKey := path[1].MapKey
-			if mapKey == nil {
+			keys := path[1].Keys
+			if len(keys) == 0 {
 				return accessResourceAttributes(), nil
 			}
-			return accessResourceAttributesKey(mapKey), nil
+			return accessResourceAttributesKey(keys), nil
 		}
 	case "instrumentation_library":
 		if len(path) == 1 {
@@ -129,11 +138,13 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 			return accessStringSpanID(), nil
 		}
 	case "trace_state":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessTraceState(), nil
 		}
-		return accessTraceStateKey(mapKey), nil
+		if len(keys) == 1 && keys[0].String != nil {
+			return accessTraceStateKey(keys[0].String), nil
+		}
 	case "parent_span_id":
 		return accessParentSpanID(), nil
 	case "name":
@@ -145,11 +156,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	case "end_time_unix_nano":
 		return accessEndTimeUnixNano(), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "events":
@@ -179,160 +190,170 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 
 func accessResource() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newRes, ok := val.(pcommon.Resource); ok {
 				ctx.GetResource().Attributes().Clear()
 				newRes.CopyTo(ctx.GetResource())
 			}
+			return nil
 		},
 	}
 }
 
 func accessResourceAttributes() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource().Attributes()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetResource().Attributes(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if attrs, ok := val.(pcommon.Map); ok {
 				ctx.GetResource().Attributes().Clear()
 				attrs.CopyTo(ctx.GetResource().Attributes())
 			}
+			return nil
 		},
 	}
 }
 
-func accessResourceAttributesKey(mapKey *string) pathGetSetter {
+func accessResourceAttributesKey(keys []tql.Key) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetResource().Attributes(), keys)
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetResource().Attributes(), keys, val)
 		},
 	}
 }
 
 func accessInstrumentationScope() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
 				newIl.CopyTo(ctx.GetInstrumentationScope())
 			}
+			return nil
 		},
 	}
 }
 
 func accessInstrumentationScopeName() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Name()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Name(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetInstrumentationScope().SetName(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessInstrumentationScopeVersion() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Version()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetInstrumentationScope().Version(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetInstrumentationScope().SetVersion(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessTraceID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).TraceID()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).TraceID(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newTraceID, ok := val.(pcommon.TraceID); ok {
 				ctx.GetItem().(ptrace.Span).SetTraceID(newTraceID)
 			}
+			return nil
 		},
 	}
 }
 
 func accessStringTraceID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).TraceID().HexString()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).TraceID().HexString(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				if traceID, err := common.ParseTraceID(str); err == nil {
+				if traceID, err := tqlcommon.ParseTraceID(str); err == nil {
 					ctx.GetItem().(ptrace.Span).SetTraceID(traceID)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessSpanID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).SpanID()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).SpanID(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newSpanID, ok := val.(pcommon.SpanID); ok {
 				ctx.GetItem().(ptrace.Span).SetSpanID(newSpanID)
 			}
+			return nil
 		},
 	}
 }
 
 func accessStringSpanID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).SpanID().HexString()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).SpanID().HexString(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
-				if spanID, err := common.ParseSpanID(str); err == nil {
+				if spanID, err := tqlcommon.ParseSpanID(str); err == nil {
 					ctx.GetItem().(ptrace.Span).SetSpanID(spanID)
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessTraceState() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return (string)(ctx.GetItem().(ptrace.Span).TraceState())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return (string)(ctx.GetItem().(ptrace.Span).TraceState()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetItem().(ptrace.Span).SetTraceState(ptrace.TraceState(str))
 			}
+			return nil
 		},
 	}
 }
 
 func accessTraceStateKey(mapKey *string) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			if ts, err := trace.ParseTraceState(string(ctx.GetItem().(ptrace.Span).TraceState())); err == nil {
-				return ts.Get(*mapKey)
+				return ts.Get(*mapKey), nil
 			}
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				if ts, err := trace.ParseTraceState(string(ctx.GetItem().(ptrace.Span).TraceState())); err == nil {
 					if updated, err := ts.Insert(*mapKey, str); err == nil {
@@ -340,215 +361,234 @@ func accessTraceStateKey(mapKey *string) pathGetSetter {
 					}
 				}
 			}
+			return nil
 		},
 	}
 }
 
 func accessParentSpanID() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).ParentSpanID()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).ParentSpanID(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if newParentSpanID, ok := val.(pcommon.SpanID); ok {
 				ctx.GetItem().(ptrace.Span).SetParentSpanID(newParentSpanID)
 			}
+			return nil
 		},
 	}
 }
 
 func accessName() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).Name()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).Name(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetItem().(ptrace.Span).SetName(str)
 			}
+			return nil
 		},
 	}
 }
 
 func accessKind() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(ptrace.Span).Kind())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(ptrace.Span).Kind()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).SetKind(ptrace.SpanKind(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessStartTimeUnixNano() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).StartTimestamp().AsTime().UnixNano()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).StartTimestamp().AsTime().UnixNano(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
 			}
+			return nil
 		},
 	}
 }
 
 func accessEndTimeUnixNano() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).EndTimestamp().AsTime().UnixNano()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).EndTimestamp().AsTime().UnixNano(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
 			}
+			return nil
 		},
 	}
 }
 
 func accessAttributes() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).Attributes()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).Attributes(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if attrs, ok := val.(pcommon.Map); ok {
 				ctx.GetItem().(ptrace.Span).Attributes().Clear()
 				attrs.CopyTo(ctx.GetItem().(ptrace.Span).Attributes())
 			}
+			return nil
 		},
 	}
 }
 
-func accessAttributesKey(mapKey *string) pathGetSetter {
+func accessAttributesKey(keys []tql.Key) pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetItem().(ptrace.Span).Attributes(), *mapKey)
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return getAttr(ctx.GetItem().(ptrace.Span).Attributes(), keys)
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetItem().(ptrace.Span).Attributes(), *mapKey, val)
+		setter: func(ctx tql.TransformContext, val interface{}) error {
+			return setAttr(ctx.GetItem().(ptrace.Span).Attributes(), keys, val)
 		},
 	}
 }
 
 func accessDroppedAttributesCount() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(ptrace.Span).DroppedAttributesCount())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(ptrace.Span).DroppedAttributesCount()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).SetDroppedAttributesCount(uint32(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessEvents() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).Events()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).Events(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if slc, ok := val.(ptrace.SpanEventSlice); ok {
 				ctx.GetItem().(ptrace.Span).Events().RemoveIf(func(event ptrace.SpanEvent) bool {
 					return true
 				})
 				slc.CopyTo(ctx.GetItem().(ptrace.Span).Events())
 			}
+			return nil
 		},
 	}
 }
 
 func accessDroppedEventsCount() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(ptrace.Span).DroppedEventsCount())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(ptrace.Span).DroppedEventsCount()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).SetDroppedEventsCount(uint32(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessLinks() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).Links()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).Links(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if slc, ok := val.(ptrace.SpanLinkSlice); ok {
 				ctx.GetItem().(ptrace.Span).Links().RemoveIf(func(event ptrace.SpanLink) bool {
 					return true
 				})
 				slc.CopyTo(ctx.GetItem().(ptrace.Span).Links())
 			}
+			return nil
 		},
 	}
 }
 
 func accessDroppedLinksCount() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(ptrace.Span).DroppedLinksCount())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(ptrace.Span).DroppedLinksCount()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).SetDroppedLinksCount(uint32(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessStatus() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).Status()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).Status(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if status, ok := val.(ptrace.SpanStatus); ok {
 				status.CopyTo(ctx.GetItem().(ptrace.Span).Status())
 			}
+			return nil
 		},
 	}
 }
 
 func accessStatusCode() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return int64(ctx.GetItem().(ptrace.Span).Status().Code())
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return int64(ctx.GetItem().(ptrace.Span).Status().Code()), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(ptrace.Span).Status().SetCode(ptrace.StatusCode(i))
 			}
+			return nil
 		},
 	}
 }
 
 func accessStatusMessage() pathGetSetter {
 	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(ptrace.Span).Status().Message()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(ptrace.Span).Status().Message(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			if str, ok := val.(string); ok {
 				ctx.GetItem().(ptrace.Span).Status().SetMessage(str)
 			}
+			return nil
 		},
 	}
 }
 
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
-	if !ok {
-		return nil
+func getAttr(attrs pcommon.Map, keys []tql.Key) (interface{}, error) {
+	val, ok, err := tql.IndexMap(attrs, keys)
+	if err != nil || !ok {
+		return nil, err
 	}
+	return getValue(val), nil
+}
+
+func getValue(val pcommon.Value) interface{} {
 	switch val.Type() {
 	case pcommon.ValueTypeString:
 		return val.StringVal()
@@ -568,49 +608,67 @@ func getAttr(attrs pcommon.Map, mapKey string) interface{} {
 	return nil
 }
 
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
+func setAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) error {
+	newVal, ok := newValue(val)
+	if !ok {
+		return nil
+	}
+	target, err := tql.UpsertMapValue(attrs, keys)
+	if err != nil {
+		return err
+	}
+	newVal.CopyTo(target)
+	return nil
+}
+
+// newValue converts a value produced by a query into a pcommon.Value. ok is false for unsupported types.
+func newValue(val interface{}) (value pcommon.Value, ok bool) {
 	switch v := val.(type) {
 	case string:
-		attrs.UpsertString(mapKey, v)
+		return pcommon.NewValueString(v), true
 	case bool:
-		attrs.UpsertBool(mapKey, v)
+		return pcommon.NewValueBool(v), true
 	case int64:
-		attrs.UpsertInt(mapKey, v)
+		return pcommon.NewValueInt(v), true
 	case float64:
-		attrs.UpsertDouble(mapKey, v)
+		return pcommon.NewValueDouble(v), true
 	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
+		return pcommon.NewValueBytes(pcommon.NewImmutableByteSlice(v)), true
 	case []string:
 		arr := pcommon.NewValueSlice()
 		for _, str := range v {
 			arr.SliceVal().AppendEmpty().SetStringVal(str)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []bool:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBoolVal(b)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []int64:
 		arr := pcommon.NewValueSlice()
 		for _, i := range v {
 			arr.SliceVal().AppendEmpty().SetIntVal(i)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case []float64:
 		arr := pcommon.NewValueSlice()
 		for _, f := range v {
 			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
 	case [][]byte:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-		attrs.Upsert(mapKey, arr)
+		return arr, true
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		return m, true
 	default:
-		// TODO(anuraaga): Support set of map type.
+		return pcommon.Value{}, false
 	}
 }
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package traces
+package tqltraces
 
 import (
 	"encoding/hex"
@@ -146,8 +146,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "trace_state key",
 			path: []tql.Field{
 				{
-					Name:   "trace_state",
-					MapKey: tqltest.Strp("key1"),
+					Name: "trace_state",
+					Keys: []tql.Key{{String: tqltest.Strp("key1")}},
 				},
 			},
 			orig: "val1",
@@ -239,8 +239,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -253,8 +253,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -267,8 +267,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -281,8 +281,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: float64(1.2),
@@ -295,8 +295,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -309,8 +309,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -326,8 +326,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -343,8 +343,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -360,8 +360,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -377,8 +377,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -530,8 +530,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("str")}},
 				},
 			},
 			orig: "val",
@@ -547,8 +547,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bool")}},
 				},
 			},
 			orig: true,
@@ -564,8 +564,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("int")}},
 				},
 			},
 			orig: int64(10),
@@ -581,8 +581,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("double")}},
 				},
 			},
 			orig: float64(1.2),
@@ -598,8 +598,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("bytes")}},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -615,8 +615,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -635,8 +635,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -655,8 +655,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -675,8 +675,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -695,8 +695,8 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{{String: tqltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -716,18 +716,20 @@ func Test_newPathGetSetter(t *testing.T) {
 
 			span, il, resource := createTelemetry()
 
-			got := accessor.Get(spanTransformContext{
+			got, err := accessor.Get(TransformContext{
 				span:     span,
 				il:       il,
 				resource: resource,
 			})
+			assert.NoError(t, err)
 			assert.Equal(t, tt.orig, got)
 
-			accessor.Set(spanTransformContext{
+			err = accessor.Set(TransformContext{
 				span:     span,
 				il:       il,
 				resource: resource,
 			}, tt.new)
+			assert.NoError(t, err)
 
 			exSpan, exIl, exRes := createTelemetry()
 			tt.modified(exSpan, exIl, exRes)
@@ -0,0 +1,40 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+
+import (
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func Concat(delimiter string, vals []tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		builder := strings.Builder{}
+		for i, getter := range vals {
+			val, err := getter.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				builder.WriteString(delimiter)
+			}
+			if val != nil {
+				builder.WriteString(toString(val))
+			}
+		}
+		return builder.String(), nil
+	}, nil
+}
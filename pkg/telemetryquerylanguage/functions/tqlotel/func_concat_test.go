@@ -0,0 +1,84 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Concat(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.InsertString("key", "value")
+
+	tests := []struct {
+		name      string
+		delimiter string
+		vals      []tql.Getter
+		expected  string
+	}{
+		{
+			name:      "strings",
+			delimiter: "-",
+			vals:      []tql.Getter{testLiteral{value: "hello"}, testLiteral{value: "world"}},
+			expected:  "hello-world",
+		},
+		{
+			name:      "empty delimiter",
+			delimiter: "",
+			vals:      []tql.Getter{testLiteral{value: "hello"}, testLiteral{value: "world"}},
+			expected:  "helloworld",
+		},
+		{
+			name:      "mixed types",
+			delimiter: ",",
+			vals: []tql.Getter{
+				testLiteral{value: int64(1)},
+				testLiteral{value: 2.5},
+				testLiteral{value: true},
+				testLiteral{value: []byte{1, 2}},
+				testLiteral{value: attrs},
+			},
+			expected: `1,2.5,true,0102,{"key":"value"}`,
+		},
+		{
+			name:      "nil value",
+			delimiter: "|",
+			vals:      []tql.Getter{testLiteral{value: "a"}, testLiteral{value: nil}, testLiteral{value: "b"}},
+			expected:  "a||b",
+		},
+		{
+			name:      "no values",
+			delimiter: "|",
+			vals:      []tql.Getter{},
+			expected:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Concat(tt.delimiter, tt.vals)
+			assert.NoError(t, err)
+
+			result, err := exprFunc(tqltest.TestTransformContext{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Int(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		expected interface{}
+	}{
+		{
+			name:     "int",
+			target:   int64(5),
+			expected: int64(5),
+		},
+		{
+			name:     "float",
+			target:   2.9,
+			expected: int64(2),
+		},
+		{
+			name:     "true",
+			target:   true,
+			expected: int64(1),
+		},
+		{
+			name:     "false",
+			target:   false,
+			expected: int64(0),
+		},
+		{
+			name:     "string",
+			target:   "-42",
+			expected: int64(-42),
+		},
+		{
+			name:     "invalid string",
+			target:   "1.5",
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			target:   nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Int(testLiteral{value: tt.target})
+			assert.NoError(t, err)
+
+			result, err := exprFunc(tqltest.TestTransformContext{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
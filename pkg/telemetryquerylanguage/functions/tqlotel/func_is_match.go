@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
 
 import (
 	"fmt"
@@ -21,17 +21,19 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
-func isMatch(target tql.Getter, pattern string) (tql.ExprFunc, error) {
+func IsMatch(target tql.Getter, pattern string) (tql.ExprFunc, error) {
 	regexp, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to IsMatch is not a valid regexp pattern: %w", err)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		if val := target.Get(ctx); val != nil {
-			if valStr, ok := val.(string); ok {
-				return regexp.MatchString(valStr)
-			}
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
 		}
-		return false
+		if valStr, ok := val.(string); ok {
+			return regexp.MatchString(valStr), nil
+		}
+		return false, nil
 	}, nil
 }
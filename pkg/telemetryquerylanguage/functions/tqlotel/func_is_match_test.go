@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package common
+package tqlotel
 
 import (
 	"testing"
@@ -23,7 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
 )
 
-func Test_isMatch(t *testing.T) {
+func Test_IsMatch(t *testing.T) {
 	tests := []struct {
 		name     string
 		target   tql.Getter
@@ -33,8 +33,8 @@ func Test_isMatch(t *testing.T) {
 		{
 			name: "replace match true",
 			target: &testGetSetter{
-				getter: func(ctx tql.TransformContext) interface{} {
-					return "hello world"
+				getter: func(ctx tql.TransformContext) (interface{}, error) {
+					return "hello world", nil
 				},
 			},
 			pattern:  "hello.*",
@@ -43,8 +43,8 @@ func Test_isMatch(t *testing.T) {
 		{
 			name: "replace match false",
 			target: &testGetSetter{
-				getter: func(ctx tql.TransformContext) interface{} {
-					return "goodbye world"
+				getter: func(ctx tql.TransformContext) (interface{}, error) {
+					return "goodbye world", nil
 				},
 			},
 			pattern:  "hello.*",
@@ -53,8 +53,8 @@ func Test_isMatch(t *testing.T) {
 		{
 			name: "replace match complex",
 			target: &testGetSetter{
-				getter: func(ctx tql.TransformContext) interface{} {
-					return "-12.001"
+				getter: func(ctx tql.TransformContext) (interface{}, error) {
+					return "-12.001", nil
 				},
 			},
 			pattern:  "[-+]?\\d*\\.\\d+([eE][-+]?\\d+)?",
@@ -63,8 +63,8 @@ func Test_isMatch(t *testing.T) {
 		{
 			name: "target not a string",
 			target: &testGetSetter{
-				getter: func(ctx tql.TransformContext) interface{} {
-					return 1
+				getter: func(ctx tql.TransformContext) (interface{}, error) {
+					return 1, nil
 				},
 			},
 			pattern:  "doesnt matter will be false",
@@ -73,8 +73,8 @@ func Test_isMatch(t *testing.T) {
 		{
 			name: "target nil",
 			target: &testGetSetter{
-				getter: func(ctx tql.TransformContext) interface{} {
-					return nil
+				getter: func(ctx tql.TransformContext) (interface{}, error) {
+					return nil, nil
 				},
 			},
 			pattern:  "doesnt matter will be false",
@@ -85,20 +85,21 @@ func Test_isMatch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := tqltest.TestTransformContext{}
 
-			exprFunc, _ := isMatch(tt.target, tt.pattern)
-			actual := exprFunc(ctx)
+			exprFunc, _ := IsMatch(tt.target, tt.pattern)
+			actual, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func Test_isMatch_validation(t *testing.T) {
+func Test_IsMatch_validation(t *testing.T) {
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return "anything"
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return "anything", nil
 		},
 	}
-	_, err := isMatch(target, "\\K")
+	_, err := IsMatch(target, "\\K")
 	assert.Error(t, err)
 }
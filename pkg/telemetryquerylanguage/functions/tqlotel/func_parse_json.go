@@ -0,0 +1,43 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+
+import (
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// ParseJSON returns the JSON object in target as a pcommon.Map. JSON numbers are converted to doubles.
+// It returns nil if target is not a string or not a valid JSON object.
+func ParseJSON(target tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		str, ok := val.(string)
+		if !ok {
+			return nil, nil
+		}
+		var parsed map[string]interface{}
+		if err = json.Unmarshal([]byte(str), &parsed); err != nil {
+			return nil, nil
+		}
+		return pcommon.NewMapFromRaw(parsed), nil
+	}, nil
+}
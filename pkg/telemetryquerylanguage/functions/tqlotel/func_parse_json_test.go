@@ -0,0 +1,89 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ParseJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+		want   func(pcommon.Map)
+	}{
+		{
+			name:   "flat object",
+			target: `{"str":"value","num":2.5,"bool":true}`,
+			want: func(expected pcommon.Map) {
+				expected.InsertString("str", "value")
+				expected.InsertDouble("num", 2.5)
+				expected.InsertBool("bool", true)
+			},
+		},
+		{
+			name:   "nested object and array",
+			target: `{"nested":{"key":"value"},"arr":["a","b"]}`,
+			want: func(expected pcommon.Map) {
+				nested := pcommon.NewValueMap()
+				nested.MapVal().InsertString("key", "value")
+				expected.Insert("nested", nested)
+				arr := pcommon.NewValueSlice()
+				arr.SliceVal().AppendEmpty().SetStringVal("a")
+				arr.SliceVal().AppendEmpty().SetStringVal("b")
+				expected.Insert("arr", arr)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseJSON(testLiteral{value: tt.target})
+			assert.NoError(t, err)
+
+			result, err := exprFunc(tqltest.TestTransformContext{})
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.Sort(), result.(pcommon.Map).Sort())
+		})
+	}
+}
+
+func Test_ParseJSON_not_string(t *testing.T) {
+	exprFunc, err := ParseJSON(testLiteral{value: int64(1)})
+	assert.NoError(t, err)
+
+	result, err := exprFunc(tqltest.TestTransformContext{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_ParseJSON_invalid(t *testing.T) {
+	for _, target := range []string{`not json`, `["an", "array"]`} {
+		exprFunc, err := ParseJSON(testLiteral{value: target})
+		assert.NoError(t, err)
+
+		result, err := exprFunc(tqltest.TestTransformContext{})
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	}
+}
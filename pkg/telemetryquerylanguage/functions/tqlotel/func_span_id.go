@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
 
 import (
 	"errors"
@@ -22,14 +22,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
-func spanID(bytes []byte) (tql.ExprFunc, error) {
+func SpanID(bytes []byte) (tql.ExprFunc, error) {
 	if len(bytes) != 8 {
 		return nil, errors.New("span ids must be 8 bytes")
 	}
 	var idArr [8]byte
 	copy(idArr[:8], bytes)
 	id := pcommon.NewSpanID(idArr)
-	return func(ctx tql.TransformContext) interface{} {
-		return id
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		return id, nil
 	}, nil
 }
@@ -0,0 +1,72 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Split(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    interface{}
+		delimiter string
+		expected  interface{}
+	}{
+		{
+			name:      "split string",
+			target:    "A|B|C",
+			delimiter: "|",
+			expected:  []string{"A", "B", "C"},
+		},
+		{
+			name:      "delimiter not found",
+			target:    "A|B|C",
+			delimiter: ",",
+			expected:  []string{"A|B|C"},
+		},
+		{
+			name:      "multi character delimiter",
+			target:    "A::B",
+			delimiter: "::",
+			expected:  []string{"A", "B"},
+		},
+		{
+			name:      "not a string",
+			target:    int64(1),
+			delimiter: "|",
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Split(testLiteral{value: tt.target}, tt.delimiter)
+			assert.NoError(t, err)
+
+			result, err := exprFunc(tqltest.TestTransformContext{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Split_validation(t *testing.T) {
+	_, err := Split(testLiteral{value: "A|B"}, "")
+	assert.Error(t, err)
+}
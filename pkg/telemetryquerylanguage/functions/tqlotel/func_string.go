@@ -0,0 +1,74 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// String converts target to a string, see toString. nil is returned when target is nil.
+func String(target tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		return toString(val), nil
+	}, nil
+}
+
+// toString converts a value to a string. Byte slices and IDs are hex encoded, and maps and slices are
+// encoded as JSON.
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		return hex.EncodeToString(v)
+	case pcommon.TraceID:
+		return v.HexString()
+	case pcommon.SpanID:
+		return v.HexString()
+	case pcommon.Map:
+		return toJSON(v.AsRaw())
+	case pcommon.Slice:
+		return toJSON(v.AsRaw())
+	default:
+		return toJSON(v)
+	}
+}
+
+func toJSON(val interface{}) string {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
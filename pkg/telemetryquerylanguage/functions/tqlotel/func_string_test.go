@@ -0,0 +1,87 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_String(t *testing.T) {
+	slice := pcommon.NewSlice()
+	slice.AppendEmpty().SetStringVal("a")
+	slice.AppendEmpty().SetIntVal(1)
+
+	tests := []struct {
+		name     string
+		target   interface{}
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			target:   "str",
+			expected: "str",
+		},
+		{
+			name:     "int",
+			target:   int64(-5),
+			expected: "-5",
+		},
+		{
+			name:     "float",
+			target:   1.25,
+			expected: "1.25",
+		},
+		{
+			name:     "bool",
+			target:   false,
+			expected: "false",
+		},
+		{
+			name:     "bytes",
+			target:   []byte{0xde, 0xad},
+			expected: "dead",
+		},
+		{
+			name:     "span id",
+			target:   pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+			expected: "0102030405060708",
+		},
+		{
+			name:     "slice",
+			target:   slice,
+			expected: `["a",1]`,
+		},
+		{
+			name:     "nil",
+			target:   nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := String(testLiteral{value: tt.target})
+			assert.NoError(t, err)
+
+			result, err := exprFunc(tqltest.TestTransformContext{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// Substring returns length characters of target starting at the character at index start. Characters are
+// counted in runes, so that multi-byte characters are never split. It returns nil if target is not a string
+// or if the range exceeds its length.
+func Substring(target tql.Getter, start int64, length int64) (tql.ExprFunc, error) {
+	if start < 0 {
+		return nil, fmt.Errorf("invalid start for substring function, %d cannot be negative", start)
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid length for substring function, %d cannot be negative or zero", length)
+	}
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok {
+			return nil, nil
+		}
+		runes := []rune(valStr)
+		if start+length > int64(len(runes)) {
+			return nil, nil
+		}
+		return string(runes[start : start+length]), nil
+	}, nil
+}
@@ -0,0 +1,94 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Substring(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		start    int64
+		length   int64
+		expected interface{}
+	}{
+		{
+			name:     "prefix",
+			target:   "123456789",
+			start:    0,
+			length:   3,
+			expected: "123",
+		},
+		{
+			name:     "middle",
+			target:   "123456789",
+			start:    3,
+			length:   4,
+			expected: "4567",
+		},
+		{
+			name:     "whole string",
+			target:   "123456789",
+			start:    0,
+			length:   9,
+			expected: "123456789",
+		},
+		{
+			name:     "multi-byte characters",
+			target:   "héllo wörld",
+			start:    1,
+			length:   4,
+			expected: "éllo",
+		},
+		{
+			name:     "out of range",
+			target:   "123",
+			start:    1,
+			length:   3,
+			expected: nil,
+		},
+		{
+			name:     "not a string",
+			target:   int64(123456789),
+			start:    0,
+			length:   3,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Substring(testLiteral{value: tt.target}, tt.start, tt.length)
+			assert.NoError(t, err)
+
+			result, err := exprFunc(tqltest.TestTransformContext{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Substring_validation(t *testing.T) {
+	_, err := Substring(testLiteral{value: "123"}, -1, 1)
+	assert.Error(t, err)
+
+	_, err = Substring(testLiteral{value: "123"}, 0, 0)
+	assert.Error(t, err)
+}
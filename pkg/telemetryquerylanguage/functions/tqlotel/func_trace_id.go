@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
 
 import (
 	"errors"
@@ -22,14 +22,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
-func traceID(bytes []byte) (tql.ExprFunc, error) {
+func TraceID(bytes []byte) (tql.ExprFunc, error) {
 	if len(bytes) != 16 {
 		return nil, errors.New("traces ids must be 16 bytes")
 	}
 	var idArr [16]byte
 	copy(idArr[:16], bytes)
 	id := pcommon.NewTraceID(idArr)
-	return func(ctx tql.TransformContext) interface{} {
-		return id
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		return id, nil
 	}, nil
 }
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package common
+package tqlotel
 
 import (
 	"testing"
@@ -23,7 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
 )
 
-func Test_traceID(t *testing.T) {
+func Test_TraceID(t *testing.T) {
 	tests := []struct {
 		name  string
 		bytes []byte
@@ -40,15 +40,16 @@ func Test_traceID(t *testing.T) {
 
 			ctx := tqltest.TestTransformContext{}
 
-			exprFunc, _ := traceID(tt.bytes)
-			actual := exprFunc(ctx)
+			exprFunc, _ := TraceID(tt.bytes)
+			actual, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			assert.Equal(t, tt.want, actual)
 		})
 	}
 }
 
-func Test_traceID_validation(t *testing.T) {
+func Test_TraceID_validation(t *testing.T) {
 	tests := []struct {
 		name  string
 		bytes []byte
@@ -64,7 +65,7 @@ func Test_traceID_validation(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := traceID(tt.bytes)
+			_, err := TraceID(tt.bytes)
 			assert.Error(t, err, "traces ids must be 16 bytes")
 		})
 	}
@@ -0,0 +1,39 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+
+var converters = map[string]interface{}{
+	"TraceID":   TraceID,
+	"SpanID":    SpanID,
+	"IsMatch":   IsMatch,
+	"ParseJSON": ParseJSON,
+	"Split":     Split,
+	"Concat":    Concat,
+	"SHA256":    SHA256,
+	"Int":       Int,
+	"Double":    Double,
+	"String":    String,
+	"Substring": Substring,
+}
+
+// Converters returns the functions that compute a value from their arguments without modifying telemetry.
+// They are available to every component that evaluates queries or conditions.
+func Converters() map[string]interface{} {
+	functions := make(map[string]interface{}, len(converters))
+	for name, f := range converters {
+		functions[name] = f
+	}
+	return functions
+}
@@ -19,39 +19,47 @@ import (
 )
 
 // BoolExpressionEvaluator is a function that returns the result.
-type BoolExpressionEvaluator = func(ctx TransformContext) bool
+type BoolExpressionEvaluator = func(ctx TransformContext) (bool, error)
 
-var alwaysTrue = func(ctx TransformContext) bool {
-	return true
+var alwaysTrue = func(ctx TransformContext) (bool, error) {
+	return true, nil
 }
 
-var alwaysFalse = func(ctx TransformContext) bool {
-	return false
+var alwaysFalse = func(ctx TransformContext) (bool, error) {
+	return false, nil
 }
 
 // builds a function that returns a short-circuited result of ANDing
 // BoolExpressionEvaluator funcs
 func andFuncs(funcs []BoolExpressionEvaluator) BoolExpressionEvaluator {
-	return func(ctx TransformContext) bool {
+	return func(ctx TransformContext) (bool, error) {
 		for _, f := range funcs {
-			if !f(ctx) {
-				return false
+			result, err := f(ctx)
+			if err != nil {
+				return false, err
+			}
+			if !result {
+				return false, nil
 			}
 		}
-		return true
+		return true, nil
 	}
 }
 
 // builds a function that returns a short-circuited result of ORing
 // BoolExpressionEvaluator funcs
 func orFuncs(funcs []BoolExpressionEvaluator) BoolExpressionEvaluator {
-	return func(ctx TransformContext) bool {
+	return func(ctx TransformContext) (bool, error) {
 		for _, f := range funcs {
-			if f(ctx) {
-				return true
+			result, err := f(ctx)
+			if err != nil {
+				return false, err
+			}
+			if result {
+				return true, nil
 			}
 		}
-		return false
+		return false, nil
 	}
 }
 
@@ -71,16 +79,20 @@ func newComparisonEvaluator(comparison *Comparison, functions map[string]interfa
 
 	switch comparison.Op {
 	case "==":
-		return func(ctx TransformContext) bool {
-			a := left.Get(ctx)
-			b := right.Get(ctx)
-			return a == b
+		return func(ctx TransformContext) (bool, error) {
+			a, b, err := getOperands(ctx, left, right)
+			if err != nil {
+				return false, err
+			}
+			return a == b, nil
 		}, nil
 	case "!=":
-		return func(ctx TransformContext) bool {
-			a := left.Get(ctx)
-			b := right.Get(ctx)
-			return a != b
+		return func(ctx TransformContext) (bool, error) {
+			a, b, err := getOperands(ctx, left, right)
+			if err != nil {
+				return false, err
+			}
+			return a != b, nil
 		}, nil
 	}
 
@@ -149,3 +161,15 @@ func newBooleanValueEvaluator(value *BooleanValue, functions map[string]interfac
 
 	return nil, fmt.Errorf("unhandled boolean operation %v", value)
 }
+
+func getOperands(ctx TransformContext, left Getter, right Getter) (interface{}, interface{}, error) {
+	a, err := left.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := right.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
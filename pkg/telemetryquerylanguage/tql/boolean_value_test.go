@@ -124,9 +124,11 @@ func Test_newComparisonEvaluator(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			evaluate, err := newComparisonEvaluator(tt.comparison, DefaultFunctionsForTests(), testParsePath, testParseEnum)
 			assert.NoError(t, err)
-			assert.True(t, evaluate(tqltest.TestTransformContext{
+			result, err := evaluate(tqltest.TestTransformContext{
 				Item: tt.item,
-			}))
+			})
+			assert.NoError(t, err)
+			assert.True(t, result)
 		})
 	}
 }
@@ -354,9 +356,11 @@ func Test_newBooleanExpressionEvaluator(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			evaluate, err := newBooleanExpressionEvaluator(tt.expr, DefaultFunctionsForTests(), testParsePath, testParseEnum)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.want, evaluate(tqltest.TestTransformContext{
+			result, err := evaluate(tqltest.TestTransformContext{
 				Item: nil,
-			}))
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
 		})
 	}
 }
@@ -26,16 +26,16 @@ type TransformContext interface {
 	GetResource() pcommon.Resource
 }
 
-type ExprFunc func(ctx TransformContext) interface{}
+type ExprFunc func(ctx TransformContext) (interface{}, error)
 
 type Enum int64
 
 type Getter interface {
-	Get(ctx TransformContext) interface{}
+	Get(ctx TransformContext) (interface{}, error)
 }
 
 type Setter interface {
-	Set(ctx TransformContext, val interface{})
+	Set(ctx TransformContext, val interface{}) error
 }
 
 type GetSetter interface {
@@ -47,15 +47,15 @@ type literal struct {
 	value interface{}
 }
 
-func (l literal) Get(ctx TransformContext) interface{} {
-	return l.value
+func (l literal) Get(ctx TransformContext) (interface{}, error) {
+	return l.value, nil
 }
 
 type exprGetter struct {
 	expr ExprFunc
 }
 
-func (g exprGetter) Get(ctx TransformContext) interface{} {
+func (g exprGetter) Get(ctx TransformContext) (interface{}, error) {
 	return g.expr(ctx)
 }
 
@@ -92,6 +92,10 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 		return pathParser(val.Path)
 	}
 
+	if val.MathExpression != nil {
+		return newMathExpressionGetter(val.MathExpression, functions, pathParser, enumParser)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the transformprocessor")
@@ -23,8 +23,8 @@ import (
 )
 
 func hello() (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "world"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "world", nil
 	}, nil
 }
 
@@ -113,9 +113,10 @@ func Test_newGetter(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			reader, err := NewGetter(tt.val, functions, testParsePath, testParseEnum)
 			assert.NoError(t, err)
-			val := reader.Get(tqltest.TestTransformContext{
+			val, err := reader.Get(tqltest.TestTransformContext{
 				Item: tt.want,
 			})
+			assert.NoError(t, err)
 			assert.Equal(t, tt.want, val)
 		})
 	}
@@ -129,13 +130,13 @@ func Test_newGetter(t *testing.T) {
 // pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
 type testGetSetter struct {
 	getter ExprFunc
-	setter func(ctx TransformContext, val interface{})
+	setter func(ctx TransformContext, val interface{}) error
 }
 
-func (path testGetSetter) Get(ctx TransformContext) interface{} {
+func (path testGetSetter) Get(ctx TransformContext) (interface{}, error) {
 	return path.getter(ctx)
 }
 
-func (path testGetSetter) Set(ctx TransformContext, val interface{}) {
-	path.setter(ctx, val)
+func (path testGetSetter) Set(ctx TransformContext, val interface{}) error {
+	return path.setter(ctx, val)
 }
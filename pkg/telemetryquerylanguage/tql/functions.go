@@ -50,7 +50,7 @@ func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interfac
 		argType := fType.In(i)
 
 		if argType.Kind() == reflect.Slice {
-			err := buildSliceArg(inv, argType, i, &args)
+			err := buildSliceArg(inv, argType, i, &args, functions, pathParser, enumParser)
 			if err != nil {
 				return nil, err
 			}
@@ -69,7 +69,8 @@ func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interfac
 	return args, nil
 }
 
-func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args *[]reflect.Value) error {
+func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args *[]reflect.Value,
+	functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) error {
 	switch argType.Elem().Kind() {
 	case reflect.String:
 		arg := make([]string, 0)
@@ -103,6 +104,19 @@ func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args
 			return fmt.Errorf("invalid argument for slice parameter at position %v, must be a byte slice literal", startingIndex)
 		}
 		*args = append(*args, reflect.ValueOf(([]byte)(*inv.Arguments[startingIndex].Bytes)))
+	case reflect.Interface:
+		if argType.Elem().Name() != "Getter" {
+			return fmt.Errorf("unsupported slice type for function %v", inv.Function)
+		}
+		arg := make([]Getter, 0)
+		for j := startingIndex; j < len(inv.Arguments); j++ {
+			getter, err := NewGetter(inv.Arguments[j], functions, pathParser, enumParser)
+			if err != nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v %w", j, err)
+			}
+			arg = append(arg, getter)
+		}
+		*args = append(*args, reflect.ValueOf(arg))
 	default:
 		return fmt.Errorf("unsupported slice type for function %v", inv.Function)
 	}
@@ -32,6 +32,7 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 	functions["testing_string"] = functionWithString
 	functions["testing_byte_slice"] = functionWithByteSlice
 	functions["testing_enum"] = functionWithEnum
+	functions["testing_getter_slice"] = functionWithGetterSlice
 
 	tests := []struct {
 		name string
@@ -44,6 +45,22 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				Arguments: []Value{},
 			},
 		},
+		{
+			name: "invalid getter slice arg",
+			inv: Invocation{
+				Function: "testing_getter_slice",
+				Arguments: []Value{
+					{
+						String: tqltest.Strp("test"),
+					},
+					{
+						Invocation: &Invocation{
+							Function: "unknownfunc",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "not accessor",
 			inv: Invocation{
@@ -166,6 +183,29 @@ func Test_NewFunctionCall(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "getter slice arg",
+			inv: Invocation{
+				Function: "testing_getter_slice",
+				Arguments: []Value{
+					{
+						String: tqltest.Strp("test"),
+					},
+					{
+						Int: tqltest.Intp(1),
+					},
+					{
+						Path: &Path{
+							Fields: []Field{
+								{
+									Name: "name",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "float slice arg",
 			inv: Invocation{
@@ -370,87 +410,93 @@ func Test_NewFunctionCall(t *testing.T) {
 }
 
 func functionWithStringSlice(_ []string) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithFloatSlice(_ []float64) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithIntSlice(_ []int64) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithByteSlice(_ []byte) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
+	}, nil
+}
+
+func functionWithGetterSlice(_ []Getter) (ExprFunc, error) {
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithSetter(_ Setter) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithGetSetter(_ GetSetter) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithGetter(_ Getter) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithString(_ string) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithFloat(_ float64) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithInt(_ int64) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithBool(_ bool) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionWithMultipleArgs(_ GetSetter, _ string, _ float64, _ int64, _ []string) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
 func functionThatHasAnError() (ExprFunc, error) {
 	err := errors.New("testing")
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, err
 }
 
 func functionWithEnum(_ Enum) (ExprFunc, error) {
-	return func(ctx TransformContext) interface{} {
-		return "anything"
+	return func(ctx TransformContext) (interface{}, error) {
+		return "anything", nil
 	}, nil
 }
 
@@ -460,6 +506,7 @@ func DefaultFunctionsForTests() map[string]interface{} {
 	functions["testing_float_slice"] = functionWithFloatSlice
 	functions["testing_int_slice"] = functionWithIntSlice
 	functions["testing_byte_slice"] = functionWithByteSlice
+	functions["testing_getter_slice"] = functionWithGetterSlice
 	functions["testing_setter"] = functionWithSetter
 	functions["testing_getsetter"] = functionWithGetSetter
 	functions["testing_getter"] = functionWithGetter
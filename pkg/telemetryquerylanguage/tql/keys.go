@@ -0,0 +1,148 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// IndexError is returned when a key can't be used to index a value.
+type IndexError struct {
+	Key    Key
+	Reason string
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("invalid key %s: %s", formatKey(e.Key), e.Reason)
+}
+
+func formatKey(k Key) string {
+	if k.String != nil {
+		return "[" + strconv.Quote(*k.String) + "]"
+	}
+	if k.Int != nil {
+		return "[" + strconv.FormatInt(*k.Int, 10) + "]"
+	}
+	return "[]"
+}
+
+// IndexMap returns the value of m, or of its nested maps and slices, at the given keys. ok is false
+// when a map key or a slice index doesn't exist. An error is returned when a key can't index the value
+// it is applied to, e.g. an int key on a map.
+func IndexMap(m pcommon.Map, keys []Key) (val pcommon.Value, ok bool, err error) {
+	if len(keys) == 0 {
+		return pcommon.Value{}, false, fmt.Errorf("no keys provided to index the map")
+	}
+	if keys[0].String == nil {
+		return pcommon.Value{}, false, &IndexError{Key: keys[0], Reason: "maps can only be indexed by strings"}
+	}
+	val, ok = m.Get(*keys[0].String)
+	if !ok {
+		return pcommon.Value{}, false, nil
+	}
+	return IndexValue(val, keys[1:])
+}
+
+// IndexValue returns the value of the nested maps and slices of v at the given keys,
+// see IndexMap. v itself is returned when there are no keys.
+func IndexValue(v pcommon.Value, keys []Key) (val pcommon.Value, ok bool, err error) {
+	val = v
+	for _, key := range keys {
+		switch val.Type() {
+		case pcommon.ValueTypeMap:
+			if key.String == nil {
+				return pcommon.Value{}, false, &IndexError{Key: key, Reason: "maps can only be indexed by strings"}
+			}
+			if val, ok = val.MapVal().Get(*key.String); !ok {
+				return pcommon.Value{}, false, nil
+			}
+		case pcommon.ValueTypeSlice:
+			if key.Int == nil {
+				return pcommon.Value{}, false, &IndexError{Key: key, Reason: "slices can only be indexed by ints"}
+			}
+			slice := val.SliceVal()
+			if *key.Int < 0 || *key.Int >= int64(slice.Len()) {
+				return pcommon.Value{}, false, nil
+			}
+			val = slice.At(int(*key.Int))
+		default:
+			return pcommon.Value{}, false, &IndexError{Key: key, Reason: fmt.Sprintf("a %v value can't be indexed", val.Type())}
+		}
+	}
+	return val, true, nil
+}
+
+// UpsertMapValue returns the value of m, or of its nested maps and slices, at the given keys so that it
+// can be set. Missing map entries are inserted as empty values. An error is returned when a slice index
+// is out of range or a key can't index the value it is applied to.
+func UpsertMapValue(m pcommon.Map, keys []Key) (pcommon.Value, error) {
+	if len(keys) == 0 {
+		return pcommon.Value{}, fmt.Errorf("no keys provided to index the map")
+	}
+	if keys[0].String == nil {
+		return pcommon.Value{}, &IndexError{Key: keys[0], Reason: "maps can only be indexed by strings"}
+	}
+	val, ok := m.Get(*keys[0].String)
+	if !ok {
+		m.Insert(*keys[0].String, newValueFor(keys[1:]))
+		val, _ = m.Get(*keys[0].String)
+	}
+	return UpsertValue(val, keys[1:])
+}
+
+// UpsertValue returns the value of the nested maps and slices of v at the given keys so that
+// it can be set, see UpsertMapValue. v itself is returned when there are no keys.
+func UpsertValue(v pcommon.Value, keys []Key) (pcommon.Value, error) {
+	val := v
+	for i, key := range keys {
+		switch val.Type() {
+		case pcommon.ValueTypeMap:
+			if key.String == nil {
+				return pcommon.Value{}, &IndexError{Key: key, Reason: "maps can only be indexed by strings"}
+			}
+			m := val.MapVal()
+			next, ok := m.Get(*key.String)
+			if !ok {
+				m.Insert(*key.String, newValueFor(keys[i+1:]))
+				next, _ = m.Get(*key.String)
+			}
+			val = next
+		case pcommon.ValueTypeSlice:
+			if key.Int == nil {
+				return pcommon.Value{}, &IndexError{Key: key, Reason: "slices can only be indexed by ints"}
+			}
+			slice := val.SliceVal()
+			if *key.Int < 0 || *key.Int >= int64(slice.Len()) {
+				return pcommon.Value{}, &IndexError{Key: key, Reason: fmt.Sprintf("index out of range for a slice of length %d", slice.Len())}
+			}
+			val = slice.At(int(*key.Int))
+		default:
+			return pcommon.Value{}, &IndexError{Key: key, Reason: fmt.Sprintf("a %v value can't be indexed", val.Type())}
+		}
+	}
+	return val, nil
+}
+
+// newValueFor returns the value to insert for a missing map entry: a map if it is indexed
+// by the remaining keys, and an empty value to be set otherwise.
+func newValueFor(remainingKeys []Key) pcommon.Value {
+	if len(remainingKeys) > 0 {
+		return pcommon.NewValueMap()
+	}
+	return pcommon.NewValueEmpty()
+}
@@ -0,0 +1,190 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func strKey(s string) Key {
+	return Key{String: tqltest.Strp(s)}
+}
+
+func intKey(i int64) Key {
+	return Key{Int: tqltest.Intp(i)}
+}
+
+func nestedMap() pcommon.Map {
+	m := pcommon.NewMap()
+	m.InsertString("str", "val")
+	inner := pcommon.NewValueMap()
+	inner.MapVal().InsertInt("int", 1)
+	m.Insert("map", inner)
+	slice := pcommon.NewValueSlice()
+	slice.SliceVal().AppendEmpty().SetStringVal("first")
+	slice.SliceVal().AppendEmpty().SetIntVal(2)
+	m.Insert("slice", slice)
+	return m
+}
+
+func Test_IndexMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []Key
+		expected interface{}
+		ok       bool
+	}{
+		{
+			name:     "top level key",
+			keys:     []Key{strKey("str")},
+			expected: "val",
+			ok:       true,
+		},
+		{
+			name:     "nested map",
+			keys:     []Key{strKey("map"), strKey("int")},
+			expected: int64(1),
+			ok:       true,
+		},
+		{
+			name:     "slice index",
+			keys:     []Key{strKey("slice"), intKey(1)},
+			expected: int64(2),
+			ok:       true,
+		},
+		{
+			name: "missing key",
+			keys: []Key{strKey("map"), strKey("missing")},
+		},
+		{
+			name: "index out of range",
+			keys: []Key{strKey("slice"), intKey(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, ok, err := IndexMap(nestedMap(), tt.keys)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				switch expected := tt.expected.(type) {
+				case string:
+					assert.Equal(t, expected, val.StringVal())
+				case int64:
+					assert.Equal(t, expected, val.IntVal())
+				}
+			}
+		})
+	}
+}
+
+func Test_IndexMap_error(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{
+			name: "no keys",
+		},
+		{
+			name: "int key on map",
+			keys: []Key{intKey(0)},
+		},
+		{
+			name: "string key on slice",
+			keys: []Key{strKey("slice"), strKey("foo")},
+		},
+		{
+			name: "key on string",
+			keys: []Key{strKey("str"), strKey("foo")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := IndexMap(nestedMap(), tt.keys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_UpsertMapValue(t *testing.T) {
+	m := nestedMap()
+
+	val, err := UpsertMapValue(m, []Key{strKey("map"), strKey("int")})
+	require.NoError(t, err)
+	val.SetIntVal(5)
+
+	val, err = UpsertMapValue(m, []Key{strKey("new"), strKey("nested"), strKey("key")})
+	require.NoError(t, err)
+	val.SetStringVal("inserted")
+
+	val, err = UpsertMapValue(m, []Key{strKey("slice"), intKey(0)})
+	require.NoError(t, err)
+	val.SetBoolVal(true)
+
+	expected := nestedMap()
+	expected.Update("map", pcommon.NewValueMap())
+	inner, _ := expected.Get("map")
+	inner.MapVal().InsertInt("int", 5)
+	newVal := pcommon.NewValueMap()
+	nested := pcommon.NewValueMap()
+	nested.MapVal().InsertString("key", "inserted")
+	newVal.MapVal().Insert("nested", nested)
+	expected.Insert("new", newVal)
+	slice, _ := expected.Get("slice")
+	slice.SliceVal().At(0).SetBoolVal(true)
+
+	assert.Equal(t, expected.Sort().AsRaw(), m.Sort().AsRaw())
+}
+
+func Test_UpsertMapValue_error(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{
+			name: "no keys",
+		},
+		{
+			name: "int key on map",
+			keys: []Key{intKey(0)},
+		},
+		{
+			name: "index out of range",
+			keys: []Key{strKey("slice"), intKey(5)},
+		},
+		{
+			name: "key on int",
+			keys: []Key{strKey("map"), strKey("int"), strKey("foo")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UpsertMapValue(nestedMap(), tt.keys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_IndexError(t *testing.T) {
+	err := &IndexError{Key: intKey(3), Reason: "maps can only be indexed by strings"}
+	assert.Equal(t, "invalid key [3]: maps can only be indexed by strings", err.Error())
+}
@@ -97,6 +97,24 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"math_operators", `1 + 2.5 * (3 - 4) / x`, false, []result{
+			{"Int", "1"},
+			{"OpAddSub", "+"},
+			{"Float", "2.5"},
+			{"OpMultDiv", "*"},
+			{"LParen", "("},
+			{"Int", "3"},
+			{"OpAddSub", "-"},
+			{"Int", "4"},
+			{"RParen", ")"},
+			{"OpMultDiv", "/"},
+			{"Lowercase", "x"},
+		}},
+		{"unspaced_operator", `1-2`, false, []result{
+			{"Int", "1"},
+			{"OpAddSub", "-"},
+			{"Int", "2"}, // the sign of a number is parsed by the grammar, not the lexer
+		}},
 		{"Mixing case", `aBCd`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
@@ -0,0 +1,150 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDivisionByZero is returned when an int64 value is divided by zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// MathTypeError is returned when the operands of a math expression are not int64 or float64 values,
+// or two strings being added.
+type MathTypeError struct {
+	Op    MathOp
+	Left  interface{}
+	Right interface{}
+}
+
+func (e *MathTypeError) Error() string {
+	return fmt.Sprintf("unsupported operand types for %v: %T and %T", e.Op, e.Left, e.Right)
+}
+
+// mathOperation applies an operator to the values returned by two getters.
+type mathOperation struct {
+	op    MathOp
+	left  Getter
+	right Getter
+}
+
+func (m mathOperation) Get(ctx TransformContext) (interface{}, error) {
+	left, right, err := getOperands(ctx, m.left, m.right)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateMath(m.op, left, right)
+}
+
+func newMathExpressionGetter(expr *MathExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	getter, err := newAddSubTermGetter(expr.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range expr.Right {
+		right, err := newAddSubTermGetter(rhs.Term, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		getter = mathOperation{op: rhs.Operator, left: getter, right: right}
+	}
+	return getter, nil
+}
+
+func newAddSubTermGetter(term *AddSubTerm, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	getter, err := newMathValueGetter(term.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range term.Right {
+		right, err := newMathValueGetter(rhs.Value, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		getter = mathOperation{op: rhs.Operator, left: getter, right: right}
+	}
+	return getter, nil
+}
+
+func newMathValueGetter(value *MathValue, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	if value.SubExpression != nil {
+		return newMathExpressionGetter(value.SubExpression, functions, pathParser, enumParser)
+	}
+	literal := value.Literal
+	return NewGetter(Value{
+		Invocation: literal.Invocation,
+		String:     literal.String,
+		Float:      literal.Float,
+		Int:        literal.Int,
+		Path:       literal.Path,
+	}, functions, pathParser, enumParser)
+}
+
+// evaluateMath applies the operator to int64 and float64 values. The result is an int64 when both values
+// are int64, and a float64 otherwise. Two strings can be concatenated with +.
+func evaluateMath(op MathOp, left interface{}, right interface{}) (interface{}, error) {
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok && op == ADD {
+			return l + r, nil
+		}
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return evaluateIntMath(op, l, r)
+		case float64:
+			return evaluateFloatMath(op, float64(l), r), nil
+		}
+	case float64:
+		switch r := right.(type) {
+		case int64:
+			return evaluateFloatMath(op, l, float64(r)), nil
+		case float64:
+			return evaluateFloatMath(op, l, r), nil
+		}
+	}
+	return nil, &MathTypeError{Op: op, Left: left, Right: right}
+}
+
+func evaluateIntMath(op MathOp, left int64, right int64) (interface{}, error) {
+	switch op {
+	case ADD:
+		return left + right, nil
+	case SUB:
+		return left - right, nil
+	case MULT:
+		return left * right, nil
+	case DIV:
+		if right == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return left / right, nil
+	}
+	return nil, fmt.Errorf("unsupported operator %v", op)
+}
+
+func evaluateFloatMath(op MathOp, left float64, right float64) interface{} {
+	switch op {
+	case ADD:
+		return left + right
+	case SUB:
+		return left - right
+	case MULT:
+		return left * right
+	default:
+		return left / right
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func three() (ExprFunc, error) {
+	return func(ctx TransformContext) (interface{}, error) {
+		return int64(3), nil
+	}, nil
+}
+
+func mathParsePath(val *Path) (GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 && val.Fields[0].Name == "one_point_five" {
+		return &testGetSetter{
+			getter: func(ctx TransformContext) (interface{}, error) {
+				return 1.5, nil
+			},
+		}, nil
+	}
+	return testParsePath(val)
+}
+
+func Test_evaluateMathExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		mathExpr string
+		item     interface{}
+		expected interface{}
+	}{
+		{
+			name:     "int addition",
+			mathExpr: "1 + 2",
+			expected: int64(3),
+		},
+		{
+			name:     "float and int",
+			mathExpr: "1.5 + 2",
+			expected: 3.5,
+		},
+		{
+			name:     "precedence",
+			mathExpr: "1 + 2 * 3 - 4 / 2",
+			expected: int64(5),
+		},
+		{
+			name:     "left associativity",
+			mathExpr: "10 - 4 - 3",
+			expected: int64(3),
+		},
+		{
+			name:     "parentheses",
+			mathExpr: "(1 + 2) * 3",
+			expected: int64(9),
+		},
+		{
+			name:     "int division",
+			mathExpr: "7 / 2",
+			expected: int64(3),
+		},
+		{
+			name:     "float division",
+			mathExpr: "7 / 2.0",
+			expected: 3.5,
+		},
+		{
+			name:     "negative literal",
+			mathExpr: "-2 * 3",
+			expected: int64(-6),
+		},
+		{
+			name:     "invocations",
+			mathExpr: "three() * three()",
+			expected: int64(9),
+		},
+		{
+			name:     "string concatenation",
+			mathExpr: `"foo" + name + "baz"`,
+			item:     "bar",
+			expected: "foobarbaz",
+		},
+		{
+			name:     "paths",
+			mathExpr: "name * one_point_five",
+			item:     int64(4),
+			expected: 6.0,
+		},
+	}
+
+	functions := map[string]interface{}{"three": three}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(name, " + tt.mathExpr + ")")
+			require.NoError(t, err)
+			require.NotNil(t, parsed.Invocation.Arguments[1].MathExpression)
+
+			getter, err := NewGetter(parsed.Invocation.Arguments[1], functions, mathParsePath, testParseEnum)
+			require.NoError(t, err)
+
+			result, err := getter.Get(tqltest.TestTransformContext{
+				Item: tt.item,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_evaluateMathExpression_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		mathExpr string
+		item     interface{}
+		err      error
+	}{
+		{
+			name:     "division by zero",
+			mathExpr: "1 / (2 - 2)",
+			err:      ErrDivisionByZero,
+		},
+		{
+			name:     "string operand",
+			mathExpr: "name + 1",
+			item:     "foo",
+			err:      &MathTypeError{Op: ADD, Left: "foo", Right: int64(1)},
+		},
+		{
+			name:     "string subtraction",
+			mathExpr: `"foo" - "o"`,
+			err:      &MathTypeError{Op: SUB, Left: "foo", Right: "o"},
+		},
+		{
+			name:     "nil operand",
+			mathExpr: "2 * name",
+			item:     nil,
+			err:      &MathTypeError{Op: MULT, Left: int64(2), Right: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(name, " + tt.mathExpr + ")")
+			require.NoError(t, err)
+
+			getter, err := NewGetter(parsed.Invocation.Arguments[1], map[string]interface{}{}, mathParsePath, testParseEnum)
+			require.NoError(t, err)
+
+			_, err = getter.Get(tqltest.TestTransformContext{
+				Item: tt.item,
+			})
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_MathTypeError(t *testing.T) {
+	err := &MathTypeError{Op: SUB, Left: "foo", Right: 1.5}
+	assert.Equal(t, "unsupported operand types for -: string and float64", err.Error())
+}
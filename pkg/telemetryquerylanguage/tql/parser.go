@@ -16,6 +16,8 @@ package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"encoding/hex"
+	"fmt"
+	"math"
 
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
@@ -84,18 +86,20 @@ type Invocation struct {
 }
 
 // Value represents a part of a parsed query which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal or math expression.
+// The operands of math expressions are only parsed as a MathExpression when they are followed by an operator.
 // nolint:govet
 type Value struct {
-	Invocation *Invocation `( @@`
-	Bytes      *Bytes      `| @Bytes`
-	String     *string     `| @String`
-	Float      *float64    `| @Float`
-	Int        *int64      `| @Int`
-	Bool       *Boolean    `| @Boolean`
-	IsNil      *IsNil      `| @"nil"`
-	Enum       *EnumSymbol `| @Uppercase`
-	Path       *Path       `| @@ )`
+	Invocation     *Invocation     `( @@ (?! OpAddSub | OpMultDiv)`
+	Bytes          *Bytes          `| @Bytes`
+	String         *string         `| @String (?! OpAddSub | OpMultDiv)`
+	Float          *float64        `| @(OpAddSub? Float) (?! OpAddSub | OpMultDiv)`
+	Int            *int64          `| @(OpAddSub? Int) (?! OpAddSub | OpMultDiv)`
+	Bool           *Boolean        `| @Boolean`
+	IsNil          *IsNil          `| @"nil"`
+	Enum           *EnumSymbol     `| @Uppercase (?! "(" | Lowercase)`
+	Path           *Path           `| @@ (?! OpAddSub | OpMultDiv | "(")`
+	MathExpression *MathExpression `| @@ )`
 }
 
 // Path represents a telemetry path expression.
@@ -107,8 +111,63 @@ type Path struct {
 // Field is an item within a Path.
 // nolint:govet
 type Field struct {
-	Name   string  `@Lowercase`
-	MapKey *string `( "[" @String "]" )?`
+	Name string `@Lowercase`
+	Keys []Key  `( "[" @@ "]" )*`
+}
+
+// Key represents an index into a map, using a string, or into a slice, using an int.
+// Keys can be chained to index nested maps and slices, e.g. attributes["a"][0].
+// nolint:govet
+type Key struct {
+	String *string `( @String`
+	Int    *int64  `| @(OpAddSub? Int) )`
+}
+
+// MathExpression represents an arbitrary number of terms separated by + or -.
+// nolint:govet
+type MathExpression struct {
+	Left  *AddSubTerm     `@@`
+	Right []*OpAddSubTerm `@@*`
+}
+
+// OpAddSubTerm represents the right side of an addition or a subtraction.
+// nolint:govet
+type OpAddSubTerm struct {
+	Operator MathOp      `@OpAddSub`
+	Term     *AddSubTerm `@@`
+}
+
+// AddSubTerm represents an arbitrary number of values separated by * or /,
+// which take precedence over + and -.
+// nolint:govet
+type AddSubTerm struct {
+	Left  *MathValue        `@@`
+	Right []*OpMultDivValue `@@*`
+}
+
+// OpMultDivValue represents the right side of a multiplication or a division.
+// nolint:govet
+type OpMultDivValue struct {
+	Operator MathOp     `@OpMultDiv`
+	Value    *MathValue `@@`
+}
+
+// MathValue represents an operand of a math expression, either a literal or a parenthesized subexpression.
+// nolint:govet
+type MathValue struct {
+	Literal       *MathExprLiteral `( @@`
+	SubExpression *MathExpression  `| "(" @@ ")" )`
+}
+
+// MathExprLiteral represents the literals which can be used in math expressions.
+// The sign of numbers is a separate OpAddSub token, so that it can't be confused with an operator.
+// nolint:govet
+type MathExprLiteral struct {
+	Invocation *Invocation `( @@`
+	String     *string     `| @String`
+	Float      *float64    `| @(OpAddSub? Float)`
+	Int        *int64      `| @(OpAddSub? Int)`
+	Path       *Path       `| @@ )`
 }
 
 // Query holds a top level Query for processing telemetry data. A Query is a combination of a function
@@ -116,6 +175,8 @@ type Field struct {
 type Query struct {
 	Function  ExprFunc
 	Condition BoolExpressionEvaluator
+	// FunctionName is the name of the invoked function, for processors which call some functions differently.
+	FunctionName string
 }
 
 // Bytes type for capturing byte arrays
@@ -149,6 +210,41 @@ func (n *IsNil) Capture(_ []string) error {
 
 type EnumSymbol string
 
+// MathOp is an operator of a math expression.
+type MathOp int
+
+const (
+	ADD MathOp = iota
+	SUB
+	MULT
+	DIV
+)
+
+var mathOpTable = map[string]MathOp{
+	"+": ADD,
+	"-": SUB,
+	"*": MULT,
+	"/": DIV,
+}
+
+func (m *MathOp) Capture(values []string) error {
+	op, ok := mathOpTable[values[0]]
+	if !ok {
+		return fmt.Errorf("'%s' is not a valid operator", values[0])
+	}
+	*m = op
+	return nil
+}
+
+func (m MathOp) String() string {
+	for str, op := range mathOpTable {
+		if op == m {
+			return str
+		}
+	}
+	return "<unknown>"
+}
+
 func ParseQueries(statements []string, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]Query, error) {
 	queries := make([]Query, 0)
 	var errors error
@@ -170,8 +266,9 @@ func ParseQueries(statements []string, functions map[string]interface{}, pathPar
 			continue
 		}
 		queries = append(queries, Query{
-			Function:  function,
-			Condition: expression,
+			Function:     function,
+			Condition:    expression,
+			FunctionName: parsed.Invocation.Function,
 		})
 	}
 
@@ -181,8 +278,36 @@ func ParseQueries(statements []string, functions map[string]interface{}, pathPar
 	return queries, nil
 }
 
+// ParseConditions parses a list of boolean expressions, written the same way as the where clause of a query, into
+// BoolExpressionEvaluators that can be used to match telemetry without invoking a function.
+func ParseConditions(conditions []string, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]BoolExpressionEvaluator, error) {
+	evaluators := make([]BoolExpressionEvaluator, 0, len(conditions))
+	var errors error
+
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluator, err := newBooleanExpressionEvaluator(parsed, functions, pathParser, enumParser)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluators = append(evaluators, evaluator)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return evaluators, nil
+}
+
 var parser = newParser()
 
+var conditionParser = newConditionParser()
+
 func parseQuery(raw string) (*ParsedQuery, error) {
 	parsed := &ParsedQuery{}
 	err := parser.ParseString("", raw, parsed)
@@ -192,14 +317,25 @@ func parseQuery(raw string) (*ParsedQuery, error) {
 	return parsed, nil
 }
 
+func parseCondition(raw string) (*BooleanExpression, error) {
+	parsed := &BooleanExpression{}
+	err := conditionParser.ParseString("", raw, parsed)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
 // buildLexer constructs a SimpleLexer definition.
 // Note that the ordering of these rules matters.
 // It's in a separate function so it can be easily tested alone (see lexer_test.go).
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
@@ -217,11 +353,24 @@ func buildLexer() *lexer.StatefulDefinition {
 // newParser returns a parser that can be used to read a string into a ParsedQuery. An error will be returned if the string
 // is not formatted for the DSL.
 func newParser() *participle.Parser {
+	return buildParser(&ParsedQuery{})
+}
+
+// newConditionParser returns a parser that can be used to read a string into a BooleanExpression. An error will be
+// returned if the string is not formatted for the DSL.
+func newConditionParser() *participle.Parser {
+	return buildParser(&BooleanExpression{})
+}
+
+func buildParser(grammar interface{}) *participle.Parser {
 	lex := buildLexer()
-	parser, err := participle.Build(&ParsedQuery{},
+	parser, err := participle.Build(grammar,
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		// Values are only known to be the operand of a math expression once the operator is reached,
+		// which can be arbitrarily far when the operand is a path or an invocation.
+		participle.UseLookahead(math.MaxInt32),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
@@ -128,8 +128,8 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("bar")}},
 									},
 									{
 										Name: "cat",
@@ -159,8 +159,8 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("bar")}},
 									},
 									{
 										Name: "cat",
@@ -210,8 +210,8 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("bar")}},
 									},
 									{
 										Name: "cat",
@@ -261,8 +261,8 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("bar")}},
 									},
 									{
 										Name: "cat",
@@ -360,8 +360,8 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bytes"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("bytes")}},
 									},
 								},
 							},
@@ -385,8 +385,8 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("test")}},
 									},
 								},
 							},
@@ -410,8 +410,8 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("test")}},
 									},
 								},
 							},
@@ -424,6 +424,345 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "Invocation with nested keys",
+			query: `set(attributes["foo"]["bar"][0], body[1])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{String: tqltest.Strp("foo")},
+											{String: tqltest.Strp("bar")},
+											{Int: tqltest.Intp(0)},
+										},
+									},
+								},
+							},
+						},
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "body",
+										Keys: []Key{{Int: tqltest.Intp(1)}},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with math expression",
+			query: `set(attributes["test"], 1 + attributes["a"] * (3.5 - 2))`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("test")}},
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Int: tqltest.Intp(1),
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: ADD,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Path: &Path{
+														Fields: []Field{
+															{
+																Name: "attributes",
+																Keys: []Key{{String: tqltest.Strp("a")}},
+															},
+														},
+													},
+												},
+											},
+											Right: []*OpMultDivValue{
+												{
+													Operator: MULT,
+													Value: &MathValue{
+														SubExpression: &MathExpression{
+															Left: &AddSubTerm{
+																Left: &MathValue{
+																	Literal: &MathExprLiteral{
+																		Float: tqltest.Floatp(3.5),
+																	},
+																},
+															},
+															Right: []*OpAddSubTerm{
+																{
+																	Operator: SUB,
+																	Term: &AddSubTerm{
+																		Left: &MathValue{
+																			Literal: &MathExprLiteral{
+																				Int: tqltest.Intp(2),
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with math expression on invocations",
+			query: `set(attributes["test"], hello() / 2)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("test")}},
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Invocation: &Invocation{
+												Function: "hello",
+											},
+										},
+									},
+									Right: []*OpMultDivValue{
+										{
+											Operator: DIV,
+											Value: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(2),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with math expression on mixed case invocations",
+			query: `set(attributes["test"], Int("5") + 1)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("test")}},
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Invocation: &Invocation{
+												Function: "Int",
+												Arguments: []Value{
+													{
+														String: tqltest.Strp("5"),
+													},
+												},
+											},
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: ADD,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(1),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with unspaced subtraction",
+			query: `set(attributes["a"], attributes["b"]-1)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{{String: tqltest.Strp("a")}},
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "attributes",
+														Keys: []Key{{String: tqltest.Strp("b")}},
+													},
+												},
+											},
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: SUB,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(1),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with unspaced addition",
+			query: `fff(1+2)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "fff",
+					Arguments: []Value{
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Int: tqltest.Intp(1),
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: ADD,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(2),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with negative numbers",
+			query: `fff(-12, -.5, 2 * -3)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "fff",
+					Arguments: []Value{
+						{
+							Int: tqltest.Intp(-12),
+						},
+						{
+							Float: tqltest.Floatp(-0.5),
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Int: tqltest.Intp(2),
+										},
+									},
+									Right: []*OpMultDivValue{
+										{
+											Operator: MULT,
+											Value: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(-3),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -457,6 +796,11 @@ func Test_parse_failure(t *testing.T) {
 		`set("foo") where )`,
 		`set("foo") where (name == "fido"))`,
 		`set("foo") where ((name == "fido")`,
+		`set(name, 1 +)`,
+		`set(name, * 2)`,
+		`set(name, (1 + 2)`,
+		`set(attributes[], "foo")`,
+		`set(attributes[1.5], "foo")`,
 	}
 	for _, tt := range tests {
 		t.Run(tt, func(t *testing.T) {
@@ -469,11 +813,12 @@ func Test_parse_failure(t *testing.T) {
 func testParsePath(val *Path) (GetSetter, error) {
 	if val != nil && len(val.Fields) > 0 && val.Fields[0].Name == "name" {
 		return &testGetSetter{
-			getter: func(ctx TransformContext) interface{} {
-				return ctx.GetItem()
+			getter: func(ctx TransformContext) (interface{}, error) {
+				return ctx.GetItem(), nil
 			},
-			setter: func(ctx TransformContext, val interface{}) {
+			setter: func(ctx TransformContext, val interface{}) error {
 				ctx.GetItem()
+				return nil
 			},
 		}, nil
 	}
@@ -783,6 +1128,79 @@ func Test_parseWhere(t *testing.T) {
 			parsed, err := parseQuery(query)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expected, parsed)
+
+			condition, err := parseCondition(tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.WhereClause, condition)
+		})
+	}
+}
+
+func Test_ParseConditions(t *testing.T) {
+	tests := []struct {
+		condition string
+		item      interface{}
+		expected  bool
+	}{
+		{
+			condition: `name == "foo"`,
+			item:      "foo",
+			expected:  true,
+		},
+		{
+			condition: `name == "foo"`,
+			item:      "bar",
+			expected:  false,
+		},
+		{
+			condition: `name == "foo" or name == "bar"`,
+			item:      "bar",
+			expected:  true,
+		},
+		{
+			condition: `(name != "foo" and name != "bar") or false`,
+			item:      "bar",
+			expected:  false,
+		},
+		{
+			condition: `2-1 == 1+0`,
+			item:      "bar",
+			expected:  true,
+		},
+		{
+			condition: `-2 == 1 -3`,
+			item:      "bar",
+			expected:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			conditions, err := ParseConditions([]string{tt.condition}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.NoError(t, err)
+			assert.Len(t, conditions, 1)
+
+			result, err := conditions[0](tqltest.TestTransformContext{
+				Item: tt.item,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_ParseConditions_failure(t *testing.T) {
+	tests := []string{
+		`set(name, "foo")`,
+		`name == "foo" where true`,
+		`name = "foo"`,
+		`name ==`,
+		`unknown == "foo"`,
+		`UnknownFunction() == "foo"`,
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseConditions([]string{`name == "foo"`, tt}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.Error(t, err)
 		})
 	}
 }
@@ -252,6 +252,8 @@ func validateMetrics(metric pmetric.Metric) bool {
 		return metric.Sum().DataPoints().Len() != 0 && metric.Sum().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
 	case pmetric.MetricDataTypeHistogram:
 		return metric.Histogram().DataPoints().Len() != 0 && metric.Histogram().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
+	case pmetric.MetricDataTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len() != 0 && metric.ExponentialHistogram().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
 	case pmetric.MetricDataTypeSummary:
 		return metric.Summary().DataPoints().Len() != 0
 	}
@@ -354,10 +356,16 @@ func addSingleHistogramDataPoint(pt pmetric.HistogramDataPoint, resource pcommon
 }
 
 func getPromExemplars(pt pmetric.HistogramDataPoint) []prompb.Exemplar {
+	return convertExemplars(pt.Exemplars())
+}
+
+// convertExemplars converts OTLP exemplars to Prometheus exemplars, dropping the filtered
+// attributes of an exemplar when they would exceed the max number of runes.
+func convertExemplars(exemplars pmetric.ExemplarSlice) []prompb.Exemplar {
 	var promExemplars []prompb.Exemplar
 
-	for i := 0; i < pt.Exemplars().Len(); i++ {
-		exemplar := pt.Exemplars().At(i)
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
 		exemplarRunes := 0
 
 		promExemplar := &prompb.Exemplar{
@@ -428,6 +436,11 @@ func mostRecentTimestampInMetric(metric pmetric.Metric) pcommon.Timestamp {
 		for x := 0; x < dataPoints.Len(); x++ {
 			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
 		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		for x := 0; x < dataPoints.Len(); x++ {
+			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
+		}
 	case pmetric.MetricDataTypeSummary:
 		dataPoints := metric.Summary().DataPoints()
 		for x := 0; x < dataPoints.Len(); x++ {
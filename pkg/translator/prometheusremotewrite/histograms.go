@@ -0,0 +1,153 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	// minNativeHistogramSchema and maxNativeHistogramSchema are the bounds of the schemas,
+	// i.e. scales, supported by Prometheus native histograms.
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
+	// defaultZeroThreshold is the width of the zero bucket of the native histograms. The zero bucket
+	// of an exponential histogram only counts the values exactly equal to zero.
+	defaultZeroThreshold = 1e-128
+)
+
+// addSingleExponentialHistogramDataPoint converts pt to a Prometheus native histogram, and adds the histogram
+// to its corresponding time series in tsMap.
+func addSingleExponentialHistogramDataPoint(pt pmetric.ExponentialHistogramDataPoint, resource pcommon.Resource, metric pmetric.Metric, settings Settings, tsMap map[string]*prompb.TimeSeries) error {
+	name := prometheustranslator.BuildPromCompliantName(metric, settings.Namespace)
+	histogram, err := exponentialToNativeHistogram(pt)
+	if err != nil {
+		return fmt.Errorf("%s is dropped: %w", name, err)
+	}
+
+	labels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nameStr, name)
+	sig := timeSeriesSignature(metric.DataType().String(), &labels)
+	ts, ok := tsMap[sig]
+	if !ok {
+		ts = &prompb.TimeSeries{Labels: labels}
+		tsMap[sig] = ts
+	}
+	ts.Histograms = append(ts.Histograms, histogram)
+	ts.Exemplars = append(ts.Exemplars, convertExemplars(pt.Exemplars())...)
+	return nil
+}
+
+// exponentialToNativeHistogram converts an exponential histogram data point to a Prometheus native histogram
+// with integer counts. The scale of the data point is used as the schema of the native histogram. Data points
+// with a scale greater than the maximum schema are downscaled to it by merging their buckets.
+func exponentialToNativeHistogram(pt pmetric.ExponentialHistogramDataPoint) (prompb.Histogram, error) {
+	scale := pt.Scale()
+	if scale < minNativeHistogramSchema {
+		return prompb.Histogram{}, fmt.Errorf("cannot convert an exponential histogram with scale %d to a native histogram, the scale must be at least %d",
+			scale, minNativeHistogramSchema)
+	}
+	var scaleDown int32
+	if scale > maxNativeHistogramSchema {
+		scaleDown = scale - maxNativeHistogramSchema
+		scale = maxNativeHistogramSchema
+	}
+
+	positiveSpans, positiveDeltas := convertBucketsLayout(pt.Positive(), scaleDown)
+	negativeSpans, negativeDeltas := convertBucketsLayout(pt.Negative(), scaleDown)
+	histogram := prompb.Histogram{
+		Schema:         scale,
+		ZeroThreshold:  defaultZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: pt.ZeroCount()},
+		PositiveSpans:  positiveSpans,
+		PositiveDeltas: positiveDeltas,
+		NegativeSpans:  negativeSpans,
+		NegativeDeltas: negativeDeltas,
+		ResetHint:      prompb.Histogram_UNKNOWN,
+		Timestamp:      convertTimeStamp(pt.Timestamp()),
+	}
+	if pt.Flags().HasFlag(pmetric.MetricDataPointFlagNoRecordedValue) {
+		histogram.Sum = math.Float64frombits(value.StaleNaN)
+		histogram.Count = &prompb.Histogram_CountInt{CountInt: value.StaleNaN}
+	} else {
+		histogram.Sum = pt.Sum()
+		histogram.Count = &prompb.Histogram_CountInt{CountInt: pt.Count()}
+	}
+	return histogram, nil
+}
+
+// convertBucketsLayout converts the dense buckets of an exponential histogram to the sparse buckets of a
+// native histogram: spans of consecutive buckets, and the count of every bucket as a delta to the count of
+// the previous bucket. As in the Prometheus client, gaps of up to two empty buckets are kept in a span rather
+// than starting a new one.
+// The index of a bucket is shifted by one, as the bucket at index 0 is (1, base] in an exponential histogram
+// and (base^-1, 1] in a native histogram.
+// The buckets are downscaled by scaleDown first: the 2^scaleDown buckets starting at index i<<scaleDown are
+// merged into the bucket at index i.
+func convertBucketsLayout(buckets pmetric.Buckets, scaleDown int32) ([]*prompb.BucketSpan, []int64) {
+	bucketCounts := buckets.BucketCounts()
+	var (
+		spans         []*prompb.BucketSpan
+		deltas        []int64
+		prevCount     int64
+		nextBucketIdx int32
+	)
+	appendDelta := func(count int64) {
+		spans[len(spans)-1].Length++
+		deltas = append(deltas, count-prevCount)
+		prevCount = count
+	}
+	appendBucket := func(bucketIdx int32, count int64) {
+		gap := bucketIdx - nextBucketIdx
+		if len(spans) == 0 || gap > 2 {
+			spans = append(spans, &prompb.BucketSpan{Offset: gap})
+		} else {
+			for j := int32(0); j < gap; j++ {
+				appendDelta(0)
+			}
+		}
+		appendDelta(count)
+		nextBucketIdx = bucketIdx + 1
+	}
+
+	var (
+		mergedIdx   int32
+		mergedCount int64
+	)
+	for i := 0; i < bucketCounts.Len(); i++ {
+		count := int64(bucketCounts.At(i))
+		if count == 0 {
+			continue
+		}
+		bucketIdx := (buckets.Offset()+int32(i))>>scaleDown + 1
+		if mergedCount > 0 && bucketIdx != mergedIdx {
+			appendBucket(mergedIdx, mergedCount)
+			mergedCount = 0
+		}
+		mergedIdx = bucketIdx
+		mergedCount += count
+	}
+	if mergedCount > 0 {
+		appendBucket(mergedIdx, mergedCount)
+	}
+	return spans, deltas
+}
@@ -0,0 +1,187 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestConvertBucketsLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     int32
+		scaleDown  int32
+		counts     []uint64
+		wantSpans  []*prompb.BucketSpan
+		wantDeltas []int64
+	}{
+		{
+			name: "no buckets",
+		},
+		{
+			name:       "consecutive buckets",
+			counts:     []uint64{4, 3, 2, 1},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 4}},
+			wantDeltas: []int64{4, -1, -1, -1},
+		},
+		{
+			name:       "negative offset",
+			offset:     -3,
+			counts:     []uint64{1, 1},
+			wantSpans:  []*prompb.BucketSpan{{Offset: -2, Length: 2}},
+			wantDeltas: []int64{1, 0},
+		},
+		{
+			name:       "leading empty buckets",
+			counts:     []uint64{0, 0, 3},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 3, Length: 1}},
+			wantDeltas: []int64{3},
+		},
+		{
+			name:       "small gap kept in the span",
+			offset:     -1,
+			counts:     []uint64{1, 0, 0, 2},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 4}},
+			wantDeltas: []int64{1, -1, 0, 2},
+		},
+		{
+			name:       "large gap starting a new span",
+			counts:     []uint64{1, 0, 0, 0, 2},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 1}, {Offset: 3, Length: 1}},
+			wantDeltas: []int64{1, 1},
+		},
+		{
+			name:       "downscaled buckets",
+			offset:     -3,
+			scaleDown:  1,
+			counts:     []uint64{1, 2, 3, 0, 0, 0, 0, 4},
+			wantSpans:  []*prompb.BucketSpan{{Offset: -1, Length: 5}},
+			wantDeltas: []int64{1, 4, -5, 0, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPoint().Positive()
+			buckets.SetOffset(tt.offset)
+			buckets.SetBucketCounts(pcommon.NewImmutableUInt64Slice(tt.counts))
+
+			spans, deltas := convertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.wantSpans, spans)
+			assert.Equal(t, tt.wantDeltas, deltas)
+		})
+	}
+}
+
+func TestExponentialToNativeHistogram(t *testing.T) {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetTimestamp(pcommon.Timestamp(time1))
+	pt.SetScale(2)
+	pt.SetCount(10)
+	pt.SetSum(42)
+	pt.SetZeroCount(1)
+	pt.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{2, 3}))
+	pt.Negative().SetOffset(1)
+	pt.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{4}))
+
+	histogram, err := exponentialToNativeHistogram(pt)
+	require.NoError(t, err)
+	assert.Equal(t, prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 10},
+		Sum:            42,
+		Schema:         2,
+		ZeroThreshold:  defaultZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+		NegativeSpans:  []*prompb.BucketSpan{{Offset: 2, Length: 1}},
+		NegativeDeltas: []int64{4},
+		PositiveSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+		PositiveDeltas: []int64{2, 1},
+		ResetHint:      prompb.Histogram_UNKNOWN,
+		Timestamp:      convertTimeStamp(pcommon.Timestamp(time1)),
+	}, histogram)
+}
+
+func TestExponentialToNativeHistogramStaleNaN(t *testing.T) {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetFlags(pmetric.NewMetricDataPointFlags(pmetric.MetricDataPointFlagNoRecordedValue))
+	pt.SetCount(10)
+
+	histogram, err := exponentialToNativeHistogram(pt)
+	require.NoError(t, err)
+	assert.True(t, value.IsStaleNaN(histogram.Sum))
+	assert.Equal(t, &prompb.Histogram_CountInt{CountInt: value.StaleNaN}, histogram.Count)
+}
+
+func TestExponentialToNativeHistogramInvalidScale(t *testing.T) {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetScale(-5)
+	_, err := exponentialToNativeHistogram(pt)
+	assert.Error(t, err)
+}
+
+func TestExponentialToNativeHistogramDownscale(t *testing.T) {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetScale(20)
+	pt.SetCount(6)
+	pt.SetSum(6.5)
+	// At scale 20, 4096 buckets of the data point are merged into each bucket of schema 8.
+	pt.Positive().SetOffset(4095)
+	pt.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2, 3}))
+
+	histogram, err := exponentialToNativeHistogram(pt)
+	require.NoError(t, err)
+	assert.Equal(t, int32(maxNativeHistogramSchema), histogram.Schema)
+	assert.Equal(t, []*prompb.BucketSpan{{Offset: 1, Length: 2}}, histogram.PositiveSpans)
+	assert.Equal(t, []int64{1, 4}, histogram.PositiveDeltas)
+	assert.Equal(t, &prompb.Histogram_CountInt{CountInt: 6}, histogram.Count)
+}
+
+func TestFromMetricsExponentialHistogram(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	getExponentialHistogramMetric("latency", lbs1, time1, floatVal1, 6, 0, 0, []uint64{1, 2, 3}).CopyTo(metrics.AppendEmpty())
+	getExponentialHistogramMetric("sdk_latency", lbs1, time1, floatVal1, 6, 20, 0, []uint64{1, 2, 3}).CopyTo(metrics.AppendEmpty())
+	getExponentialHistogramMetric("invalid_scale", lbs1, time1, floatVal1, 6, -5, 0, []uint64{1, 2, 3}).CopyTo(metrics.AppendEmpty())
+
+	tsMap, err := FromMetrics(md, Settings{})
+	assert.EqualError(t, err, "invalid_scale is dropped: cannot convert an exponential histogram with scale -5 to a native histogram, the scale must be at least -4")
+
+	require.Len(t, tsMap, 2)
+	wantDeltas := map[string][]int64{
+		"latency": {1, 1, 1},
+		// Downscaled from scale 20 to schema 8, which merges the three buckets into one.
+		"sdk_latency": {6},
+	}
+	for _, ts := range tsMap {
+		var name string
+		for _, l := range ts.Labels {
+			if l.Name == nameStr {
+				name = l.Value
+			}
+		}
+		assert.ElementsMatch(t, getPromLabels(label11, value11, label12, value12, nameStr, name), ts.Labels)
+		assert.Empty(t, ts.Samples)
+		require.Len(t, ts.Histograms, 1)
+		assert.Equal(t, &prompb.Histogram_CountInt{CountInt: 6}, ts.Histograms[0].Count)
+		assert.Equal(t, floatVal1, ts.Histograms[0].Sum)
+		assert.Equal(t, wantDeltas[name], ts.Histograms[0].PositiveDeltas, name)
+	}
+}
@@ -121,6 +121,8 @@ var (
 	validSummary     = "valid_Summary"
 	suffixedCounter  = "valid_IntSum_total"
 
+	validExponentialHistogram = "valid_ExponentialHistogram"
+
 	// valid metrics as input should not return error
 	validMetrics1 = map[string]pmetric.Metric{
 		validIntGauge:    getIntGaugeMetric(validIntGauge, lbs1, intVal1, time1),
@@ -130,6 +132,8 @@ var (
 		validSum:         getSumMetric(validSum, lbs1, floatVal1, time1),
 		validHistogram:   getHistogramMetric(validHistogram, lbs1, time1, floatVal1, uint64(intVal1), bounds, buckets),
 		validSummary:     getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
+
+		validExponentialHistogram: getExponentialHistogramMetric(validExponentialHistogram, lbs1, time1, floatVal1, 6, 0, 0, []uint64{1, 2, 3}),
 	}
 
 	empty = "empty"
@@ -144,6 +148,8 @@ var (
 	emptyCumulativeSum       = "emptyCumulativeSum"
 	emptyCumulativeHistogram = "emptyCumulativeHistogram"
 
+	deltaExponentialHistogram = "deltaExponentialHistogram"
+
 	// different metrics that will not pass validate metrics and will cause the exporter to return an error
 	invalidMetrics = map[string]pmetric.Metric{
 		empty:                    pmetric.NewMetric(),
@@ -153,6 +159,8 @@ var (
 		emptySummary:             getEmptySummaryMetric(emptySummary),
 		emptyCumulativeSum:       getEmptyCumulativeSumMetric(emptyCumulativeSum),
 		emptyCumulativeHistogram: getEmptyCumulativeHistogramMetric(emptyCumulativeHistogram),
+
+		deltaExponentialHistogram: getDeltaExponentialHistogramMetric(deltaExponentialHistogram),
 	}
 )
 
@@ -382,6 +390,36 @@ func getHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum floa
 	return metric
 }
 
+func getExponentialHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum float64, count uint64, scale int32,
+	offset int32, positiveBuckets []uint64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	metric.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	if strings.HasPrefix(name, "staleNaN") {
+		dp.SetFlags(1)
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetScale(scale)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(positiveBuckets))
+	attributes.CopyTo(dp.Attributes())
+
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	return metric
+}
+
+func getDeltaExponentialHistogramMetric(name string) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	metric.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	return metric
+}
+
 func getEmptySummaryMetric(name string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(name)
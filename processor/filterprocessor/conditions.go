@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func parseMetricConditions(conditions *MetricConditions) ([]tql.BoolExpressionEvaluator, []tql.BoolExpressionEvaluator, error) {
+	if conditions == nil {
+		return nil, nil, nil
+	}
+	metric, err := tql.ParseConditions(conditions.Metric, tqlotel.Converters(), tqlmetrics.ParsePath, tqlmetrics.ParseEnum)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataPoint, err := tql.ParseConditions(conditions.DataPoint, tqlotel.Converters(), tqlmetrics.ParsePath, tqlmetrics.ParseEnum)
+	if err != nil {
+		return nil, nil, err
+	}
+	return metric, dataPoint, nil
+}
+
+func parseSpanConditions(conditions *SpanConditions) ([]tql.BoolExpressionEvaluator, []tql.BoolExpressionEvaluator, error) {
+	if conditions == nil {
+		return nil, nil, nil
+	}
+	span, err := tql.ParseConditions(conditions.Span, tqlotel.Converters(), tqltraces.ParsePath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, nil, err
+	}
+	spanEvent, err := tql.ParseConditions(conditions.SpanEvent, tqlotel.Converters(), tqlspanevents.ParsePath, tqlspanevents.ParseEnum)
+	if err != nil {
+		return nil, nil, err
+	}
+	return span, spanEvent, nil
+}
+
+func parseLogConditions(conditions *LogConditions) ([]tql.BoolExpressionEvaluator, error) {
+	if conditions == nil {
+		return nil, nil
+	}
+	return tql.ParseConditions(conditions.LogRecord, tqlotel.Converters(), tqllogs.ParsePath, tqllogs.ParseEnum)
+}
+
+// matchesAnyCondition returns true as soon as one of the conditions is true for ctx.
+// Conditions that fail to be evaluated are skipped, and the last error is returned.
+func matchesAnyCondition(conditions []tql.BoolExpressionEvaluator, ctx tql.TransformContext) (bool, error) {
+	var lastErr error
+	for _, condition := range conditions {
+		matches, err := condition(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, lastErr
+}
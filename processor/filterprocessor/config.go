@@ -15,7 +15,11 @@
 package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermetric"
@@ -48,6 +52,21 @@ type MetricFilters struct {
 
 	// RegexpConfig specifies options for the Regexp match type
 	RegexpConfig *regexp.Config `mapstructure:"regexp"`
+
+	// Conditions are telemetry query language boolean expressions describing metrics and data points that should be
+	// dropped. Conditions cannot be used together with Include or Exclude.
+	Conditions *MetricConditions `mapstructure:"conditions"`
+}
+
+// MetricConditions lists the conditions evaluated on metrics and on their data points.
+// Telemetry matching any of the conditions is dropped.
+type MetricConditions struct {
+	// Metric conditions are evaluated once per metric. A metric matching any of them is dropped with all its data points.
+	Metric []string `mapstructure:"metric"`
+
+	// DataPoint conditions are evaluated on each data point of the remaining metrics.
+	// Metrics left without data points are dropped.
+	DataPoint []string `mapstructure:"datapoint"`
 }
 
 // SpanFilters filters by Span attributes and various other fields, Regexp config is per matcher
@@ -61,6 +80,24 @@ type SpanFilters struct {
 	// all other spans should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *filterconfig.MatchProperties `mapstructure:"exclude"`
+
+	// Conditions are telemetry query language boolean expressions describing spans and span events that should be
+	// dropped. Conditions cannot be used together with Include or Exclude.
+	Conditions *SpanConditions `mapstructure:"conditions"`
+}
+
+// SpanConditions lists the conditions evaluated on spans and on their events.
+// Telemetry matching any of the conditions is dropped.
+type SpanConditions struct {
+	// Span conditions are evaluated on each span. A span matching any of them is dropped with all its events.
+	Span []string `mapstructure:"span"`
+
+	// SpanEvent conditions are evaluated on each event of the remaining spans.
+	SpanEvent []string `mapstructure:"span_event"`
+
+	// DropSpanOnEventMatch drops the whole span, instead of only the matching events, when any of its events
+	// matches one of the SpanEvent conditions.
+	DropSpanOnEventMatch bool `mapstructure:"drop_span_on_event_match"`
 }
 
 // LogFilters filters by Log properties.
@@ -73,6 +110,17 @@ type LogFilters struct {
 	// all other logs should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *LogMatchProperties `mapstructure:"exclude"`
+
+	// Conditions are telemetry query language boolean expressions describing log records that should be
+	// dropped. Conditions cannot be used together with Include or Exclude.
+	Conditions *LogConditions `mapstructure:"conditions"`
+}
+
+// LogConditions lists the conditions evaluated on log records.
+// Log records matching any of the conditions are dropped.
+type LogConditions struct {
+	// LogRecord conditions are evaluated on each log record.
+	LogRecord []string `mapstructure:"log_record"`
 }
 
 // LogMatchType specifies the strategy for matching against `plog.Log`s.
@@ -104,5 +152,34 @@ var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	var errs error
+
+	if cfg.Metrics.Conditions != nil {
+		if cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil {
+			errs = multierr.Append(errs, errors.New("metrics: conditions cannot be used together with include or exclude"))
+		}
+		if _, _, err := parseMetricConditions(cfg.Metrics.Conditions); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("metrics: %w", err))
+		}
+	}
+
+	if cfg.Spans.Conditions != nil {
+		if cfg.Spans.Include != nil || cfg.Spans.Exclude != nil {
+			errs = multierr.Append(errs, errors.New("spans: conditions cannot be used together with include or exclude"))
+		}
+		if _, _, err := parseSpanConditions(cfg.Spans.Conditions); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("spans: %w", err))
+		}
+	}
+
+	if cfg.Logs.Conditions != nil {
+		if cfg.Logs.Include != nil || cfg.Logs.Exclude != nil {
+			errs = multierr.Append(errs, errors.New("logs: conditions cannot be used together with include or exclude"))
+		}
+		if _, err := parseLogConditions(cfg.Logs.Conditions); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("logs: %w", err))
+		}
+	}
+
+	return errs
 }
@@ -397,3 +397,109 @@ func TestLoadingConfigExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadingConfigConditions(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config_conditions.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	expCfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "conditions")),
+		Spans: SpanFilters{
+			Conditions: &SpanConditions{
+				Span: []string{
+					`attributes["http.route"] == "/health"`,
+					`resource.attributes["host.name"] == "localhost"`,
+				},
+				SpanEvent: []string{
+					`name == "cache miss"`,
+				},
+				DropSpanOnEventMatch: true,
+			},
+		},
+		Metrics: MetricFilters{
+			Conditions: &MetricConditions{
+				Metric: []string{
+					`metric.name == "system.cpu.time"`,
+				},
+				DataPoint: []string{
+					`attributes["state"] == "idle"`,
+				},
+			},
+		},
+		Logs: LogFilters{
+			Conditions: &LogConditions{
+				LogRecord: []string{
+					`IsMatch(body, "^DEBUG") == true`,
+					`severity_number == SEVERITY_NUMBER_TRACE`,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expCfg, cfg.Processors[expCfg.ID()])
+}
+
+func TestValidateConditions(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{
+			name: "spans conditions with include",
+			cfg: &Config{
+				Spans: SpanFilters{
+					Include: &filterconfig.MatchProperties{
+						Config:   filterset.Config{MatchType: filterset.Strict},
+						Services: []string{"test"},
+					},
+					Conditions: &SpanConditions{Span: []string{`name == "test"`}},
+				},
+			},
+		},
+		{
+			name: "metrics conditions with exclude",
+			cfg: &Config{
+				Metrics: MetricFilters{
+					Exclude: &filtermetric.MatchProperties{
+						MatchType:   filtermetric.Strict,
+						MetricNames: []string{"test"},
+					},
+					Conditions: &MetricConditions{Metric: []string{`metric.name == "test"`}},
+				},
+			},
+		},
+		{
+			name: "invalid span event condition",
+			cfg: &Config{
+				Spans: SpanFilters{
+					Conditions: &SpanConditions{SpanEvent: []string{`span_id == SpanID(0x0102030405060708)`}},
+				},
+			},
+		},
+		{
+			name: "invalid data point condition",
+			cfg: &Config{
+				Metrics: MetricFilters{
+					Conditions: &MetricConditions{DataPoint: []string{`attributes["state"] = "idle"`}},
+				},
+			},
+		},
+		{
+			name: "invalid log record condition",
+			cfg: &Config{
+				Logs: LogFilters{
+					Conditions: &LogConditions{LogRecord: []string{`Unknown(body) == true`}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.cfg.Validate())
+		})
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type filterMetricProcessor struct {
@@ -37,9 +39,31 @@ type filterMetricProcessor struct {
 	logger           *zap.Logger
 	checksMetrics    bool
 	checksResouces   bool
+
+	metricConditions    []tql.BoolExpressionEvaluator
+	dataPointConditions []tql.BoolExpressionEvaluator
 }
 
 func newFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricProcessor, error) {
+	if cfg.Metrics.Conditions != nil {
+		metricConditions, dataPointConditions, err := parseMetricConditions(cfg.Metrics.Conditions)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info(
+			"Metric filter configured",
+			zap.Strings("metric conditions", cfg.Metrics.Conditions.Metric),
+			zap.Strings("data point conditions", cfg.Metrics.Conditions.DataPoint),
+		)
+
+		return &filterMetricProcessor{
+			cfg:                 cfg,
+			logger:              logger,
+			metricConditions:    metricConditions,
+			dataPointConditions: dataPointConditions,
+		}, nil
+	}
 
 	inc, includeAttr, err := createMatcher(cfg.Metrics.Include)
 	if err != nil {
@@ -142,7 +166,10 @@ func (fmp *filterMetricProcessor) processMetrics(_ context.Context, pdm pmetric.
 					fmp.logger.Error("shouldKeepMetric failed", zap.Error(err))
 					// don't `return`, keep the metric if there's an error
 				}
-				return !keep
+				if !keep {
+					return true
+				}
+				return fmp.shouldRemoveMetric(m, ilm.Metrics(), ilm.Scope(), rm.Resource())
 			})
 			// Filter out empty ScopeMetrics
 			return ilm.Metrics().Len() == 0
@@ -200,3 +227,58 @@ func (fmp *filterMetricProcessor) shouldKeepMetricsForResource(resource pcommon.
 
 	return true
 }
+
+// shouldRemoveMetric evaluates the metric and data point conditions on metric, dropping the data points that match.
+// True is returned when the metric matches a metric condition or has no data points left.
+func (fmp *filterMetricProcessor) shouldRemoveMetric(metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	if len(fmp.metricConditions) > 0 {
+		matches, err := matchesAnyCondition(fmp.metricConditions, tqlmetrics.NewTransformContext(nil, metric, metrics, il, resource))
+		if err != nil {
+			fmp.logger.Error("failed to evaluate metric conditions", zap.Error(err))
+		}
+		if matches {
+			return true
+		}
+	}
+
+	if len(fmp.dataPointConditions) == 0 {
+		return false
+	}
+
+	shouldRemoveDataPoint := func(dataPoint interface{}) bool {
+		matches, err := matchesAnyCondition(fmp.dataPointConditions, tqlmetrics.NewTransformContext(dataPoint, metric, metrics, il, resource))
+		if err != nil {
+			fmp.logger.Error("failed to evaluate data point conditions", zap.Error(err))
+		}
+		return matches
+	}
+
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		metric.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			return shouldRemoveDataPoint(dp)
+		})
+		return metric.Gauge().DataPoints().Len() == 0
+	case pmetric.MetricDataTypeSum:
+		metric.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			return shouldRemoveDataPoint(dp)
+		})
+		return metric.Sum().DataPoints().Len() == 0
+	case pmetric.MetricDataTypeHistogram:
+		metric.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			return shouldRemoveDataPoint(dp)
+		})
+		return metric.Histogram().DataPoints().Len() == 0
+	case pmetric.MetricDataTypeExponentialHistogram:
+		metric.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			return shouldRemoveDataPoint(dp)
+		})
+		return metric.ExponentialHistogram().DataPoints().Len() == 0
+	case pmetric.MetricDataTypeSummary:
+		metric.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			return shouldRemoveDataPoint(dp)
+		})
+		return metric.Summary().DataPoints().Len() == 0
+	}
+	return false
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type filterLogProcessor struct {
@@ -33,10 +35,18 @@ type filterLogProcessor struct {
 	excludeRecords   filtermatcher.AttributesMatcher
 	includeResources filtermatcher.AttributesMatcher
 	includeRecords   filtermatcher.AttributesMatcher
+	recordConditions []tql.BoolExpressionEvaluator
 	logger           *zap.Logger
 }
 
 func newFilterLogsProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcessor, error) {
+	recordConditions, err := parseLogConditions(cfg.Logs.Conditions)
+	if err != nil {
+		logger.Error(
+			"filterlog: Error parsing log record conditions", zap.Error(err),
+		)
+		return nil, err
+	}
 
 	includeResources, err := createLogsMatcher(cfg.Logs.Include, ResourceLevelMatch)
 	if err != nil {
@@ -76,6 +86,7 @@ func newFilterLogsProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcesso
 		includeRecords:   includeRecords,
 		excludeResources: excludeResources,
 		excludeRecords:   excludeRecords,
+		recordConditions: recordConditions,
 		logger:           logger,
 	}, nil
 }
@@ -142,7 +153,7 @@ func (flp *filterLogProcessor) filterByRecordAttributes(rLogs plog.ResourceLogsS
 			ls := ills.At(j).LogRecords()
 
 			ls.RemoveIf(func(lr plog.LogRecord) bool {
-				return flp.shouldSkipLogsForRecord(lr)
+				return flp.shouldSkipLogsForRecord(lr) || flp.matchesRecordConditions(lr, ills.At(j).Scope(), rLogs.At(i).Resource())
 			})
 		}
 
@@ -179,6 +190,18 @@ func (flp *filterLogProcessor) shouldSkipLogsForRecord(lr plog.LogRecord) bool {
 	return false
 }
 
+// matchesRecordConditions returns true when the log record matches any of the log record conditions.
+func (flp *filterLogProcessor) matchesRecordConditions(lr plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	if len(flp.recordConditions) == 0 {
+		return false
+	}
+	matches, err := matchesAnyCondition(flp.recordConditions, tqllogs.NewTransformContext(lr, il, resource))
+	if err != nil {
+		flp.logger.Error("filterlog: Error evaluating log record conditions", zap.Error(err))
+	}
+	return matches
+}
+
 // shouldSkipLogsForResource determines if a log should be processed.
 // True is returned when a log should be skipped.
 // False is returned when a log should not be skipped.
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 )
@@ -449,3 +450,82 @@ func requireNotPanicsLogs(t *testing.T, logs plog.Logs) {
 		_ = proc.ConsumeLogs(ctx, logs)
 	})
 }
+
+func TestFilterLogProcessorWithConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions *LogConditions
+		want       func(ld plog.Logs)
+	}{
+		{
+			name: "drop log records matching body",
+			conditions: &LogConditions{
+				LogRecord: []string{`IsMatch(body, "^DEBUG") == true`},
+			},
+			want: func(ld plog.Logs) {
+				ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+					return lr.Body().StringVal() == "DEBUG operationA"
+				})
+			},
+		},
+		{
+			name: "drop log records matching any condition",
+			conditions: &LogConditions{
+				LogRecord: []string{
+					`severity_number == SEVERITY_NUMBER_ERROR`,
+					`attributes["http.method"] == "post" and instrumentation_scope.name == "scope"`,
+				},
+			},
+			want: func(ld plog.Logs) {
+				ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+					return lr.Body().StringVal() == "INFO operationB"
+				})
+			},
+		},
+		{
+			name: "drop nothing",
+			conditions: &LogConditions{
+				LogRecord: []string{`resource.attributes["host.name"] == "remotehost"`},
+			},
+			want: func(ld plog.Logs) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flp, err := newFilterLogsProcessor(zap.NewNop(), &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Logs: LogFilters{
+					Conditions: tt.conditions,
+				},
+			})
+			require.NoError(t, err)
+
+			got, err := flp.ProcessLogs(context.Background(), constructLogsForConditions())
+			require.NoError(t, err)
+
+			want := constructLogsForConditions()
+			tt.want(want)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func constructLogsForConditions() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "localhost")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope")
+
+	lrA := sl.LogRecords().AppendEmpty()
+	lrA.Body().SetStringVal("DEBUG operationA")
+	lrA.SetSeverityNumber(plog.SeverityNumberDEBUG)
+	lrA.Attributes().InsertString("http.method", "get")
+
+	lrB := sl.LogRecords().AppendEmpty()
+	lrB.Body().SetStringVal("INFO operationB")
+	lrB.SetSeverityNumber(plog.SeverityNumberINFO)
+	lrB.Attributes().InsertString("http.method", "post")
+	return ld
+}
@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/goldendataset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
@@ -519,3 +520,95 @@ func requireNotPanics(t *testing.T, metrics pmetric.Metrics) {
 		_ = proc.ConsumeMetrics(ctx, metrics)
 	})
 }
+
+func TestFilterMetricProcessorWithConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions *MetricConditions
+		want       func(md pmetric.Metrics)
+	}{
+		{
+			name: "drop metrics",
+			conditions: &MetricConditions{
+				Metric: []string{`metric.name == "operationA"`},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+					return metric.Name() == "operationA"
+				})
+			},
+		},
+		{
+			name: "drop data points",
+			conditions: &MetricConditions{
+				DataPoint: []string{`attributes["state"] == "idle"`},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+					state, _ := dp.Attributes().Get("state")
+					return state.StringVal() == "idle"
+				})
+			},
+		},
+		{
+			name: "drop metrics left without data points",
+			conditions: &MetricConditions{
+				DataPoint: []string{`metric.type == METRIC_DATA_TYPE_HISTOGRAM`},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+					return metric.Name() == "operationB"
+				})
+			},
+		},
+		{
+			name: "drop nothing",
+			conditions: &MetricConditions{
+				Metric:    []string{`resource.attributes["host.name"] == "remotehost"`},
+				DataPoint: []string{`attributes["state"] == "unknown"`},
+			},
+			want: func(md pmetric.Metrics) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmp, err := newFilterMetricProcessor(zap.NewNop(), &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Metrics: MetricFilters{
+					Conditions: tt.conditions,
+				},
+			})
+			require.NoError(t, err)
+
+			got, err := fmp.processMetrics(context.Background(), constructMetricsForConditions())
+			require.NoError(t, err)
+
+			want := constructMetricsForConditions()
+			tt.want(want)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func constructMetricsForConditions() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("host.name", "localhost")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	metricA := metrics.AppendEmpty()
+	metricA.SetName("operationA")
+	metricA.SetDataType(pmetric.MetricDataTypeSum)
+	for _, state := range []string{"idle", "user"} {
+		dp := metricA.Sum().DataPoints().AppendEmpty()
+		dp.SetIntVal(1)
+		dp.Attributes().InsertString("state", state)
+	}
+
+	metricB := metrics.AppendEmpty()
+	metricB.SetName("operationB")
+	metricB.SetDataType(pmetric.MetricDataTypeHistogram)
+	metricB.Histogram().DataPoints().AppendEmpty().SetCount(1)
+	return md
+}
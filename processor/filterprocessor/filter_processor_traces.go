@@ -24,16 +24,42 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type filterSpanProcessor struct {
-	cfg     *Config
-	include filterspan.Matcher
-	exclude filterspan.Matcher
-	logger  *zap.Logger
+	cfg                 *Config
+	include             filterspan.Matcher
+	exclude             filterspan.Matcher
+	spanConditions      []tql.BoolExpressionEvaluator
+	spanEventConditions []tql.BoolExpressionEvaluator
+	logger              *zap.Logger
 }
 
 func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	if cfg.Spans.Conditions != nil {
+		spanConditions, spanEventConditions, err := parseSpanConditions(cfg.Spans.Conditions)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info(
+			"Span filter configured",
+			zap.String("ID", cfg.ID().String()),
+			zap.Strings("span conditions", cfg.Spans.Conditions.Span),
+			zap.Strings("span event conditions", cfg.Spans.Conditions.SpanEvent),
+		)
+
+		return &filterSpanProcessor{
+			cfg:                 cfg,
+			spanConditions:      spanConditions,
+			spanEventConditions: spanEventConditions,
+			logger:              logger,
+		}, nil
+	}
+
 	if cfg.Spans.Include == nil && cfg.Spans.Exclude == nil {
 		return nil, nil
 	}
@@ -93,7 +119,10 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 		for x := 0; x < resSpan.ScopeSpans().Len(); x++ {
 			ils := resSpan.ScopeSpans().At(x)
 			ils.Spans().RemoveIf(func(span ptrace.Span) bool {
-				return fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope())
+				if fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope()) {
+					return true
+				}
+				return fsp.filterSpanEvents(span, resSpan.Resource(), ils.Scope())
 			})
 		}
 		// Remove empty elements, that way if we delete everything we can tell
@@ -124,5 +153,39 @@ func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcom
 		}
 	}
 
+	if len(fsp.spanConditions) > 0 {
+		matches, err := matchesAnyCondition(fsp.spanConditions, tqltraces.NewTransformContext(span, library, resource))
+		if err != nil {
+			fsp.logger.Error("failed to evaluate span conditions", zap.Error(err))
+		}
+		return matches
+	}
+
+	return false
+}
+
+// filterSpanEvents drops the events of span matching any of the span event conditions. When the conditions are
+// configured to drop the span on event match, the events are left unchanged and it reports if the span must be dropped.
+func (fsp *filterSpanProcessor) filterSpanEvents(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+	if len(fsp.spanEventConditions) == 0 {
+		return false
+	}
+	matchEvent := func(event ptrace.SpanEvent) bool {
+		matches, err := matchesAnyCondition(fsp.spanEventConditions, tqlspanevents.NewTransformContext(event, span, library, resource))
+		if err != nil {
+			fsp.logger.Error("failed to evaluate span event conditions", zap.Error(err))
+		}
+		return matches
+	}
+
+	if fsp.cfg.Spans.Conditions.DropSpanOnEventMatch {
+		for i := 0; i < span.Events().Len(); i++ {
+			if matchEvent(span.Events().At(i)) {
+				return true
+			}
+		}
+		return false
+	}
+	span.Events().RemoveIf(matchEvent)
 	return false
 }
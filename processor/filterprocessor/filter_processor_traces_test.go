@@ -25,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
@@ -181,3 +183,129 @@ func generateTraces(traces []testTrace) ptrace.Traces {
 	}
 	return td
 }
+
+func TestFilterTraceProcessorWithConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions *SpanConditions
+		want       func(td ptrace.Traces)
+	}{
+		{
+			name: "drop spans",
+			conditions: &SpanConditions{
+				Span: []string{`name == "operationA"`},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(span ptrace.Span) bool {
+					return span.Name() == "operationA"
+				})
+			},
+		},
+		{
+			name: "drop spans matching any condition",
+			conditions: &SpanConditions{
+				Span: []string{
+					`attributes["http.method"] == "post"`,
+					`IsMatch(name, "B$") == true`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(span ptrace.Span) bool {
+					return span.Name() == "operationB"
+				})
+			},
+		},
+		{
+			name: "drop span events",
+			conditions: &SpanConditions{
+				SpanEvent: []string{`name == "cache miss" and span.name == "operationA"`},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+					return event.Name() == "cache miss"
+				})
+			},
+		},
+		{
+			name: "drop spans on event match",
+			conditions: &SpanConditions{
+				SpanEvent:            []string{`name == "cache hit"`},
+				DropSpanOnEventMatch: true,
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(span ptrace.Span) bool {
+					return span.Name() == "operationA"
+				})
+			},
+		},
+		{
+			name: "drop nothing on event match",
+			conditions: &SpanConditions{
+				SpanEvent:            []string{`name == "cache error"`},
+				DropSpanOnEventMatch: true,
+			},
+			want: func(td ptrace.Traces) {},
+		},
+		{
+			name: "drop nothing",
+			conditions: &SpanConditions{
+				Span:      []string{`resource.attributes["host.name"] == "remotehost"`},
+				SpanEvent: []string{`attributes["missing"] == "value"`},
+			},
+			want: func(td ptrace.Traces) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsp, err := newFilterSpansProcessor(zap.NewNop(), &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Spans: SpanFilters{
+					Conditions: tt.conditions,
+				},
+			})
+			require.NoError(t, err)
+
+			got, err := fsp.processTraces(context.Background(), constructTracesForConditions())
+			require.NoError(t, err)
+
+			want := constructTracesForConditions()
+			tt.want(want)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestFilterTraceProcessorWithConditionsDropAll(t *testing.T) {
+	fsp, err := newFilterSpansProcessor(zap.NewNop(), &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Spans: SpanFilters{
+			Conditions: &SpanConditions{
+				Span: []string{`resource.attributes["host.name"] == "localhost"`},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = fsp.processTraces(context.Background(), constructTracesForConditions())
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func constructTracesForConditions() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("host.name", "localhost")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	spanA := spans.AppendEmpty()
+	spanA.SetName("operationA")
+	spanA.Attributes().InsertString("http.method", "get")
+	spanA.Events().AppendEmpty().SetName("cache miss")
+	spanA.Events().AppendEmpty().SetName("cache hit")
+
+	spanB := spans.AppendEmpty()
+	spanB.SetName("operationB")
+	spanB.Attributes().InsertString("http.method", "get")
+	spanB.Events().AppendEmpty().SetName("cache miss")
+	return td
+}
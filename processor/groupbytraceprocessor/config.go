@@ -42,9 +42,12 @@ type Config struct {
 	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
+	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to
+	// the storage extension set in StorageID. Traces still waiting to be released are restored on startup.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of the storage extension, such as file_storage, keeping the traces when StoreOnDisk is set.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
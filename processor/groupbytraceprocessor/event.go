@@ -41,6 +41,9 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// traceID found in the storage when starting up
+	traceRestored
 )
 
 var (
@@ -85,6 +88,7 @@ type eventMachine struct {
 	onTraceExpired  func(traceID pcommon.TraceID, worker *eventMachineWorker) error
 	onTraceReleased func(rss []ptrace.ResourceSpans) error
 	onTraceRemoved  func(traceID pcommon.TraceID) error
+	onTraceRestored func(traceID pcommon.TraceID, worker *eventMachineWorker) error
 
 	onError func(event)
 
@@ -214,6 +218,22 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceRestored:
+		if em.onTraceRestored == nil {
+			em.logger.Debug("onTraceRestored not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(pcommon.TraceID)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceRestored", func() error {
+			return em.onTraceRestored(payload, w)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -242,6 +262,19 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 	return nil
 }
 
+// restore routes the ID of a trace already in the storage to the worker responsible for it.
+func (em *eventMachine) restore(traceID pcommon.TraceID) {
+	var bucket uint64
+	if len(em.workers) != 1 {
+		bucket = workerIndexForTraceID(traceID, len(em.workers))
+	}
+
+	em.workers[bucket].fire(event{
+		typ:     traceRestored,
+		payload: traceID,
+	})
+}
+
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
 	hash := hashPool.Get().(*maphash.Hash)
 	defer func() {
@@ -39,7 +39,7 @@ const (
 )
 
 var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
+	errMissingStorageID           = fmt.Errorf("option 'store_on_disk' requires a storage extension to be set in 'storage'")
 	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
 )
 
@@ -62,9 +62,10 @@ func createDefaultConfig() config.Processor {
 		NumWorkers:        defaultNumWorkers,
 		WaitDuration:      defaultWaitDuration,
 
+		StoreOnDisk: defaultStoreOnDisk,
+
 		// not supported for now
 		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
 	}
 }
 
@@ -77,16 +78,19 @@ func createTracesProcessor(
 
 	oCfg := cfg.(*Config)
 
-	var st storage
-	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
-	}
 	if oCfg.DiscardOrphans {
 		return nil, errDiscardOrphansNotSupported
 	}
 
-	// the only supported storage for now
-	st = newMemoryStorage()
+	var st storage
+	if oCfg.StoreOnDisk {
+		if oCfg.StorageID == nil {
+			return nil, errMissingStorageID
+		}
+		st = newDiskStorage(params.Logger, oCfg.ID(), *oCfg.StorageID)
+	} else {
+		st = newMemoryStorage()
+	}
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
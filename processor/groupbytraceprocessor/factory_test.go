@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 )
 
 func TestDefaultConfiguration(t *testing.T) {
@@ -47,6 +48,23 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	storageID := config.NewComponentID("file_storage")
+	c.StoreOnDisk = true
+	c.StorageID = &storageID
+
+	next := &mockProcessor{}
+
+	// test
+	p, err := createTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), c, next)
+
+	// verify
+	assert.NoError(t, err)
+	assert.NotNil(t, p)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
+}
+
 func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 	// prepare
 	f := NewFactory()
@@ -67,7 +85,7 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			&Config{
 				StoreOnDisk: true,
 			},
-			errDiskStorageNotSupported,
+			errMissingStorageID,
 		},
 	} {
 		p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.config, next)
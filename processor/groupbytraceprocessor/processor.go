@@ -76,6 +76,7 @@ func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consu
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRestored = sp.onTraceRestored
 
 	return sp
 }
@@ -93,14 +94,26 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	// resume the traces left in the storage by a previous run
+	traceIDs, err := sp.st.traceIDs()
+	if err != nil {
+		return fmt.Errorf("couldn't retrieve the traces from the storage: %w", err)
+	}
+	for _, traceID := range traceIDs {
+		sp.eventMachine.restore(traceID)
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -126,6 +139,31 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	// at this point, we determined that we haven't seen the trace yet, so, record the
 	// traceID in the map and the spans to the storage
 
+	sp.bufferTraceID(traceID, worker)
+
+	// we have the traceID in the memory, place the spans in the storage too
+	if err := sp.addSpans(traceID, trace.td); err != nil {
+		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+	}
+
+	sp.scheduleRelease(traceID, worker)
+	return nil
+}
+
+func (sp *groupByTraceProcessor) onTraceRestored(traceID pcommon.TraceID, worker *eventMachineWorker) error {
+	if worker.buffer.contains(traceID) {
+		// spans for this trace arrived before the restore, it's already scheduled
+		return nil
+	}
+
+	// the spans are already in the storage, we just need to track the trace again
+	sp.bufferTraceID(traceID, worker)
+	sp.scheduleRelease(traceID, worker)
+	return nil
+}
+
+// bufferTraceID places the trace ID in the worker's buffer, removing the evicted trace from the storage
+func (sp *groupByTraceProcessor) bufferTraceID(traceID pcommon.TraceID, worker *eventMachineWorker) {
 	// place the trace ID in the buffer, and check if an item had to be evicted
 	evicted := worker.buffer.put(traceID)
 	if !evicted.IsEmpty() {
@@ -140,12 +178,9 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
 			zap.String("traceID", evicted.HexString()))
 	}
+}
 
-	// we have the traceID in the memory, place the spans in the storage too
-	if err := sp.addSpans(traceID, trace.td); err != nil {
-		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
-	}
-
+func (sp *groupByTraceProcessor) scheduleRelease(traceID pcommon.TraceID, worker *eventMachineWorker) {
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
 
 	time.AfterFunc(sp.config.WaitDuration, func() {
@@ -155,7 +190,6 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 			payload: traceID,
 		})
 	})
-	return nil
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
@@ -616,6 +616,7 @@ type mockStorage struct {
 	onCreateOrAppend func(pcommon.TraceID, ptrace.Traces) error
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onDelete         func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
+	onTraceIDs       func() ([]pcommon.TraceID, error)
 	onStart          func() error
 	onShutdown       func() error
 }
@@ -640,7 +641,13 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) traceIDs() ([]pcommon.TraceID, error) {
+	if st.onTraceIDs != nil {
+		return st.onTraceIDs()
+	}
+	return nil, nil
+}
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
@@ -15,6 +15,9 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -35,8 +38,12 @@ type storage interface {
 	// or nil in case a trace cannot be found
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
+	// traceIDs returns the IDs of all the traces currently in the storage, allowing the
+	// processor to resume the traces persisted by a previous run
+	traceIDs() ([]pcommon.TraceID, error)
+
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
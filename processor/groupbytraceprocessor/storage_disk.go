@@ -0,0 +1,361 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// diskStorageCountKey is the key holding the number of traces in the storage
+	diskStorageCountKey = "trace_count"
+	// diskStorageIndexPrefix is the prefix of the keys holding the ID of the trace at each slot of
+	// the index, slots being dense so that the index can be restored from the count only
+	diskStorageIndexPrefix = "trace_ids_"
+	// diskStorageTracePrefix is the prefix of the keys holding the number of chunks and the index
+	// slot of each trace, and of the keys holding the spans of each chunk
+	diskStorageTracePrefix = "trace_"
+)
+
+var errInvalidStorageIndex = errors.New("the trace index in the storage is corrupted")
+
+// diskStorage keeps only the trace IDs in memory, serializing the spans of each trace to a
+// storage extension. Each batch of spans is stored as a new chunk of its trace, so that appending
+// to a trace doesn't rewrite the spans already stored. Each trace ID is stored under its own index
+// slot, updated in the same storage batch as the chunks, so that the in-flight traces can be
+// restored once the processor starts again, even after a crash. Deleting a trace moves the last
+// trace ID into the freed slot, so that each change only writes a fixed number of keys.
+type diskStorage struct {
+	sync.Mutex
+	logger      *zap.Logger
+	processorID config.ComponentID
+	storageID   config.ComponentID
+	client      storageext.Client
+
+	// index holds the number of chunks and the slot of each trace in the storage
+	index map[pcommon.TraceID]diskTrace
+	// slots holds the trace IDs by their slot in the storage index
+	slots []pcommon.TraceID
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+// diskTrace is the in-memory index entry of a trace in the storage
+type diskTrace struct {
+	chunks int
+	slot   int
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, processorID config.ComponentID, storageID config.ComponentID) *diskStorage {
+	return &diskStorage{
+		logger:                    logger,
+		processorID:               processorID,
+		storageID:                 storageID,
+		index:                     make(map[pcommon.TraceID]diskTrace),
+		marshaler:                 ptrace.NewProtoMarshaler(),
+		unmarshaler:               ptrace.NewProtoUnmarshaler(),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	buf, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize trace %q: %w", traceID.HexString(), err)
+	}
+
+	entry, found := st.index[traceID]
+	if !found {
+		entry.slot = len(st.slots)
+	}
+	ops := []storageext.Operation{
+		storageext.SetOperation(chunkKey(traceID, entry.chunks), buf),
+		storageext.SetOperation(traceKey(traceID), encodeTrace(entry.chunks+1, entry.slot)),
+	}
+	if !found {
+		id := traceID.Bytes()
+		ops = append(ops,
+			storageext.SetOperation(slotKey(entry.slot), id[:]),
+			storageext.SetOperation(diskStorageCountKey, encodeCount(len(st.slots)+1)),
+		)
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+
+	entry.chunks++
+	st.index[traceID] = entry
+	if !found {
+		st.slots = append(st.slots, traceID)
+	}
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	entry, found := st.index[traceID]
+	if !found {
+		return nil, nil
+	}
+
+	trace, err := st.read(traceID, entry.chunks)
+	if err != nil {
+		return nil, err
+	}
+	return resourceSpansOf(trace), nil
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	entry, found := st.index[traceID]
+	if !found {
+		return nil, nil
+	}
+
+	trace, err := st.read(traceID, entry.chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	last := len(st.slots) - 1
+	ops := make([]storageext.Operation, 0, entry.chunks+5)
+	for i := 0; i < entry.chunks; i++ {
+		ops = append(ops, storageext.DeleteOperation(chunkKey(traceID, i)))
+	}
+	ops = append(ops, storageext.DeleteOperation(traceKey(traceID)))
+	// the last trace takes the freed slot, keeping the slots dense
+	moved := st.slots[last]
+	if entry.slot != last {
+		id := moved.Bytes()
+		ops = append(ops,
+			storageext.SetOperation(slotKey(entry.slot), id[:]),
+			storageext.SetOperation(traceKey(moved), encodeTrace(st.index[moved].chunks, entry.slot)),
+		)
+	}
+	ops = append(ops,
+		storageext.DeleteOperation(slotKey(last)),
+		storageext.SetOperation(diskStorageCountKey, encodeCount(last)),
+	)
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	if entry.slot != last {
+		movedEntry := st.index[moved]
+		movedEntry.slot = entry.slot
+		st.index[moved] = movedEntry
+		st.slots[entry.slot] = moved
+	}
+	st.slots = st.slots[:last]
+	delete(st.index, traceID)
+	return resourceSpansOf(trace), nil
+}
+
+func (st *diskStorage) traceIDs() ([]pcommon.TraceID, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	ids := make([]pcommon.TraceID, len(st.slots))
+	copy(ids, st.slots)
+	return ids, nil
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[st.storageID]
+	if !found {
+		return fmt.Errorf("storage extension %q not found", st.storageID)
+	}
+	se, ok := ext.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", st.storageID)
+	}
+
+	client, err := se.GetClient(ctx, component.KindProcessor, st.processorID, "")
+	if err != nil {
+		return err
+	}
+	st.client = client
+
+	if err := st.loadIndex(ctx); err != nil {
+		return err
+	}
+	st.logger.Info("loaded in-flight traces from the storage", zap.Int("traces", len(st.index)))
+
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *diskStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	if st.client == nil {
+		return nil
+	}
+	return st.client.Close(context.Background())
+}
+
+// read retrieves the chunks of the trace from the storage, the caller is expected to hold the lock
+func (st *diskStorage) read(traceID pcommon.TraceID, chunks int) (ptrace.Traces, error) {
+	ops := make([]storageext.Operation, chunks)
+	for i := range ops {
+		ops[i] = storageext.GetOperation(chunkKey(traceID, i))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return ptrace.Traces{}, err
+	}
+
+	trace := ptrace.NewTraces()
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		chunk, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return ptrace.Traces{}, fmt.Errorf("couldn't deserialize trace %q: %w", traceID.HexString(), err)
+		}
+		chunk.ResourceSpans().MoveAndAppendTo(trace.ResourceSpans())
+	}
+	return trace, nil
+}
+
+func (st *diskStorage) loadIndex(ctx context.Context) error {
+	buf, err := st.client.Get(ctx, diskStorageCountKey)
+	if err != nil {
+		return err
+	}
+	if buf == nil {
+		return nil
+	}
+	if len(buf) != 4 {
+		return errInvalidStorageIndex
+	}
+
+	count := int(binary.BigEndian.Uint32(buf))
+	slotOps := make([]storageext.Operation, count)
+	for i := range slotOps {
+		slotOps[i] = storageext.GetOperation(slotKey(i))
+	}
+	if err := st.client.Batch(ctx, slotOps...); err != nil {
+		return err
+	}
+
+	traceIDs := make([]pcommon.TraceID, count)
+	traceOps := make([]storageext.Operation, count)
+	for i, op := range slotOps {
+		if len(op.Value) != 16 {
+			return errInvalidStorageIndex
+		}
+		var id [16]byte
+		copy(id[:], op.Value)
+		traceIDs[i] = pcommon.NewTraceID(id)
+		traceOps[i] = storageext.GetOperation(traceKey(traceIDs[i]))
+	}
+	if err := st.client.Batch(ctx, traceOps...); err != nil {
+		return err
+	}
+
+	st.Lock()
+	defer st.Unlock()
+	for i, op := range traceOps {
+		if len(op.Value) != 8 || int(binary.BigEndian.Uint32(op.Value[4:])) != i {
+			return errInvalidStorageIndex
+		}
+		st.index[traceIDs[i]] = diskTrace{chunks: int(binary.BigEndian.Uint32(op.Value)), slot: i}
+	}
+	st.slots = traceIDs
+	return nil
+}
+
+func (st *diskStorage) periodicMetrics() {
+	st.Lock()
+	numTraces := len(st.index)
+	st.Unlock()
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(numTraces)))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func traceKey(traceID pcommon.TraceID) string {
+	return diskStorageTracePrefix + traceID.HexString()
+}
+
+func chunkKey(traceID pcommon.TraceID, chunk int) string {
+	return traceKey(traceID) + "_" + strconv.Itoa(chunk)
+}
+
+func slotKey(slot int) string {
+	return diskStorageIndexPrefix + strconv.Itoa(slot)
+}
+
+func encodeCount(count int) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(count))
+	return buf
+}
+
+// encodeTrace serializes the number of chunks of a trace, followed by its index slot
+func encodeTrace(chunks int, slot int) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint32(buf, uint32(chunks))
+	binary.BigEndian.PutUint32(buf[4:], uint32(slot))
+	return buf
+}
+
+func resourceSpansOf(trace ptrace.Traces) []ptrace.ResourceSpans {
+	var result []ptrace.ResourceSpans
+	for i := 0; i < trace.ResourceSpans().Len(); i++ {
+		result = append(result, trace.ResourceSpans().At(i))
+	}
+	return result
+}
@@ -0,0 +1,406 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var storageID = config.NewComponentID("mock_storage")
+
+func TestDiskCreateAppendAndGetTrace(t *testing.T) {
+	// prepare
+	st := newTestDiskStorage(t, newMockStorageClient())
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	for _, rs := range retrieved {
+		assert.Equal(t, traceID, rs.ScopeSpans().At(0).Spans().At(0).TraceID())
+	}
+
+	ids, err := st.traceIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{traceID}, ids)
+}
+
+func TestDiskGetNonExistingTrace(t *testing.T) {
+	// prepare
+	st := newTestDiskStorage(t, newMockStorageClient())
+
+	// test
+	retrieved, err := st.get(pcommon.NewTraceID([16]byte{1, 2, 3, 4}))
+
+	// verify
+	assert.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	st := newTestDiskStorage(t, client)
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	value, err := client.Get(context.Background(), traceKey(traceID))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = client.Get(context.Background(), chunkKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDiskAppendDoesNotRewriteTrace(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	st := newTestDiskStorage(t, client)
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	first, err := client.Get(context.Background(), chunkKey(traceID, 0))
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	value, err := client.Get(context.Background(), chunkKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Equal(t, first, value)
+
+	value, err = client.Get(context.Background(), chunkKey(traceID, 1))
+	require.NoError(t, err)
+	second, err := ptrace.NewProtoUnmarshaler().UnmarshalTraces(value)
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.SpanCount())
+
+	value, err = client.Get(context.Background(), traceKey(traceID))
+	require.NoError(t, err)
+	assert.Equal(t, encodeTrace(2, 0), value)
+}
+
+func TestDiskRestoreTraceIDsAfterRestart(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	st := newTestDiskStorage(t, client)
+	first := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	second := pcommon.NewTraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(first, simpleTracesWithID(first)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+	_, err := st.delete(first)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+	assert.True(t, client.closed)
+
+	// test
+	restarted := newTestDiskStorage(t, client)
+
+	// verify
+	ids, err := restarted.traceIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{second}, ids)
+
+	retrieved, err := restarted.get(second)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestDiskRestoreTraceIDsAfterCrash(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	st := newTestDiskStorage(t, client)
+	first := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	second := pcommon.NewTraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(first, simpleTracesWithID(first)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+	_, err := st.delete(first)
+	require.NoError(t, err)
+
+	// test
+	restarted := newTestDiskStorage(t, client)
+
+	// verify
+	ids, err := restarted.traceIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{second}, ids)
+
+	retrieved, err := restarted.get(second)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+}
+
+func TestDiskDeleteMovesLastTraceToFreedSlot(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	st := newTestDiskStorage(t, client)
+	first := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	second := pcommon.NewTraceID([16]byte{2, 3, 4, 5})
+	third := pcommon.NewTraceID([16]byte{3, 4, 5, 6})
+	for _, traceID := range []pcommon.TraceID{first, second, third} {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// test
+	_, err := st.delete(first)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []string{
+		chunkKey(first, 0),
+		traceKey(first),
+		slotKey(0),
+		traceKey(third),
+		slotKey(2),
+		diskStorageCountKey,
+	}, client.lastBatch)
+
+	value, err := client.Get(context.Background(), slotKey(0))
+	require.NoError(t, err)
+	id := third.Bytes()
+	assert.Equal(t, id[:], value)
+
+	value, err = client.Get(context.Background(), traceKey(third))
+	require.NoError(t, err)
+	assert.Equal(t, encodeTrace(1, 0), value)
+
+	value, err = client.Get(context.Background(), slotKey(2))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = client.Get(context.Background(), diskStorageCountKey)
+	require.NoError(t, err)
+	assert.Equal(t, encodeCount(2), value)
+
+	restarted := newTestDiskStorage(t, client)
+	ids, err := restarted.traceIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{third, second}, ids)
+}
+
+func TestDiskStartWithInvalidIndex(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	require.NoError(t, client.Set(context.Background(), diskStorageCountKey, []byte{1, 2, 3}))
+	st := newDiskStorage(zap.NewNop(), config.NewComponentID(typeStr), storageID)
+
+	// test
+	err := st.start(context.Background(), newMockStorageHost(client))
+
+	// verify
+	assert.ErrorIs(t, err, errInvalidStorageIndex)
+}
+
+func TestDiskStartWithoutChunkCount(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	id := traceID.Bytes()
+	require.NoError(t, client.Set(context.Background(), diskStorageCountKey, encodeCount(1)))
+	require.NoError(t, client.Set(context.Background(), slotKey(0), id[:]))
+	st := newDiskStorage(zap.NewNop(), config.NewComponentID(typeStr), storageID)
+
+	// test
+	err := st.start(context.Background(), newMockStorageHost(client))
+
+	// verify
+	assert.ErrorIs(t, err, errInvalidStorageIndex)
+}
+
+func TestDiskStartWithoutStorageExtension(t *testing.T) {
+	// prepare
+	st := newDiskStorage(zap.NewNop(), config.NewComponentID(typeStr), storageID)
+
+	// test
+	err := st.start(context.Background(), componenttest.NewNopHost())
+
+	// verify
+	assert.EqualError(t, err, `storage extension "mock_storage" not found`)
+}
+
+func TestProcessorReleasesRestoredTraces(t *testing.T) {
+	// prepare
+	client := newMockStorageClient()
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+	previous := newTestDiskStorage(t, client)
+	require.NoError(t, previous.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, previous.shutdown())
+
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		WaitDuration:      time.Millisecond,
+		NumTraces:         10,
+		NumWorkers:        1,
+	}
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	var released ptrace.Traces
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			released = td
+			wg.Done()
+			return nil
+		},
+	}
+
+	st := newDiskStorage(zap.NewNop(), cfg.ID(), storageID)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, next, cfg)
+
+	// test
+	require.NoError(t, p.Start(context.Background(), newMockStorageHost(client)))
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	wg.Wait()
+	assert.Equal(t, 1, released.SpanCount())
+	assert.Equal(t, traceID, released.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+}
+
+func newTestDiskStorage(t *testing.T, client *mockStorageClient) *diskStorage {
+	st := newDiskStorage(zap.NewNop(), config.NewComponentID(typeStr), storageID)
+	require.NoError(t, st.start(context.Background(), newMockStorageHost(client)))
+	return st
+}
+
+type mockStorageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func newMockStorageHost(client *mockStorageClient) component.Host {
+	return &mockStorageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			storageID: &mockStorageExtension{client: client},
+		},
+	}
+}
+
+func (h *mockStorageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type mockStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *mockStorageClient
+}
+
+var _ storageext.Extension = (*mockStorageExtension)(nil)
+
+func (e *mockStorageExtension) GetClient(context.Context, component.Kind, config.ComponentID, string) (storageext.Client, error) {
+	e.client.closed = false
+	return e.client, nil
+}
+
+type mockStorageClient struct {
+	sync.Mutex
+	content map[string][]byte
+	closed  bool
+
+	// lastBatch holds the keys of the operations of the last batch
+	lastBatch []string
+}
+
+var _ storageext.Client = (*mockStorageClient)(nil)
+
+func newMockStorageClient() *mockStorageClient {
+	return &mockStorageClient{content: map[string][]byte{}}
+}
+
+func (c *mockStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.content[key], nil
+}
+
+func (c *mockStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.Lock()
+	defer c.Unlock()
+	c.content[key] = value
+	return nil
+}
+
+func (c *mockStorageClient) Delete(_ context.Context, key string) error {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.content, key)
+	return nil
+}
+
+func (c *mockStorageClient) Batch(ctx context.Context, ops ...storageext.Operation) error {
+	keys := make([]string, len(ops))
+	for i, op := range ops {
+		keys[i] = op.Key
+	}
+	c.Lock()
+	c.lastBatch = keys
+	c.Unlock()
+
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storageext.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storageext.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storageext.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mockStorageClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
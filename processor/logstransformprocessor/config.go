@@ -18,8 +18,10 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 // Config defines configuration for Resource processor.
@@ -30,13 +32,17 @@ type Config struct {
 
 var _ config.Processor = (*Config)(nil)
 
+// Unmarshal decodes the config, including its operators, through mapstructure
+func (cfg *Config) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
+	}
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
+}
+
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	operators, err := cfg.BaseConfig.DecodeOperatorConfigs()
-	if err != nil {
-		return err
-	}
-	if len(operators) == 0 {
+	if len(cfg.BaseConfig.Operators) == 0 {
 		return errors.New("no operators were configured for this logs transform processor")
 	}
 	return nil
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/processor/processorhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 const (
@@ -49,7 +50,7 @@ func createDefaultConfig() config.Processor {
 	return &Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
 		BaseConfig: adapter.BaseConfig{
-			Operators: adapter.OperatorConfigs{},
+			Operators: []operator.Config{},
 			Converter: adapter.ConverterConfig{
 				MaxFlushCount: 100,
 				FlushInterval: 100 * time.Millisecond,
@@ -68,11 +69,7 @@ func createLogsProcessor(
 		return nil, errors.New("could not initialize logs transform processor")
 	}
 
-	operators, err := pCfg.BaseConfig.DecodeOperatorConfigs()
-	if err != nil {
-		return nil, err
-	}
-	if len(operators) == 0 {
+	if len(pCfg.BaseConfig.Operators) == 0 {
 		return nil, errors.New("no operators were configured for this logs transform processor")
 	}
 
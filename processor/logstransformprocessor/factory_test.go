@@ -23,9 +23,14 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -40,17 +45,22 @@ func TestCreateProcessor(t *testing.T) {
 	cfg := &Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
 		BaseConfig: adapter.BaseConfig{
-			Operators: adapter.OperatorConfigs{
-				map[string]interface{}{
-					"type":  "regex_parser",
-					"regex": "^(?P<time>\\d{4}-\\d{2}-\\d{2}) (?P<sev>[A-Z]*) (?P<msg>.*)$",
-					"severity": map[string]interface{}{
-						"parse_from": "body.sev",
-					},
-					"timestamp": map[string]interface{}{
-						"layout":     "%Y-%m-%d",
-						"parse_from": "body.time",
-					},
+			Operators: []operator.Config{
+				{
+					Builder: func() *regex.Config {
+						parserCfg := regex.NewConfig("")
+						parserCfg.Regex = "^(?P<time>\\d{4}-\\d{2}-\\d{2}) (?P<sev>[A-Z]*) (?P<msg>.*)$"
+						sevField := entry.NewBodyField("sev")
+						sevCfg := helper.NewSeverityConfig()
+						sevCfg.ParseFrom = &sevField
+						parserCfg.Config = &sevCfg
+						timeField := entry.NewBodyField("time")
+						parserCfg.TimeParser = &helper.TimeParser{
+							Layout:    "%Y-%m-%d",
+							ParseFrom: &timeField,
+						}
+						return parserCfg
+					}(),
 				},
 			},
 			Converter: adapter.ConverterConfig{
@@ -67,17 +77,16 @@ func TestCreateProcessor(t *testing.T) {
 
 func TestInvalidOperators(t *testing.T) {
 	factory := NewFactory()
-	cfg := &Config{
-		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
-		BaseConfig: adapter.BaseConfig{
-			Operators: adapter.OperatorConfigs{
-				map[string]interface{}{
-					"type": "nonsense",
-				},
+	cfg := factory.CreateDefaultConfig().(*Config)
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]interface{}{
+		"operators": []interface{}{
+			map[string]interface{}{
+				"type": "nonsense",
 			},
 		},
-	}
+	}))
+	assert.Error(t, err)
 
-	_, err := factory.CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, nil)
+	_, err = factory.CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, nil)
 	assert.Error(t, err)
 }
@@ -15,30 +15,68 @@
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	hmacSHA256 = "hmac-sha256"
+	hmacSHA512 = "hmac-sha512"
+)
+
+var (
+	errUnknownHashFunction = errors.New("unknown hash function")
+	errMissingHashKey      = errors.New("hash_key must be set when hash_function is set")
+)
+
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"`
 
-	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
+	// AllowAllKeys is a flag to allow all attribute keys. Setting this
 	// to true disables the AllowedKeys list. The list of BlockedValues is
 	// applied regardless. If you just want to block values, set this to true.
 	AllowAllKeys bool `mapstructure:"allow_all_keys"`
 
-	// AllowedKeys is a list of allowed span attribute keys. Span attributes
-	// not on the list are removed. The list fails closed if it's empty. To
-	// allow all keys, you should explicitly set AllowAllKeys
+	// AllowedKeys is a list of allowed attribute keys of spans, log records
+	// and metric data points. Attributes not on the list are removed. The
+	// list fails closed if it's empty. To allow all keys, you should
+	// explicitly set AllowAllKeys
 	AllowedKeys []string `mapstructure:"allowed_keys"`
 
 	// BlockedValues is a list of regular expressions for blocking values of
-	// allowed span attributes. Values that match are masked
+	// allowed attributes and of log bodies. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// HashFunction replaces the parts of values matching BlockedValues with
+	// their keyed hash instead of masking them, so the same value remains
+	// correlatable across signals without being exposed. Possible values
+	// are `hmac-sha256` and `hmac-sha512`. Values are masked if unset.
+	HashFunction string `mapstructure:"hash_function"`
+
+	// HashKey is the secret key of the HashFunction.
+	HashKey string `mapstructure:"hash_key"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
-	// the processor adds to the spans when it redacts or masks other
+	// the processor adds to the spans, log records and resources when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
 	// information, while it is valuable when integrating and testing a new
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.HashFunction {
+	case "":
+		return nil
+	case hmacSHA256, hmacSHA512:
+	default:
+		return fmt.Errorf("%w: %q", errUnknownHashFunction, cfg.HashFunction)
+	}
+	if cfg.HashKey == "" {
+		return errMissingHashKey
+	}
+	return nil
+}
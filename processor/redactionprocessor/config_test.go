@@ -35,6 +35,12 @@ func TestLoadConfig(t *testing.T) {
 	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
+
+	p0 := cfg.Processors[config.NewComponentID(typeStr)].(*Config)
+	assert.Equal(t, []string{"description", "group", "id", "name"}, p0.AllowedKeys)
+	assert.Equal(t, hmacSHA256, p0.HashFunction)
+	assert.Equal(t, "a-secret-key", p0.HashKey)
+	assert.Equal(t, debug, p0.Summary)
 }
 
 func TestLoadConfigEmpty(t *testing.T) {
@@ -51,3 +57,39 @@ func TestLoadConfigEmpty(t *testing.T) {
 	p0 := cfg.Processors[config.NewComponentID(typeStr)]
 	assert.Equal(t, p0, createDefaultConfig())
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  error
+	}{
+		{
+			name: "mask",
+			cfg:  &Config{},
+		},
+		{
+			name: "hmac-sha256",
+			cfg:  &Config{HashFunction: hmacSHA256, HashKey: "secret"},
+		},
+		{
+			name: "hmac-sha512",
+			cfg:  &Config{HashFunction: hmacSHA512, HashKey: "secret"},
+		},
+		{
+			name: "unknown hash function",
+			cfg:  &Config{HashFunction: "md5", HashKey: "secret"},
+			err:  errUnknownHashFunction,
+		},
+		{
+			name: "missing hash key",
+			cfg:  &Config{HashFunction: hmacSHA256},
+			err:  errMissingHashKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
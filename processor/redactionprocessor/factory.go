@@ -37,6 +37,8 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithTracesProcessorAndStabilityLevel(createTracesProcessor, stability),
+		component.WithLogsProcessorAndStabilityLevel(createLogsProcessor, stability),
+		component.WithMetricsProcessorAndStabilityLevel(createMetricsProcessor, stability),
 	)
 }
 
@@ -69,3 +71,51 @@ func createTracesProcessor(
 		processorhelper.WithStart(redaction.Start),
 		processorhelper.WithShutdown(redaction.Shutdown))
 }
+
+// createLogsProcessor creates an instance of redaction for processing logs
+func createLogsProcessor(
+	ctx context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	next consumer.Logs,
+) (component.LogsProcessor, error) {
+	oCfg := cfg.(*Config)
+
+	redaction, err := newRedaction(ctx, oCfg, params.Logger, nil)
+	if err != nil {
+		// TODO: Placeholder for an error metric in the next PR
+		return nil, fmt.Errorf("error creating a redaction processor: %w", err)
+	}
+
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		next,
+		redaction.processLogs,
+		processorhelper.WithCapabilities(redaction.Capabilities()),
+		processorhelper.WithStart(redaction.Start),
+		processorhelper.WithShutdown(redaction.Shutdown))
+}
+
+// createMetricsProcessor creates an instance of redaction for processing metrics
+func createMetricsProcessor(
+	ctx context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	next consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+
+	redaction, err := newRedaction(ctx, oCfg, params.Logger, nil)
+	if err != nil {
+		// TODO: Placeholder for an error metric in the next PR
+		return nil, fmt.Errorf("error creating a redaction processor: %w", err)
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		next,
+		redaction.processMetrics,
+		processorhelper.WithCapabilities(redaction.Capabilities()),
+		processorhelper.WithStart(redaction.Start),
+		processorhelper.WithShutdown(redaction.Shutdown))
+}
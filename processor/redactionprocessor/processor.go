@@ -16,7 +16,12 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"regexp"
 	"sort"
 	"strings"
@@ -24,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -33,13 +40,15 @@ var _ component.TracesProcessor = (*redaction)(nil)
 type redaction struct {
 	// Attribute keys allowed in a span
 	allowList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Attribute values blocked in a span, in the order of the configuration
+	blockRegexList []*regexp.Regexp
+	// Replaces the parts of a value matching a blocked regex
+	replace func(match string) string
 	// Redaction processor configuration
 	config *Config
 	// Logger
 	logger *zap.Logger
-	// Next trace consumer in line
+	// Next trace consumer in line, only set when consuming traces directly
 	next consumer.Traces
 }
 
@@ -55,6 +64,7 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next
 	return &redaction{
 		allowList:      allowList,
 		blockRegexList: blockRegexList,
+		replace:        makeReplaceFunc(config),
 		config:         config,
 		logger:         logger,
 		next:           next,
@@ -91,12 +101,113 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
+// processResourceLog processes the resource and all of its log records
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	rsAttrs := rl.Resource().Attributes()
+
+	// Attributes can be part of a resource
+	s.processAttrs(ctx, &rsAttrs)
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		ils := rl.ScopeLogs().At(j)
+		for k := 0; k < ils.LogRecords().Len(); k++ {
+			log := ils.LogRecords().At(k)
+			logAttrs := log.Attributes()
+
+			// Attributes and the body can also be part of a log record
+			toDelete, toBlock := s.redactAttrs(&logAttrs)
+			if s.maskBody(log.Body()) {
+				toBlock = append(toBlock, bodyKey)
+			}
+			s.summarizeRedacted(toDelete, &logAttrs)
+			s.summarizeMasked(toBlock, &logAttrs)
+		}
+	}
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.processResourceMetric(ctx, rm)
+	}
+	return metrics, nil
+}
+
+// processResourceMetric processes the resource and the data points of all its metrics
+func (s *redaction) processResourceMetric(ctx context.Context, rm pmetric.ResourceMetrics) {
+	rsAttrs := rm.Resource().Attributes()
+
+	// Attributes can be part of a resource
+	s.processAttrs(ctx, &rsAttrs)
+
+	for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+		ils := rm.ScopeMetrics().At(j)
+		for k := 0; k < ils.Metrics().Len(); k++ {
+			metric := ils.Metrics().At(k)
+
+			// Attributes can also be part of a data point. The summary is not
+			// added to data points since it would change the identity of their series
+			switch metric.DataType() {
+			case pmetric.MetricDataTypeGauge:
+				dps := metric.Gauge().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					dpAttrs := dps.At(i).Attributes()
+					s.redactAttrs(&dpAttrs)
+				}
+			case pmetric.MetricDataTypeSum:
+				dps := metric.Sum().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					dpAttrs := dps.At(i).Attributes()
+					s.redactAttrs(&dpAttrs)
+				}
+			case pmetric.MetricDataTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					dpAttrs := dps.At(i).Attributes()
+					s.redactAttrs(&dpAttrs)
+				}
+			case pmetric.MetricDataTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					dpAttrs := dps.At(i).Attributes()
+					s.redactAttrs(&dpAttrs)
+				}
+			case pmetric.MetricDataTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					dpAttrs := dps.At(i).Attributes()
+					s.redactAttrs(&dpAttrs)
+				}
+			}
+		}
+	}
+}
+
+// processAttrs redacts the attributes of a resource, span or log record and summarizes the changes
 func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 	// TODO: Use the context for recording metrics
-	var toDelete []string
-	var toBlock []string
+	toDelete, toBlock := s.redactAttrs(attributes)
+
+	// Add diagnostic information to the span, log record or resource
+	s.summarizeRedacted(toDelete, attributes)
+	s.summarizeMasked(toBlock, attributes)
+}
 
+// redactAttrs deletes the attributes that aren't allowed and masks the
+// blocked values of the others. It returns the deleted and masked keys.
+func (s *redaction) redactAttrs(attributes *pcommon.Map) (toDelete []string, toBlock []string) {
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
 	// 2. Mask any blocked values for the other attributes
@@ -116,15 +227,9 @@ func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(value.StringVal())
-			if match {
-				toBlock = append(toBlock, k)
-
-				valueCopy := value.StringVal()
-				maskedValue := compiledRE.ReplaceAllString(valueCopy, "****")
-				attributes.Update(k, pcommon.NewValueString(maskedValue))
-			}
+		if maskedValue, masked := s.maskValue(value.StringVal()); masked {
+			toBlock = append(toBlock, k)
+			attributes.Update(k, pcommon.NewValueString(maskedValue))
 		}
 		return true
 	})
@@ -133,9 +238,56 @@ func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 	for _, k := range toDelete {
 		attributes.Remove(k)
 	}
-	// Add diagnostic information to the span
-	s.summarizeRedactedSpan(toDelete, attributes)
-	s.summarizeMaskedSpan(toBlock, attributes)
+	return toDelete, toBlock
+}
+
+// maskBody masks the blocked values of a string log body and reports if any matched
+func (s *redaction) maskBody(body pcommon.Value) bool {
+	if body.Type() != pcommon.ValueTypeString {
+		return false
+	}
+	maskedBody, masked := s.maskValue(body.StringVal())
+	if masked {
+		body.SetStringVal(maskedBody)
+	}
+	return masked
+}
+
+// maskValue replaces the parts of the value matching the blocked regexes and reports if any matched.
+// All the regexes are matched against the original value, so that a replaced part is never matched
+// again by another regex, and the parts matched by overlapping regexes are replaced as a whole.
+func (s *redaction) maskValue(value string) (string, bool) {
+	var matches [][]int
+	for _, compiledRE := range s.blockRegexList {
+		matches = append(matches, compiledRE.FindAllStringIndex(value, -1)...)
+	}
+	if len(matches) == 0 {
+		return value, false
+	}
+	// Sort the matches by their start, and the longest first among those starting at the same position
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i][0] != matches[j][0] {
+			return matches[i][0] < matches[j][0]
+		}
+		return matches[i][1] > matches[j][1]
+	})
+
+	var masked strings.Builder
+	last := 0
+	for i := 0; i < len(matches); {
+		start, end := matches[i][0], matches[i][1]
+		// Merge the following matches which overlap this one
+		for i++; i < len(matches) && matches[i][0] < end; i++ {
+			if matches[i][1] > end {
+				end = matches[i][1]
+			}
+		}
+		masked.WriteString(value[last:start])
+		masked.WriteString(s.replace(value[start:end]))
+		last = end
+	}
+	masked.WriteString(value[last:])
+	return masked.String(), true
 }
 
 // ConsumeTraces implements the SpanProcessor interface
@@ -149,35 +301,35 @@ func (s *redaction) ConsumeTraces(ctx context.Context, batch ptrace.Traces) erro
 	return err
 }
 
-// summarizeRedactedSpan adds diagnostic information about redacted attribute keys
-func (s *redaction) summarizeRedactedSpan(toDelete []string, attributes *pcommon.Map) {
-	redactedSpanCount := int64(len(toDelete))
-	if redactedSpanCount == 0 {
+// summarizeRedacted adds diagnostic information about redacted attribute keys
+func (s *redaction) summarizeRedacted(toDelete []string, attributes *pcommon.Map) {
+	redactedCount := int64(len(toDelete))
+	if redactedCount == 0 {
 		return
 	}
-	// Record summary as span attributes
+	// Record summary as attributes
 	if s.config.Summary == debug {
 		sort.Strings(toDelete)
 		attributes.Insert(redactedKeys, pcommon.NewValueString(strings.Join(toDelete, ",")))
 	}
 	if s.config.Summary == info || s.config.Summary == debug {
-		attributes.Insert(redactedKeyCount, pcommon.NewValueInt(redactedSpanCount))
+		attributes.Insert(redactedKeyCount, pcommon.NewValueInt(redactedCount))
 	}
 }
 
-// summarizeMaskedSpan adds diagnostic information about masked attribute values
-func (s *redaction) summarizeMaskedSpan(toBlock []string, attributes *pcommon.Map) {
-	maskedSpanCount := int64(len(toBlock))
-	if maskedSpanCount == 0 {
+// summarizeMasked adds diagnostic information about masked attribute values
+func (s *redaction) summarizeMasked(toBlock []string, attributes *pcommon.Map) {
+	maskedCount := int64(len(toBlock))
+	if maskedCount == 0 {
 		return
 	}
-	// Records summary as span attributes
+	// Records summary as attributes
 	if s.config.Summary == debug {
 		sort.Strings(toBlock)
 		attributes.Insert(maskedValues, pcommon.NewValueString(strings.Join(toBlock, ",")))
 	}
 	if s.config.Summary == info || s.config.Summary == debug {
-		attributes.Insert(maskedValueCount, pcommon.NewValueInt(maskedSpanCount))
+		attributes.Insert(maskedValueCount, pcommon.NewValueInt(maskedCount))
 	}
 }
 
@@ -188,6 +340,10 @@ const (
 	redactedKeyCount = "redaction.redacted.count"
 	maskedValues     = "redaction.masked.keys"
 	maskedValueCount = "redaction.masked.count"
+	// bodyKey stands for the body of a log record in the list of masked keys
+	bodyKey = "body"
+	// mask replaces blocked values when no hash function is configured
+	mask = "****"
 )
 
 // makeAllowList sets up a lookup table of allowed span attribute keys
@@ -216,20 +372,42 @@ func makeAllowList(c *Config) map[string]string {
 	return allowList
 }
 
-// makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+// makeBlockRegexList precompiles all the blocked regex patterns, keeping the order of the configuration
+func makeBlockRegexList(_ context.Context, config *Config) ([]*regexp.Regexp, error) {
+	blockRegexList := make([]*regexp.Regexp, 0, len(config.BlockedValues))
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockRegexList = append(blockRegexList, re)
 	}
 	return blockRegexList, nil
 }
 
+// makeReplaceFunc returns the function replacing the parts of values
+// matching a blocked regex: either a fixed mask or their keyed hash
+func makeReplaceFunc(config *Config) func(string) string {
+	var newHash func() hash.Hash
+	switch config.HashFunction {
+	case hmacSHA256:
+		newHash = sha256.New
+	case hmacSHA512:
+		newHash = sha512.New
+	default:
+		return func(string) string {
+			return mask
+		}
+	}
+	key := []byte(config.HashKey)
+	return func(match string) string {
+		h := hmac.New(newHash, key)
+		h.Write([]byte(match))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+}
+
 // Capabilities specifies what this processor does, such as whether it mutates data
 func (s *redaction) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
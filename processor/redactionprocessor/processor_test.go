@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
@@ -350,6 +352,199 @@ func TestMultipleBlockValues(t *testing.T) {
 	assert.Equal(t, "mystery ****", mysteryValue.StringVal())
 }
 
+// TestHashBlockedValues validates that the processor replaces blocked values
+// with their keyed hash when a hash function is configured
+func TestHashBlockedValues(t *testing.T) {
+	tests := []struct {
+		hashFunction string
+		expected     string
+	}{
+		{
+			hashFunction: hmacSHA256,
+			expected:     "mystery d6c005134ac50dec0e01cbc4aeaf3fbdb511c4ceeb55f909c29def3b0cffba36",
+		},
+		{
+			hashFunction: hmacSHA512,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hashFunction, func(t *testing.T) {
+			config := &Config{
+				AllowAllKeys:  true,
+				BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+				HashFunction:  tt.hashFunction,
+				HashKey:       "secret",
+			}
+			masked := map[string]pcommon.Value{
+				"credit_card": pcommon.NewValueString("mystery 4111111111111111"),
+				"other_card":  pcommon.NewValueString("4111111111111111"),
+			}
+
+			_, _, next := runTest(t, nil, nil, masked, config)
+
+			attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+			creditCard, _ := attr.Get("credit_card")
+			otherCard, _ := attr.Get("other_card")
+			assert.NotContains(t, creditCard.StringVal(), "4111111111111111")
+			assert.NotContains(t, creditCard.StringVal(), "****")
+			assert.Equal(t, "mystery "+otherCard.StringVal(), creditCard.StringVal(), "the same value must have the same hash")
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, creditCard.StringVal())
+			}
+		})
+	}
+}
+
+// TestRedactLogs validates that the processor redacts the attributes of log
+// records and resources and masks the blocked values of log bodies
+// TestBlockedValuesMatchOriginalValue validates that each blocked value is matched
+// against the original value rather than against the replacements of the others
+func TestBlockedValuesMatchOriginalValue(t *testing.T) {
+	config := &Config{
+		AllowAllKeys: true,
+		// The hash of the card number matches the second pattern
+		BlockedValues: []string{"4[0-9]{15}", "[0-9a-f]{64}"},
+		HashFunction:  hmacSHA256,
+		HashKey:       "secret",
+		Summary:       info,
+	}
+	masked := map[string]pcommon.Value{
+		"credit_card": pcommon.NewValueString("mystery 4111111111111111"),
+	}
+
+	_, _, next := runTest(t, nil, nil, masked, config)
+
+	attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	creditCard, _ := attr.Get("credit_card")
+	assert.Equal(t, "mystery d6c005134ac50dec0e01cbc4aeaf3fbdb511c4ceeb55f909c29def3b0cffba36", creditCard.StringVal())
+	maskedCount, _ := attr.Get(maskedValueCount)
+	assert.Equal(t, int64(1), maskedCount.IntVal())
+}
+
+// TestOverlappingBlockedValues validates that the parts matched by overlapping
+// blocked values are masked as a whole
+func TestOverlappingBlockedValues(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"[0-9]{4} [0-9]{4}", "[0-9]{4}-[0-9]{4}"},
+	}
+	masked := map[string]pcommon.Value{
+		"numbers": pcommon.NewValueString("id 1234 5678-9012 end"),
+	}
+
+	_, _, next := runTest(t, nil, nil, masked, config)
+
+	attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	numbers, _ := attr.Get("numbers")
+	assert.Equal(t, "id **** end", numbers.StringVal())
+}
+
+func TestRedactLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "notes"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       debug,
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), nil)
+	assert.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("id", "resource")
+	rl.Resource().Attributes().InsertString("user.email", "john@example.com")
+	log := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	log.Body().SetStringVal("payment with 4111111111111111 accepted")
+	log.Attributes().InsertString("notes", "card 4111111111111111")
+	log.Attributes().InsertString("birth_date", "1970-01-01")
+	mapBody := rl.ScopeLogs().At(0).LogRecords().AppendEmpty()
+	body := pcommon.NewValueMap()
+	body.MapVal().InsertString("card", "4111111111111111")
+	body.CopyTo(mapBody.Body())
+
+	out, err := processor.processLogs(context.Background(), logs)
+	assert.NoError(t, err)
+
+	rl = out.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"id":             "resource",
+		redactedKeys:     "user.email",
+		redactedKeyCount: int64(1),
+	}, rl.Resource().Attributes().AsRaw())
+
+	log = rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "payment with **** accepted", log.Body().StringVal())
+	assert.Equal(t, map[string]interface{}{
+		"notes":          "card ****",
+		redactedKeys:     "birth_date",
+		redactedKeyCount: int64(1),
+		maskedValues:     "body,notes",
+		maskedValueCount: int64(2),
+	}, log.Attributes().AsRaw())
+
+	mapBody = rl.ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, map[string]interface{}{"card": "4111111111111111"}, mapBody.Body().MapVal().AsRaw(), "only string bodies are masked")
+}
+
+// TestRedactMetrics validates that the processor redacts the attributes of
+// data points of every metric type and of resources, without adding the
+// summary to the data points
+func TestRedactMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "notes"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       info,
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), nil)
+	assert.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("id", "resource")
+	rm.Resource().Attributes().InsertString("user.email", "john@example.com")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	var dpAttrs []pcommon.Map
+	for _, dataType := range []pmetric.MetricDataType{
+		pmetric.MetricDataTypeGauge,
+		pmetric.MetricDataTypeSum,
+		pmetric.MetricDataTypeHistogram,
+		pmetric.MetricDataTypeExponentialHistogram,
+		pmetric.MetricDataTypeSummary,
+	} {
+		m := ms.AppendEmpty()
+		m.SetName(dataType.String())
+		m.SetDataType(dataType)
+		var attrs pcommon.Map
+		switch dataType {
+		case pmetric.MetricDataTypeGauge:
+			attrs = m.Gauge().DataPoints().AppendEmpty().Attributes()
+		case pmetric.MetricDataTypeSum:
+			attrs = m.Sum().DataPoints().AppendEmpty().Attributes()
+		case pmetric.MetricDataTypeHistogram:
+			attrs = m.Histogram().DataPoints().AppendEmpty().Attributes()
+		case pmetric.MetricDataTypeExponentialHistogram:
+			attrs = m.ExponentialHistogram().DataPoints().AppendEmpty().Attributes()
+		case pmetric.MetricDataTypeSummary:
+			attrs = m.Summary().DataPoints().AppendEmpty().Attributes()
+		}
+		attrs.InsertString("notes", "card 4111111111111111")
+		attrs.InsertString("birth_date", "1970-01-01")
+		dpAttrs = append(dpAttrs, attrs)
+	}
+
+	_, err = processor.processMetrics(context.Background(), metrics)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":             "resource",
+		redactedKeyCount: int64(1),
+	}, rm.Resource().Attributes().AsRaw())
+	for _, attrs := range dpAttrs {
+		assert.Equal(t, map[string]interface{}{
+			"notes": "card ****",
+		}, attrs.AsRaw())
+	}
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
@@ -27,6 +27,7 @@ import (
 var (
 	errRequiresTargets  = errors.New("requires schema targets")
 	errDuplicateTargets = errors.New("duplicate targets detected")
+	errEmptySchemaFile  = errors.New("schema file path must not be empty")
 )
 
 // Config defines the user provided values for the Schema Processor
@@ -44,6 +45,11 @@ type Config struct {
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
 	Targets []string `mapstructure:"targets"`
+
+	// SchemaFiles is a list of paths to local schema files.
+	// A schema file is used instead of fetching any schema URL of its
+	// family with a version it defines. (Optional field)
+	SchemaFiles []string `mapstructure:"schema_files"`
 }
 
 func (c *Config) Validate() error {
@@ -53,6 +59,11 @@ func (c *Config) Validate() error {
 			return err
 		}
 	}
+	for _, file := range c.SchemaFiles {
+		if file == "" {
+			return errEmptySchemaFile
+		}
+	}
 	// Not strictly needed since it would just pass on
 	// any data that doesn't match targets, however defining
 	// this processor with no targets is wasteful.
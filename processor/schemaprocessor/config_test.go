@@ -51,6 +51,9 @@ func TestLoadConfig(t *testing.T) {
 			"https://opentelemetry.io/schemas/1.4.2",
 			"https://example.com/otel/schemas/1.2.0",
 		},
+		SchemaFiles: []string{
+			"testdata/schema.yml",
+		},
 	})
 }
 
@@ -60,6 +63,7 @@ func TestConfigurationValidation(t *testing.T) {
 	tests := []struct {
 		scenario    string
 		target      []string
+		schemaFiles []string
 		expectError error
 	}{
 		{scenario: "No targets", target: nil, expectError: errRequiresTargets},
@@ -88,11 +92,20 @@ func TestConfigurationValidation(t *testing.T) {
 			},
 			expectError: errDuplicateTargets,
 		},
+		{
+			scenario: "Empty schema file path",
+			target: []string{
+				"https://opentelemetry.io/schemas/1.9.0",
+			},
+			schemaFiles: []string{""},
+			expectError: errEmptySchemaFile,
+		},
 	}
 
 	for _, tc := range tests {
 		cfg := &Config{
-			Targets: tc.target,
+			Targets:     tc.target,
+			SchemaFiles: tc.schemaFiles,
 		}
 
 		assert.ErrorIs(t, cfg.Validate(), tc.expectError, tc.scenario)
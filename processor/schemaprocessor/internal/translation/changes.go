@@ -0,0 +1,218 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	ast10 "go.opentelemetry.io/otel/schema/v1.0/ast"
+	types10 "go.opentelemetry.io/otel/schema/v1.0/types"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+)
+
+// renames maps old names to new names, the inverse map
+// is kept so that changes can be reverted without recomputing it.
+type renames struct {
+	forward map[string]string
+	reverse map[string]string
+}
+
+func newRenames(m map[string]string) renames {
+	r := renames{
+		forward: make(map[string]string, len(m)),
+		reverse: make(map[string]string, len(m)),
+	}
+	for from, to := range m {
+		r.forward[from] = to
+		r.reverse[to] = from
+	}
+	return r
+}
+
+func (r renames) lookup(revert bool) map[string]string {
+	if revert {
+		return r.reverse
+	}
+	return r.forward
+}
+
+// matcher restricts a change to a set of names,
+// an empty matcher matches every name.
+type matcher map[string]struct{}
+
+func spanMatcher(names []types10.SpanName) matcher {
+	m := make(matcher, len(names))
+	for _, n := range names {
+		m[string(n)] = struct{}{}
+	}
+	return m
+}
+
+func eventMatcher(names []types10.EventName) matcher {
+	m := make(matcher, len(names))
+	for _, n := range names {
+		m[string(n)] = struct{}{}
+	}
+	return m
+}
+
+func metricMatcher(names []types10.MetricName) matcher {
+	m := make(matcher, len(names))
+	for _, n := range names {
+		m[string(n)] = struct{}{}
+	}
+	return m
+}
+
+func (m matcher) matches(name string) bool {
+	if len(m) == 0 {
+		return true
+	}
+	_, ok := m[name]
+	return ok
+}
+
+type spanAttributesChange struct {
+	spans      matcher
+	attributes renames
+}
+
+type spanEventsChange struct {
+	// eventNames is set when the change renames events,
+	// otherwise the attributes of the matched events are renamed.
+	eventNames *renames
+	spans      matcher
+	events     matcher
+	attributes renames
+}
+
+type metricsChange struct {
+	// metricNames is set when the change renames metrics,
+	// otherwise the data point attributes of the matched metrics are renamed.
+	metricNames *renames
+	metrics     matcher
+	attributes  renames
+}
+
+// changeSet holds every change introduced by a single version.
+type changeSet struct {
+	all        []renames
+	resources  []renames
+	spans      []spanAttributesChange
+	spanEvents []spanEventsChange
+	metrics    []metricsChange
+	logs       []renames
+}
+
+func newChangeSet(def ast.VersionDef) *changeSet {
+	cs := &changeSet{
+		all:       attributeChanges(def.All),
+		resources: attributeChanges(def.Resources),
+	}
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes == nil {
+			continue
+		}
+		cs.spans = append(cs.spans, spanAttributesChange{
+			spans:      spanMatcher(c.RenameAttributes.ApplyToSpans),
+			attributes: newRenames(c.RenameAttributes.AttributeMap),
+		})
+	}
+	for _, c := range def.SpanEvents.Changes {
+		if c.RenameEvents != nil {
+			names := newRenames(c.RenameEvents.EventNameMap)
+			cs.spanEvents = append(cs.spanEvents, spanEventsChange{eventNames: &names})
+		}
+		if c.RenameAttributes != nil {
+			cs.spanEvents = append(cs.spanEvents, spanEventsChange{
+				spans:      spanMatcher(c.RenameAttributes.ApplyToSpans),
+				events:     eventMatcher(c.RenameAttributes.ApplyToEvents),
+				attributes: newRenames(c.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, c := range def.Metrics.Changes {
+		if len(c.RenameMetrics) > 0 {
+			m := make(map[string]string, len(c.RenameMetrics))
+			for from, to := range c.RenameMetrics {
+				m[string(from)] = string(to)
+			}
+			names := newRenames(m)
+			cs.metrics = append(cs.metrics, metricsChange{metricNames: &names})
+		}
+		if c.RenameAttributes != nil {
+			cs.metrics = append(cs.metrics, metricsChange{
+				metrics:    metricMatcher(c.RenameAttributes.ApplyToMetrics),
+				attributes: newRenames(c.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, c := range def.Logs.Changes {
+		if c.RenameAttributes != nil {
+			cs.logs = append(cs.logs, newRenames(c.RenameAttributes.AttributeMap))
+		}
+	}
+	return cs
+}
+
+func attributeChanges(attrs ast10.Attributes) []renames {
+	var changes []renames
+	for _, c := range attrs.Changes {
+		if c.RenameAttributes != nil {
+			changes = append(changes, newRenames(*c.RenameAttributes))
+		}
+	}
+	return changes
+}
+
+// renameAttributes renames every key of attrs found in names. All matching keys
+// are removed before the new ones are set so that swapping two names is safe.
+func renameAttributes(attrs pcommon.Map, names map[string]string) {
+	if len(names) == 0 || attrs.Len() == 0 {
+		return
+	}
+	renamed := pcommon.NewMap()
+	attrs.RemoveIf(func(k string, v pcommon.Value) bool {
+		to, ok := names[k]
+		if !ok {
+			return false
+		}
+		renamed.Upsert(to, v)
+		return true
+	})
+	renamed.Range(func(k string, v pcommon.Value) bool {
+		attrs.Upsert(k, v)
+		return true
+	})
+}
+
+// applyRenames applies a list of attribute changes, in reverse order when reverting.
+func applyRenames(attrs pcommon.Map, changes []renames, revert bool) {
+	forEach(len(changes), revert, func(i int) {
+		renameAttributes(attrs, changes[i].lookup(revert))
+	})
+}
+
+// forEach calls fn for every index up to n, starting from the end when reverse is set.
+func forEach(n int, reverse bool, fn func(i int)) {
+	if reverse {
+		for i := n - 1; i >= 0; i-- {
+			fn(i)
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		fn(i)
+	}
+}
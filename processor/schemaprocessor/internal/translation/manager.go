@@ -0,0 +1,117 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// retryInterval is how long a schema url that failed to be retrieved
+// is not requested again, so that an unreachable server isn't hit for every batch.
+const retryInterval = time.Minute
+
+var ErrRecentlyFailed = errors.New("schema url recently failed to be retrieved")
+
+// Manager caches the translations of every schema family it has seen.
+// A schema file contains the changes of all versions up to the one in its
+// url, so only the translation with the highest version is kept per family.
+type Manager struct {
+	log      *zap.Logger
+	provider Provider
+
+	mu           sync.RWMutex
+	translations map[string]*Translation
+	failures     map[string]time.Time
+	now          func() time.Time
+}
+
+// NewManager creates a Manager that retrieves missing schema files with provider.
+func NewManager(provider Provider, log *zap.Logger) *Manager {
+	return &Manager{
+		log:          log,
+		provider:     provider,
+		translations: make(map[string]*Translation),
+		failures:     make(map[string]time.Time),
+		now:          time.Now,
+	}
+}
+
+// AddTranslation caches a translation unless a more recent one of the same family already is.
+func (m *Manager) AddTranslation(t *Translation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur, ok := m.translations[t.Family()]; ok && !t.Latest().GreaterThan(cur.Latest()) {
+		return
+	}
+	m.translations[t.Family()] = t
+}
+
+// RequestTranslation returns a translation that defines the version of the
+// schema url, retrieving the schema file if no cached translation does.
+func (m *Manager) RequestTranslation(ctx context.Context, schemaURL string) (*Translation, error) {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	t, ok := m.translations[family]
+	failedAt, failed := m.failures[schemaURL]
+	m.mu.RUnlock()
+
+	if ok && t.SupportsVersion(version) {
+		return t, nil
+	}
+	if failed && m.now().Sub(failedAt) < retryInterval {
+		return nil, fmt.Errorf("%s: %w", schemaURL, ErrRecentlyFailed)
+	}
+
+	m.log.Debug("Fetching schema url", zap.String("schema-url", schemaURL))
+	t, err = m.fetch(ctx, schemaURL, family)
+	if err != nil {
+		m.mu.Lock()
+		m.failures[schemaURL] = m.now()
+		m.mu.Unlock()
+		return nil, err
+	}
+
+	m.mu.Lock()
+	delete(m.failures, schemaURL)
+	m.mu.Unlock()
+	m.AddTranslation(t)
+	return t, nil
+}
+
+func (m *Manager) fetch(ctx context.Context, schemaURL, family string) (*Translation, error) {
+	content, err := m.provider.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewTranslation(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file at %q: %w", schemaURL, err)
+	}
+	if t.Family() != family {
+		return nil, fmt.Errorf("schema file at %q defines family %q: %w", schemaURL, t.Family(), ErrInvalidFamily)
+	}
+	return t, nil
+}
@@ -0,0 +1,90 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeProvider struct {
+	content []byte
+	err     error
+	calls   int
+}
+
+func (p *fakeProvider) Retrieve(_ context.Context, _ string) ([]byte, error) {
+	p.calls++
+	return p.content, p.err
+}
+
+func TestManagerCachesTranslations(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must be able to read test schema file")
+
+	provider := &fakeProvider{content: content}
+	m := NewManager(provider, zaptest.NewLogger(t))
+
+	tr, err := m.RequestTranslation(context.Background(), "https://opentelemetry.io/schemas/1.1.0")
+	require.NoError(t, err, "Must not error when retrieving schema")
+	assert.Equal(t, "https://opentelemetry.io/schemas", tr.Family())
+
+	_, err = m.RequestTranslation(context.Background(), "https://opentelemetry.io/schemas/1.0.0")
+	require.NoError(t, err, "Must not error when requesting a cached version")
+	assert.Equal(t, 1, provider.calls, "Must use the cached translation for versions it defines")
+}
+
+func TestManagerFamilyMismatch(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must be able to read test schema file")
+
+	m := NewManager(&fakeProvider{content: content}, zaptest.NewLogger(t))
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.1.0")
+	assert.ErrorIs(t, err, ErrInvalidFamily, "Must error when the schema file defines another family")
+}
+
+func TestManagerRetriesFailures(t *testing.T) {
+	t.Parallel()
+
+	provider := &fakeProvider{err: errors.New("unreachable")}
+	m := NewManager(provider, zaptest.NewLogger(t))
+	now := time.Unix(0, 0)
+	m.now = func() time.Time { return now }
+
+	const schemaURL = "https://opentelemetry.io/schemas/1.1.0"
+	_, err := m.RequestTranslation(context.Background(), schemaURL)
+	assert.Error(t, err, "Must error when the schema can not be retrieved")
+
+	_, err = m.RequestTranslation(context.Background(), schemaURL)
+	assert.ErrorIs(t, err, ErrRecentlyFailed, "Must not retrieve a recently failed schema url")
+	assert.Equal(t, 1, provider.calls)
+
+	now = now.Add(retryInterval)
+	_, err = m.RequestTranslation(context.Background(), schemaURL)
+	assert.Error(t, err)
+	assert.Equal(t, 2, provider.calls, "Must retry once the retry interval elapsed")
+}
@@ -0,0 +1,58 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxSchemaFileSize bounds the size of a retrieved schema file.
+const maxSchemaFileSize = 4 << 20
+
+// Provider retrieves the content of the schema file published at a schema url.
+type Provider interface {
+	Retrieve(ctx context.Context, schemaURL string) ([]byte, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider creates a Provider that downloads schema files with client.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (p *httpProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve %q: unexpected status %s", schemaURL, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSchemaFileSize))
+}
@@ -0,0 +1,298 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+)
+
+var ErrUnknownVersion = errors.New("version not defined by schema")
+
+// Translation holds the changes defined by every version of a schema file
+// and applies them to convert signals between any two of those versions.
+type Translation struct {
+	family string
+	// versions is sorted in ascending order,
+	// changes[i] holds the changes introduced by versions[i].
+	versions []*Version
+	changes  []*changeSet
+}
+
+// NewTranslation parses the content of a schema file.
+func NewTranslation(content io.Reader) (*Translation, error) {
+	s, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	family, latest, err := GetFamilyAndVersion(s.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Translation{family: family}
+	for v, def := range s.Versions {
+		ver, err := NewVersion(string(v))
+		if err != nil {
+			return nil, fmt.Errorf("schema version %q: %w", v, err)
+		}
+		t.versions = append(t.versions, ver)
+		t.changes = append(t.changes, newChangeSet(def))
+	}
+	sort.Sort(byVersion{t})
+
+	if len(t.versions) == 0 || !t.versions[len(t.versions)-1].Equal(latest) {
+		return nil, fmt.Errorf("schema url %q must match the highest defined version: %w", s.SchemaURL, ErrInvalidVersion)
+	}
+	return t, nil
+}
+
+// Family returns the schema family of the translation.
+func (t *Translation) Family() string {
+	return t.family
+}
+
+// Latest returns the highest version defined by the schema file.
+func (t *Translation) Latest() *Version {
+	return t.versions[len(t.versions)-1]
+}
+
+// SupportsVersion reports if the version is defined by the schema file.
+func (t *Translation) SupportsVersion(v *Version) bool {
+	_, found := t.index(v)
+	return found
+}
+
+func (t *Translation) index(v *Version) (int, bool) {
+	i := sort.Search(len(t.versions), func(i int) bool {
+		return !t.versions[i].LessThan(v)
+	})
+	return i, i < len(t.versions) && t.versions[i].Equal(v)
+}
+
+// iterate calls fn with the change set of every version between from and to.
+// Updating applies the changes of (from, to] in ascending order, reverting
+// applies the changes of (to, from] in descending order.
+func (t *Translation) iterate(from, to *Version, fn func(cs *changeSet, revert bool)) error {
+	fromIdx, ok := t.index(from)
+	if !ok {
+		return fmt.Errorf("%s/%s: %w", t.family, from, ErrUnknownVersion)
+	}
+	toIdx, ok := t.index(to)
+	if !ok {
+		return fmt.Errorf("%s/%s: %w", t.family, to, ErrUnknownVersion)
+	}
+	switch from.Compare(to) {
+	case Update:
+		for i := fromIdx + 1; i <= toIdx; i++ {
+			fn(t.changes[i], false)
+		}
+	case Revert:
+		for i := fromIdx; i > toIdx; i-- {
+			fn(t.changes[i], true)
+		}
+	}
+	return nil
+}
+
+// ApplyResourceChanges converts the resource attributes from one version to another.
+func (t *Translation) ApplyResourceChanges(resource pcommon.Resource, from, to *Version) error {
+	return t.iterate(from, to, func(cs *changeSet, revert bool) {
+		if revert {
+			applyRenames(resource.Attributes(), cs.resources, revert)
+			applyRenames(resource.Attributes(), cs.all, revert)
+			return
+		}
+		applyRenames(resource.Attributes(), cs.all, revert)
+		applyRenames(resource.Attributes(), cs.resources, revert)
+	})
+}
+
+// ApplySpanChanges converts the spans and their events from one version to another.
+func (t *Translation) ApplySpanChanges(spans ptrace.SpanSlice, from, to *Version) error {
+	return t.iterate(from, to, func(cs *changeSet, revert bool) {
+		for i := 0; i < spans.Len(); i++ {
+			span := spans.At(i)
+			if revert {
+				cs.revertSpanEvents(span)
+				cs.revertSpan(span)
+				continue
+			}
+			cs.updateSpan(span)
+			cs.updateSpanEvents(span)
+		}
+	})
+}
+
+// ApplyMetricChanges converts the metrics and their data points from one version to another.
+func (t *Translation) ApplyMetricChanges(metrics pmetric.MetricSlice, from, to *Version) error {
+	return t.iterate(from, to, func(cs *changeSet, revert bool) {
+		for i := 0; i < metrics.Len(); i++ {
+			metric := metrics.At(i)
+			if revert {
+				cs.applyMetric(metric, revert)
+				forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+					applyRenames(attrs, cs.all, revert)
+				})
+				continue
+			}
+			forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+				applyRenames(attrs, cs.all, revert)
+			})
+			cs.applyMetric(metric, revert)
+		}
+	})
+}
+
+// ApplyLogChanges converts the log records from one version to another.
+func (t *Translation) ApplyLogChanges(logs plog.LogRecordSlice, from, to *Version) error {
+	return t.iterate(from, to, func(cs *changeSet, revert bool) {
+		for i := 0; i < logs.Len(); i++ {
+			attrs := logs.At(i).Attributes()
+			if revert {
+				applyRenames(attrs, cs.logs, revert)
+				applyRenames(attrs, cs.all, revert)
+				continue
+			}
+			applyRenames(attrs, cs.all, revert)
+			applyRenames(attrs, cs.logs, revert)
+		}
+	})
+}
+
+func (cs *changeSet) updateSpan(span ptrace.Span) {
+	applyRenames(span.Attributes(), cs.all, false)
+	for _, c := range cs.spans {
+		if c.spans.matches(span.Name()) {
+			renameAttributes(span.Attributes(), c.attributes.forward)
+		}
+	}
+}
+
+func (cs *changeSet) revertSpan(span ptrace.Span) {
+	for i := len(cs.spans) - 1; i >= 0; i-- {
+		if c := cs.spans[i]; c.spans.matches(span.Name()) {
+			renameAttributes(span.Attributes(), c.attributes.reverse)
+		}
+	}
+	applyRenames(span.Attributes(), cs.all, true)
+}
+
+func (cs *changeSet) updateSpanEvents(span ptrace.Span) {
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		applyRenames(event.Attributes(), cs.all, false)
+		for _, c := range cs.spanEvents {
+			c.apply(span.Name(), event, false)
+		}
+	}
+}
+
+func (cs *changeSet) revertSpanEvents(span ptrace.Span) {
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		for j := len(cs.spanEvents) - 1; j >= 0; j-- {
+			cs.spanEvents[j].apply(span.Name(), event, true)
+		}
+		applyRenames(event.Attributes(), cs.all, true)
+	}
+}
+
+func (c spanEventsChange) apply(spanName string, event ptrace.SpanEvent, revert bool) {
+	if c.eventNames != nil {
+		if name, ok := c.eventNames.lookup(revert)[event.Name()]; ok {
+			event.SetName(name)
+		}
+		return
+	}
+	if c.spans.matches(spanName) && c.events.matches(event.Name()) {
+		renameAttributes(event.Attributes(), c.attributes.lookup(revert))
+	}
+}
+
+func (cs *changeSet) applyMetric(metric pmetric.Metric, revert bool) {
+	forEach(len(cs.metrics), revert, func(i int) {
+		c := cs.metrics[i]
+		if c.metricNames != nil {
+			if name, ok := c.metricNames.lookup(revert)[metric.Name()]; ok {
+				metric.SetName(name)
+			}
+			return
+		}
+		if !c.metrics.matches(metric.Name()) {
+			return
+		}
+		forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+			renameAttributes(attrs, c.attributes.lookup(revert))
+		})
+	})
+}
+
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	}
+}
+
+type byVersion struct {
+	t *Translation
+}
+
+func (b byVersion) Len() int {
+	return len(b.t.versions)
+}
+
+func (b byVersion) Less(i, j int) bool {
+	return b.t.versions[i].LessThan(b.t.versions[j])
+}
+
+func (b byVersion) Swap(i, j int) {
+	b.t.versions[i], b.t.versions[j] = b.t.versions[j], b.t.versions[i]
+	b.t.changes[i], b.t.changes[j] = b.t.changes[j], b.t.changes[i]
+}
@@ -0,0 +1,211 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTranslation(t *testing.T) *Translation {
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must be able to open test schema file")
+	t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+	tr, err := NewTranslation(f)
+	require.NoError(t, err, "Must not error when parsing schema file")
+	return tr
+}
+
+func mustVersion(t *testing.T, s string) *Version {
+	v, err := NewVersion(s)
+	require.NoError(t, err, "Must be a valid version")
+	return v
+}
+
+func TestNewTranslation(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	assert.Equal(t, "https://opentelemetry.io/schemas", tr.Family())
+	assert.Equal(t, &Version{1, 1, 0}, tr.Latest())
+	assert.True(t, tr.SupportsVersion(&Version{1, 0, 0}), "Must support defined version")
+	assert.False(t, tr.SupportsVersion(&Version{1, 0, 1}), "Must not support undefined version")
+}
+
+func TestNewTranslationInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+		content  string
+	}{
+		{
+			scenario: "not a schema file",
+			content:  "invalid: [",
+		},
+		{
+			scenario: "schema url not matching the highest version",
+			content: `file_format: 1.1.0
+schema_url: https://example.com/schemas/1.2.0
+versions:
+  1.1.0:
+  1.0.0:
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		_, err := NewTranslation(strings.NewReader(tc.content))
+		assert.Error(t, err, tc.scenario)
+	}
+}
+
+func TestTranslationUnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	err := tr.ApplyResourceChanges(pcommon.NewResource(), mustVersion(t, "1.0.0"), mustVersion(t, "1.4.0"))
+	assert.ErrorIs(t, err, ErrUnknownVersion, "Must error on a version not defined by the schema")
+}
+
+func TestTranslationResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+
+	res := pcommon.NewResource()
+	res.Attributes().InsertString("k8s.pod.name", "pod")
+	res.Attributes().InsertString("telemetry.auto.version", "1.0")
+	res.Attributes().InsertString("service.name", "svc")
+
+	require.NoError(t, tr.ApplyResourceChanges(res, mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes.pod.name":          "pod",
+		"telemetry.auto_instr.version": "1.0",
+		"service.name":                 "svc",
+	}, res.Attributes().AsRaw(), "Must update resource attributes")
+
+	require.NoError(t, tr.ApplyResourceChanges(res, mustVersion(t, "1.1.0"), mustVersion(t, "1.0.0")))
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":           "pod",
+		"telemetry.auto.version": "1.0",
+		"service.name":           "svc",
+	}, res.Attributes().AsRaw(), "Must revert resource attributes")
+}
+
+func TestTranslationSpanChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+
+	spans := ptrace.NewSpanSlice()
+	get := spans.AppendEmpty()
+	get.SetName("HTTP GET")
+	get.Attributes().InsertString("peer.service", "db")
+	get.Attributes().InsertString("k8s.node.name", "node")
+	renamed := get.Events().AppendEmpty()
+	renamed.SetName("stacktrace")
+	exception := get.Events().AppendEmpty()
+	exception.SetName("exception.stack_trace")
+	exception.Attributes().InsertString("peer.service", "db")
+	post := spans.AppendEmpty()
+	post.SetName("HTTP POST")
+	post.Attributes().InsertString("peer.service", "db")
+
+	require.NoError(t, tr.ApplySpanChanges(spans, mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, map[string]interface{}{
+		"peer.service.name":    "db",
+		"kubernetes.node.name": "node",
+	}, spans.At(0).Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", spans.At(0).Events().At(0).Name())
+	assert.Equal(t, map[string]interface{}{"peer.service.name": "db"}, spans.At(0).Events().At(1).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"peer.service": "db"}, spans.At(1).Attributes().AsRaw(), "Must only rename attributes of matching spans")
+
+	require.NoError(t, tr.ApplySpanChanges(spans, mustVersion(t, "1.1.0"), mustVersion(t, "1.0.0")))
+	assert.Equal(t, map[string]interface{}{
+		"peer.service":  "db",
+		"k8s.node.name": "node",
+	}, spans.At(0).Attributes().AsRaw())
+	assert.Equal(t, "stacktrace", spans.At(0).Events().At(0).Name())
+	assert.Equal(t, map[string]interface{}{"peer.service": "db"}, spans.At(0).Events().At(1).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"peer.service": "db"}, spans.At(1).Attributes().AsRaw())
+}
+
+func TestTranslationMetricChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+
+	metrics := pmetric.NewMetricSlice()
+	usage := metrics.AppendEmpty()
+	usage.SetName("container.memory.usage.max")
+	usage.SetDataType(pmetric.MetricDataTypeHistogram)
+	usage.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("k8s.pod.name", "pod")
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("system.cpu.utilization")
+	cpu.SetDataType(pmetric.MetricDataTypeGauge)
+	cpu.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("status", "idle")
+	other := metrics.AppendEmpty()
+	other.SetName("system.network.io")
+	other.SetDataType(pmetric.MetricDataTypeSum)
+	other.Sum().DataPoints().AppendEmpty().Attributes().InsertString("status", "up")
+
+	require.NoError(t, tr.ApplyMetricChanges(metrics, mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, "memory.usage.max", metrics.At(0).Name())
+	assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod"}, metrics.At(0).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"state": "idle"}, metrics.At(1).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"status": "up"}, metrics.At(2).Sum().DataPoints().At(0).Attributes().AsRaw(), "Must only rename attributes of matching metrics")
+
+	require.NoError(t, tr.ApplyMetricChanges(metrics, mustVersion(t, "1.1.0"), mustVersion(t, "1.0.0")))
+	assert.Equal(t, "container.memory.usage.max", metrics.At(0).Name())
+	assert.Equal(t, map[string]interface{}{"k8s.pod.name": "pod"}, metrics.At(0).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"status": "idle"}, metrics.At(1).Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslationLogChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+
+	logs := plog.NewLogRecordSlice()
+	logs.AppendEmpty().Attributes().InsertString("process.executable_name", "otelcol")
+
+	require.NoError(t, tr.ApplyLogChanges(logs, mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, logs.At(0).Attributes().AsRaw())
+
+	require.NoError(t, tr.ApplyLogChanges(logs, mustVersion(t, "1.1.0"), mustVersion(t, "1.0.0")))
+	assert.Equal(t, map[string]interface{}{"process.executable_name": "otelcol"}, logs.At(0).Attributes().AsRaw())
+}
+
+func TestRenameAttributesSwap(t *testing.T) {
+	t.Parallel()
+
+	attrs := pcommon.NewMap()
+	attrs.InsertString("a", "1")
+	attrs.InsertString("b", "2")
+
+	renameAttributes(attrs, map[string]string{"a": "b", "b": "a"})
+	assert.Equal(t, map[string]interface{}{"a": "2", "b": "1"}, attrs.AsRaw(), "Must support swapping attribute names")
+}
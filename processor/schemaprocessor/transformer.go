@@ -17,18 +17,42 @@ package schemaprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
+// target is the schema version signals of a family are converted to.
+type target struct {
+	url     string
+	version *translation.Version
+}
+
+// conversion holds what is needed to convert signals published with
+// one schema url to the target of its family.
+type conversion struct {
+	translation *translation.Translation
+	from, to    *translation.Version
+	targetURL   string
+}
+
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets     map[string]target
+	prefetch    []string
+	schemaFiles []string
+	httpClient  confighttp.HTTPClientSettings
+	set         component.TelemetrySettings
+	log         *zap.Logger
+	manager     *translation.Manager
 }
 
 func newTransformer(
@@ -40,29 +64,209 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	targets := make(map[string]target, len(cfg.Targets))
+	for _, schemaURL := range cfg.Targets {
+		family, version, err := translation.GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return nil, err
+		}
+		targets[family] = target{url: schemaURL, version: version}
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:         set.Logger,
+		set:         set.TelemetrySettings,
+		targets:     targets,
+		prefetch:    cfg.Prefetch,
+		schemaFiles: cfg.SchemaFiles,
+		httpClient:  cfg.HTTPClientSettings,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+// conversionFor returns how to convert signals published with schemaURL, or
+// nil if they must be left untouched: because the url has no target, is
+// already at the target, or the schema file needed could not be retrieved.
+func (t *transformer) conversionFor(ctx context.Context, schemaURL string) *conversion {
+	if schemaURL == "" {
+		return nil
+	}
+	family, version, err := translation.GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		t.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil
+	}
+	tgt, ok := t.targets[family]
+	if !ok || version.Equal(tgt.version) {
+		return nil
+	}
+
+	// The schema file published with the highest version defines every change needed.
+	requestURL := tgt.url
+	if version.GreaterThan(tgt.version) {
+		requestURL = schemaURL
+	}
+	tr, err := t.manager.RequestTranslation(ctx, requestURL)
+	if err != nil {
+		t.log.Warn("Unable to translate schema url", zap.String("schema-url", schemaURL), zap.String("target", tgt.url), zap.Error(err))
+		return nil
+	}
+	if !tr.SupportsVersion(version) {
+		t.log.Warn("Schema version is not defined by its schema family", zap.String("schema-url", schemaURL), zap.String("target", tgt.url))
+		return nil
+	}
+	return &conversion{translation: tr, from: version, to: tgt.version, targetURL: tgt.url}
+}
+
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	conversions := make(map[string]*conversion)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceURL := rl.SchemaUrl()
+		if conv := t.cachedConversionFor(ctx, conversions, resourceURL); conv != nil {
+			if err := conv.translation.ApplyResourceChanges(rl.Resource(), conv.from, conv.to); err != nil {
+				return ld, err
+			}
+			rl.SetSchemaUrl(conv.targetURL)
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			conv := t.cachedConversionFor(ctx, conversions, scopeSchemaURL(sl.SchemaUrl(), resourceURL))
+			if conv == nil {
+				continue
+			}
+			if err := conv.translation.ApplyLogChanges(sl.LogRecords(), conv.from, conv.to); err != nil {
+				return ld, err
+			}
+			if sl.SchemaUrl() != "" {
+				sl.SetSchemaUrl(conv.targetURL)
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	conversions := make(map[string]*conversion)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceURL := rm.SchemaUrl()
+		if conv := t.cachedConversionFor(ctx, conversions, resourceURL); conv != nil {
+			if err := conv.translation.ApplyResourceChanges(rm.Resource(), conv.from, conv.to); err != nil {
+				return md, err
+			}
+			rm.SetSchemaUrl(conv.targetURL)
+		}
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			conv := t.cachedConversionFor(ctx, conversions, scopeSchemaURL(sm.SchemaUrl(), resourceURL))
+			if conv == nil {
+				continue
+			}
+			if err := conv.translation.ApplyMetricChanges(sm.Metrics(), conv.from, conv.to); err != nil {
+				return md, err
+			}
+			if sm.SchemaUrl() != "" {
+				sm.SetSchemaUrl(conv.targetURL)
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	conversions := make(map[string]*conversion)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceURL := rs.SchemaUrl()
+		if conv := t.cachedConversionFor(ctx, conversions, resourceURL); conv != nil {
+			if err := conv.translation.ApplyResourceChanges(rs.Resource(), conv.from, conv.to); err != nil {
+				return td, err
+			}
+			rs.SetSchemaUrl(conv.targetURL)
+		}
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			conv := t.cachedConversionFor(ctx, conversions, scopeSchemaURL(ss.SchemaUrl(), resourceURL))
+			if conv == nil {
+				continue
+			}
+			if err := conv.translation.ApplySpanChanges(ss.Spans(), conv.from, conv.to); err != nil {
+				return td, err
+			}
+			if ss.SchemaUrl() != "" {
+				ss.SetSchemaUrl(conv.targetURL)
+			}
+		}
+	}
 	return td, nil
 }
 
-// start will load the remote file definition if it isn't already cached
-// and resolve the schema translation file
+// cachedConversionFor avoids resolving the same schema url more than once per batch.
+func (t *transformer) cachedConversionFor(ctx context.Context, conversions map[string]*conversion, schemaURL string) *conversion {
+	if conv, ok := conversions[schemaURL]; ok {
+		return conv
+	}
+	conv := t.conversionFor(ctx, schemaURL)
+	conversions[schemaURL] = conv
+	return conv
+}
+
+// scopeSchemaURL returns the schema url that applies to a scope,
+// which inherits the one of its resource when it has none.
+func scopeSchemaURL(scopeURL, resourceURL string) string {
+	if scopeURL != "" {
+		return scopeURL
+	}
+	return resourceURL
+}
+
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.httpClient.ToClient(host.GetExtensions(), t.set)
+	if err != nil {
+		return err
+	}
+	t.manager = translation.NewManager(translation.NewHTTPProvider(client), t.log)
+
+	for _, file := range t.schemaFiles {
+		tr, err := loadSchemaFile(file)
+		if err != nil {
+			return err
+		}
+		t.manager.AddTranslation(tr)
+	}
+
+	for _, schemaURL := range t.prefetch {
+		t.fetch(ctx, schemaURL)
+	}
+	for _, tgt := range t.targets {
+		t.fetch(ctx, tgt.url)
 	}
 	return nil
 }
+
+// fetch warms up the cache, failures are only logged since the
+// schema url is requested again when signals need it.
+func (t *transformer) fetch(ctx context.Context, schemaURL string) {
+	t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+	if _, err := t.manager.RequestTranslation(ctx, schemaURL); err != nil {
+		t.log.Warn("Unable to fetch schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+	}
+}
+
+func loadSchemaFile(path string) (*translation.Translation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open schema file: %w", err)
+	}
+	defer f.Close()
+	tr, err := translation.NewTranslation(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file %q: %w", path, err)
+	}
+	return tr, nil
+}
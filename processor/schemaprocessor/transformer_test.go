@@ -15,14 +15,18 @@
 package schemaprocessor
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -55,7 +59,179 @@ func TestTransformerStart(t *testing.T) {
 	t.Parallel()
 
 	trans := newTestTransformer(t)
-	assert.NoError(t, trans.start(context.Background(), nil))
+	assert.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+}
+
+func newTranslatingTransformer(t *testing.T, targets ...string) *transformer {
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = targets
+	cfg.SchemaFiles = []string{filepath.Join("testdata", "schema.yml")}
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()), "Must not error when starting")
+	return trans
+}
+
+func TestTransformerStartInvalidSchemaFile(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.SchemaFiles = []string{filepath.Join("testdata", "config.yml")}
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	assert.Error(t, trans.start(context.Background(), componenttest.NewNopHost()), "Must error when a schema file is invalid")
+}
+
+func TestTransformerUpdatesSignals(t *testing.T) {
+	t.Parallel()
+
+	trans := newTranslatingTransformer(t, "https://opentelemetry.io/schemas/1.1.0")
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		rs.Resource().Attributes().InsertString("k8s.pod.name", "pod")
+		rs.Resource().Attributes().InsertString("telemetry.auto.version", "1.0")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		get := ss.Spans().AppendEmpty()
+		get.SetName("HTTP GET")
+		get.Attributes().InsertString("peer.service", "db")
+		event := get.Events().AppendEmpty()
+		event.SetName("stacktrace")
+		post := ss.Spans().AppendEmpty()
+		post.SetName("HTTP POST")
+		post.Attributes().InsertString("peer.service", "db")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", rs.SchemaUrl(), "Must set the target schema url on the resource")
+		assert.Equal(t, map[string]interface{}{
+			"kubernetes.pod.name":          "pod",
+			"telemetry.auto_instr.version": "1.0",
+		}, rs.Resource().Attributes().AsRaw())
+		ss = rs.ScopeSpans().At(0)
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", ss.SchemaUrl(), "Must set the target schema url on the scope")
+		assert.Equal(t, map[string]interface{}{"peer.service.name": "db"}, ss.Spans().At(0).Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", ss.Spans().At(0).Events().At(0).Name())
+		assert.Equal(t, map[string]interface{}{"peer.service": "db"}, ss.Spans().At(1).Attributes().AsRaw(), "Must only apply to matching spans")
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+		renamed := ms.AppendEmpty()
+		renamed.SetName("container.cpu.usage.total")
+		renamed.SetDataType(pmetric.MetricDataTypeSum)
+		renamed.Sum().DataPoints().AppendEmpty().Attributes().InsertString("k8s.node.name", "node")
+		attrs := ms.AppendEmpty()
+		attrs.SetName("system.cpu.utilization")
+		attrs.SetDataType(pmetric.MetricDataTypeGauge)
+		attrs.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("status", "idle")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		ms = out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		assert.Equal(t, "cpu.usage.total", ms.At(0).Name())
+		assert.Equal(t, map[string]interface{}{"kubernetes.node.name": "node"}, ms.At(0).Sum().DataPoints().At(0).Attributes().AsRaw())
+		assert.Equal(t, map[string]interface{}{"state": "idle"}, ms.At(1).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+
+		lr := out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, lr.Attributes().AsRaw())
+	})
+}
+
+func TestTransformerRevertsSignals(t *testing.T) {
+	t.Parallel()
+
+	trans := newTranslatingTransformer(t, "https://opentelemetry.io/schemas/1.0.0")
+
+	in := ptrace.NewTraces()
+	rs := in.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	rs.Resource().Attributes().InsertString("kubernetes.pod.name", "pod")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("HTTP GET")
+	span.Attributes().InsertString("peer.service.name", "db")
+
+	out, err := trans.processTraces(context.Background(), in)
+	require.NoError(t, err, "Must not error when processing traces")
+
+	rs = out.ResourceSpans().At(0)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.0.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"k8s.pod.name": "pod"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"peer.service": "db"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestTransformerFetchesRemoteSchema(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		content := bytes.ReplaceAll(schemaContent, []byte("https://opentelemetry.io"), []byte(server.URL))
+		_, err := wr.Write(content)
+		assert.NoError(t, err, "Must not have issues writing schema content")
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{server.URL + "/schemas/1.1.0"}
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	in := plog.NewLogs()
+	rl := in.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(server.URL + "/schemas/1.0.0")
+	rl.Resource().Attributes().InsertString("k8s.pod.name", "pod")
+
+	out, err := trans.processLogs(context.Background(), in)
+	require.NoError(t, err, "Must not error when processing logs")
+	assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod"}, out.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestTransformerLeavesUnknownSchemaUntouched(t *testing.T) {
+	t.Parallel()
+
+	trans := newTranslatingTransformer(t, "https://opentelemetry.io/schemas/1.1.0")
+
+	in := plog.NewLogs()
+	rl := in.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://example.com/schemas/1.0.0")
+	rl.Resource().Attributes().InsertString("k8s.pod.name", "pod")
+	expect := in.Clone()
+
+	out, err := trans.processLogs(context.Background(), in)
+	require.NoError(t, err, "Must not error when processing logs")
+	assert.Equal(t, expect, out, "Must not modify signals without a matching target")
 }
 
 func TestTransformerProcessing(t *testing.T) {
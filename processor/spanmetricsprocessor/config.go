@@ -40,6 +40,10 @@ type Config struct {
 	// MetricsExporter is the name of the metrics exporter to use to ship metrics.
 	MetricsExporter string `mapstructure:"metrics_exporter"`
 
+	// MetricsReceiver is the name of the spanmetrics receiver to feed metrics into, so that they go through the
+	// processors of the receiver's metrics pipelines. It cannot be used together with MetricsExporter.
+	MetricsReceiver string `mapstructure:"metrics_receiver"`
+
 	// LatencyHistogramBuckets is the list of durations representing latency histogram buckets.
 	// See defaultLatencyHistogramBucketsMs in processor.go for the default value.
 	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
@@ -60,10 +64,36 @@ type Config struct {
 
 	AggregationTemporality string `mapstructure:"aggregation_temporality"`
 
+	// ExponentialHistogram, when set, records latencies in an exponential histogram instead of the explicit bucket
+	// histogram described by LatencyHistogramBuckets, so that the bucket boundaries need no tuning.
+	// It cannot be used together with LatencyHistogramBuckets.
+	ExponentialHistogram *ExponentialHistogramConfig `mapstructure:"exponential_histogram"`
+
+	// Events configures the counter of span events.
+	Events EventsConfig `mapstructure:"events"`
+
 	// skipSanitizeLabel if enabled, labels that start with _ are not sanitized
 	skipSanitizeLabel bool
 }
 
+// ExponentialHistogramConfig defines the configuration of the exponential latency histogram.
+type ExponentialHistogramConfig struct {
+	// MaxSize is the maximum number of buckets of each data point. The resolution of the histogram is lowered
+	// whenever the recorded latencies would need more buckets. Must be at least 2.
+	// Optional. See DefaultMaxSize in internal/coreinternal/expohistogram for the default value.
+	MaxSize int32 `mapstructure:"max_size"`
+}
+
+// EventsConfig defines the configuration of the span events counter.
+type EventsConfig struct {
+	// Enabled emits the events_total metric, counting the events of the spans (e.g. exceptions) per event name.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dimensions defines the list of additional dimensions of the events_total metric, on top of the span dimensions
+	// and the event name. The dimensions are fetched from the event's attributes.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
 var dropSanitizationGate = featuregate.Gate{
 	ID:          "processor.spanmetrics.PermissiveLabelSanitization",
 	Enabled:     false,
@@ -39,10 +39,12 @@ func TestLoadConfig(t *testing.T) {
 	testcases := []struct {
 		configFile                  string
 		wantMetricsExporter         string
+		wantMetricsReceiver         string
 		wantLatencyHistogramBuckets []time.Duration
 		wantDimensions              []Dimension
 		wantDimensionsCacheSize     int
 		wantAggregationTemporality  string
+		wantEvents                  EventsConfig
 	}{
 		{
 			configFile:                 "config-2-pipelines.yaml",
@@ -56,6 +58,12 @@ func TestLoadConfig(t *testing.T) {
 			wantAggregationTemporality: cumulative,
 			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
 		},
+		{
+			configFile:                 "config-metrics-receiver.yaml",
+			wantMetricsReceiver:        "spanmetrics",
+			wantAggregationTemporality: cumulative,
+			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
+		},
 		{
 			configFile:          "config-full.yaml",
 			wantMetricsExporter: "otlp/spanmetrics",
@@ -74,6 +82,10 @@ func TestLoadConfig(t *testing.T) {
 			},
 			wantDimensionsCacheSize:    1500,
 			wantAggregationTemporality: delta,
+			wantEvents: EventsConfig{
+				Enabled:    true,
+				Dimensions: []Dimension{{"exception.type", nil}},
+			},
 		},
 	}
 	for _, tc := range testcases {
@@ -84,6 +96,7 @@ func TestLoadConfig(t *testing.T) {
 
 			factories.Receivers["otlp"] = otlpreceiver.NewFactory()
 			factories.Receivers["jaeger"] = jaegerreceiver.NewFactory()
+			factories.Receivers[typeStr] = NewReceiverFactory()
 
 			factories.Processors[typeStr] = NewFactory()
 			factories.Processors["batch"] = batchprocessor.NewFactory()
@@ -102,10 +115,12 @@ func TestLoadConfig(t *testing.T) {
 				&Config{
 					ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
 					MetricsExporter:         tc.wantMetricsExporter,
+					MetricsReceiver:         tc.wantMetricsReceiver,
 					LatencyHistogramBuckets: tc.wantLatencyHistogramBuckets,
 					Dimensions:              tc.wantDimensions,
 					DimensionsCacheSize:     tc.wantDimensionsCacheSize,
 					AggregationTemporality:  tc.wantAggregationTemporality,
+					Events:                  tc.wantEvents,
 				},
 				cfg.Processors[config.NewComponentID(typeStr)],
 			)
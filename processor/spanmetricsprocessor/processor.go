@@ -16,6 +16,7 @@ package spanmetricsprocessor // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohistogram"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/internal/cache"
 )
 
@@ -40,6 +42,7 @@ const (
 	operationKey       = "operation"   // OpenTelemetry non-standard constant.
 	spanKindKey        = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
+	eventNameKey       = "event.name"  // OpenTelemetry non-standard constant.
 	metricKeySeparator = string(byte(0))
 	traceIDKey         = "trace_id"
 
@@ -54,6 +57,7 @@ var (
 
 type exemplarData struct {
 	traceID pcommon.TraceID
+	spanID  pcommon.SpanID
 	value   float64
 }
 
@@ -64,7 +68,9 @@ type processorImp struct {
 	logger *zap.Logger
 	config Config
 
-	metricsExporter component.MetricsExporter
+	// metricsConsumer is either the configured metrics exporter or the next consumer of the configured
+	// spanmetrics receiver.
+	metricsConsumer consumer.Metrics
 	nextConsumer    consumer.Traces
 
 	// Additional dimensions to add to metrics.
@@ -77,11 +83,20 @@ type processorImp struct {
 	callSum map[metricKey]int64
 
 	// Latency histogram.
-	latencyCount         map[metricKey]uint64
-	latencySum           map[metricKey]float64
-	latencyBucketCounts  map[metricKey][]uint64
-	latencyBounds        []float64
-	latencyExemplarsData map[metricKey][]exemplarData
+	latencyCount        map[metricKey]uint64
+	latencySum          map[metricKey]float64
+	latencyBucketCounts map[metricKey][]uint64
+	latencyBounds       []float64
+
+	// Latency exponential histogram, replacing the latency histogram when configured.
+	latencyExpHistograms map[metricKey]*expohistogram.Histogram
+	latencyExpMaxSize    int32
+
+	// Exemplars of the calls and latency metrics.
+	exemplarsData map[metricKey][]exemplarData
+
+	// Span event counts.
+	eventSum map[metricKey]int64
 
 	// An LRU cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
 	// e.g. { "foo/barOK": { "serviceName": "foo", "operation": "/bar", "status_code": "OK" }}
@@ -97,10 +112,40 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		bounds = mapDurationsToMillis(pConfig.LatencyHistogramBuckets)
 	}
 
+	if pConfig.MetricsExporter != "" && pConfig.MetricsReceiver != "" {
+		return nil, errors.New("metrics_exporter cannot be used together with metrics_receiver")
+	}
+
 	if err := validateDimensions(pConfig.Dimensions, pConfig.skipSanitizeLabel); err != nil {
 		return nil, err
 	}
 
+	if pConfig.Events.Enabled {
+		// Event dimensions share the attributes of the events metric with the span dimensions and the event name.
+		dimensions := append([]Dimension{{Name: eventNameKey}}, pConfig.Dimensions...)
+		if err := validateDimensions(append(dimensions, pConfig.Events.Dimensions...), pConfig.skipSanitizeLabel); err != nil {
+			return nil, fmt.Errorf("invalid events dimensions: %w", err)
+		}
+	}
+
+	var latencyExpHistograms map[metricKey]*expohistogram.Histogram
+	latencyExpMaxSize := expohistogram.DefaultMaxSize
+	if pConfig.ExponentialHistogram != nil {
+		if pConfig.LatencyHistogramBuckets != nil {
+			return nil, errors.New("latency_histogram_buckets cannot be used together with exponential_histogram")
+		}
+		if pConfig.ExponentialHistogram.MaxSize != 0 && pConfig.ExponentialHistogram.MaxSize < expohistogram.MinMaxSize {
+			return nil, fmt.Errorf(
+				"invalid exponential histogram max size: %v, the maximum number of buckets should be at least %d",
+				pConfig.ExponentialHistogram.MaxSize, expohistogram.MinMaxSize,
+			)
+		}
+		if pConfig.ExponentialHistogram.MaxSize > 0 {
+			latencyExpMaxSize = pConfig.ExponentialHistogram.MaxSize
+		}
+		latencyExpHistograms = make(map[metricKey]*expohistogram.Histogram)
+	}
+
 	if pConfig.DimensionsCacheSize <= 0 {
 		return nil, fmt.Errorf(
 			"invalid cache size: %v, the maximum number of the items in the cache should be positive",
@@ -121,7 +166,10 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		latencySum:            make(map[metricKey]float64),
 		latencyCount:          make(map[metricKey]uint64),
 		latencyBucketCounts:   make(map[metricKey][]uint64),
-		latencyExemplarsData:  make(map[metricKey][]exemplarData),
+		latencyExpHistograms:  latencyExpHistograms,
+		latencyExpMaxSize:     latencyExpMaxSize,
+		exemplarsData:         make(map[metricKey][]exemplarData),
+		eventSum:              make(map[metricKey]int64),
 		nextConsumer:          nextConsumer,
 		dimensions:            pConfig.Dimensions,
 		metricKeyToDimensions: metricKeyToDimensionsCache,
@@ -174,6 +222,21 @@ func validateDimensions(dimensions []Dimension, skipSanitizeLabel bool) error {
 // Start implements the component.Component interface.
 func (p *processorImp) Start(ctx context.Context, host component.Host) error {
 	p.logger.Info("Starting spanmetricsprocessor")
+	if p.config.MetricsReceiver != "" {
+		id, err := config.NewComponentIDFromString(p.config.MetricsReceiver)
+		if err != nil {
+			return fmt.Errorf("invalid metrics receiver: %w", err)
+		}
+		r, err := findMetricsReceiver(id)
+		if err != nil {
+			return err
+		}
+		p.metricsConsumer = r.nextConsumer
+		p.logger.Info("Found receiver", zap.String("spanmetrics-receiver", p.config.MetricsReceiver))
+		p.logger.Info("Started spanmetricsprocessor")
+		return nil
+	}
+
 	exporters := host.GetExporters()
 
 	var availableMetricsExporters []string
@@ -192,12 +255,12 @@ func (p *processorImp) Start(ctx context.Context, host component.Host) error {
 			zap.Any("available-exporters", availableMetricsExporters),
 		)
 		if k.String() == p.config.MetricsExporter {
-			p.metricsExporter = metricsExp
+			p.metricsConsumer = metricsExp
 			p.logger.Info("Found exporter", zap.String("spanmetrics-exporter", p.config.MetricsExporter))
 			break
 		}
 	}
-	if p.metricsExporter == nil {
+	if p.metricsConsumer == nil {
 		return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
 			p.config.MetricsExporter, availableMetricsExporters)
 	}
@@ -217,7 +280,8 @@ func (p *processorImp) Capabilities() consumer.Capabilities {
 }
 
 // ConsumeTraces implements the consumer.Traces interface.
-// It aggregates the trace data to generate metrics, forwarding these metrics to the discovered metrics exporter.
+// It aggregates the trace data to generate metrics, forwarding these metrics to the discovered metrics exporter
+// or spanmetrics receiver.
 // The original input trace data will be forwarded to the next consumer, unmodified.
 func (p *processorImp) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
 	// Execute trace to metrics aggregation as a goroutine and only log errors instead
@@ -241,7 +305,7 @@ func (p *processorImp) ConsumeTraces(ctx context.Context, traces ptrace.Traces)
 
 		if err != nil {
 			p.logger.Error(err.Error())
-		} else if err = p.metricsExporter.ConsumeMetrics(ctx, *m); err != nil {
+		} else if err = p.metricsConsumer.ConsumeMetrics(ctx, *m); err != nil {
 			p.logger.Error(err.Error())
 		}
 
@@ -263,7 +327,15 @@ func (p *processorImp) buildMetrics() (*pmetric.Metrics, error) {
 		return nil, err
 	}
 
-	if err := p.collectLatencyMetrics(ilm); err != nil {
+	if p.latencyExpHistograms != nil {
+		if err := p.collectLatencyExponentialHistogramMetrics(ilm); err != nil {
+			return nil, err
+		}
+	} else if err := p.collectLatencyMetrics(ilm); err != nil {
+		return nil, err
+	}
+
+	if err := p.collectEventMetrics(ilm); err != nil {
 		return nil, err
 	}
 
@@ -297,7 +369,36 @@ func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 		dpLatency.SetCount(p.latencyCount[key])
 		dpLatency.SetSum(p.latencySum[key])
 
-		setLatencyExemplars(p.latencyExemplarsData[key], timestamp, dpLatency.Exemplars())
+		setExemplars(p.exemplarsData[key], timestamp, dpLatency.Exemplars())
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			p.logger.Error(err.Error())
+			return err
+		}
+
+		dimensions.CopyTo(dpLatency.Attributes())
+	}
+	return nil
+}
+
+// collectLatencyExponentialHistogramMetrics collects the raw latency exponential histograms, writing the data
+// into the given instrumentation library metrics.
+func (p *processorImp) collectLatencyExponentialHistogramMetrics(ilm pmetric.ScopeMetrics) error {
+	for key, histogram := range p.latencyExpHistograms {
+		mLatency := ilm.Metrics().AppendEmpty()
+		mLatency.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+		mLatency.SetName("latency")
+		mLatency.ExponentialHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+		timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+		dpLatency := mLatency.ExponentialHistogram().DataPoints().AppendEmpty()
+		dpLatency.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpLatency.SetTimestamp(timestamp)
+		histogram.CopyTo(dpLatency)
+
+		setExemplars(p.exemplarsData[key], timestamp, dpLatency.Exemplars())
 
 		dimensions, err := p.getDimensionsByMetricKey(key)
 		if err != nil {
@@ -320,11 +421,15 @@ func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
 		mCalls.Sum().SetIsMonotonic(true)
 		mCalls.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
 
+		timestamp := pcommon.NewTimestampFromTime(time.Now())
+
 		dpCalls := mCalls.Sum().DataPoints().AppendEmpty()
 		dpCalls.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
-		dpCalls.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dpCalls.SetTimestamp(timestamp)
 		dpCalls.SetIntVal(p.callSum[key])
 
+		setExemplars(p.exemplarsData[key], timestamp, dpCalls.Exemplars())
+
 		dimensions, err := p.getDimensionsByMetricKey(key)
 		if err != nil {
 			return err
@@ -335,6 +440,31 @@ func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
+// collectEventMetrics collects the raw span event count metrics, writing the data
+// into the given instrumentation library metrics.
+func (p *processorImp) collectEventMetrics(ilm pmetric.ScopeMetrics) error {
+	for key := range p.eventSum {
+		mEvents := ilm.Metrics().AppendEmpty()
+		mEvents.SetDataType(pmetric.MetricDataTypeSum)
+		mEvents.SetName("events_total")
+		mEvents.Sum().SetIsMonotonic(true)
+		mEvents.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+		dpEvents := mEvents.Sum().DataPoints().AppendEmpty()
+		dpEvents.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpEvents.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dpEvents.SetIntVal(p.eventSum[key])
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			return err
+		}
+
+		dimensions.CopyTo(dpEvents.Attributes())
+	}
+	return nil
+}
+
 // getDimensionsByMetricKey gets dimensions from `metricKeyToDimensions` cache.
 func (p *processorImp) getDimensionsByMetricKey(k metricKey) (*pcommon.Map, error) {
 	if item, ok := p.metricKeyToDimensions.Get(k); ok {
@@ -387,8 +517,38 @@ func (p *processorImp) aggregateMetricsForSpan(serviceName string, span ptrace.S
 
 	p.cache(serviceName, span, key, resourceAttr)
 	p.updateCallMetrics(key)
-	p.updateLatencyMetrics(key, latencyInMilliseconds, index)
-	p.updateLatencyExemplars(key, latencyInMilliseconds, span.TraceID())
+	if p.latencyExpHistograms != nil {
+		p.updateLatencyExponentialHistogram(key, latencyInMilliseconds)
+	} else {
+		p.updateLatencyMetrics(key, latencyInMilliseconds, index)
+	}
+	p.updateExemplars(key, latencyInMilliseconds, span.TraceID(), span.SpanID())
+
+	if p.config.Events.Enabled {
+		p.aggregateMetricsForSpanEvents(serviceName, span, key, resourceAttr)
+	}
+}
+
+// aggregateMetricsForSpanEvents counts the events of the span. Each event metric is identified by the key of
+// the span's metrics, the event name and the values of the configured event dimensions.
+func (p *processorImp) aggregateMetricsForSpanEvents(serviceName string, span ptrace.Span, spanKey metricKey, resourceAttr pcommon.Map) {
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		key := buildEventKey(spanKey, event, p.config.Events.Dimensions)
+
+		// Use Get to ensure any existing key has its recent-ness updated.
+		if _, has := p.metricKeyToDimensions.Get(key); !has {
+			dims := p.buildDimensionKVs(serviceName, span, p.dimensions, resourceAttr)
+			dims.UpsertString(eventNameKey, event.Name())
+			for _, d := range p.config.Events.Dimensions {
+				if v, ok := getEventDimensionValue(d, event.Attributes()); ok {
+					dims.Upsert(d.Name, v)
+				}
+			}
+			p.metricKeyToDimensions.Add(key, dims)
+		}
+		p.eventSum[key]++
+	}
 }
 
 // updateCallMetrics increments the call count for the given metric key.
@@ -403,27 +563,32 @@ func (p *processorImp) resetAccumulatedMetrics() {
 	p.latencyCount = make(map[metricKey]uint64)
 	p.latencySum = make(map[metricKey]float64)
 	p.latencyBucketCounts = make(map[metricKey][]uint64)
+	if p.latencyExpHistograms != nil {
+		p.latencyExpHistograms = make(map[metricKey]*expohistogram.Histogram)
+	}
+	p.eventSum = make(map[metricKey]int64)
 	p.metricKeyToDimensions.Purge()
 }
 
-// updateLatencyExemplars sets the histogram exemplars for the given metric key and append the exemplar data.
-func (p *processorImp) updateLatencyExemplars(key metricKey, value float64, traceID pcommon.TraceID) {
-	if _, ok := p.latencyExemplarsData[key]; !ok {
-		p.latencyExemplarsData[key] = []exemplarData{}
+// updateExemplars appends the exemplar data of the span to the calls and latency exemplars of the given metric key.
+func (p *processorImp) updateExemplars(key metricKey, value float64, traceID pcommon.TraceID, spanID pcommon.SpanID) {
+	if _, ok := p.exemplarsData[key]; !ok {
+		p.exemplarsData[key] = []exemplarData{}
 	}
 
 	e := exemplarData{
 		traceID: traceID,
+		spanID:  spanID,
 		value:   value,
 	}
-	p.latencyExemplarsData[key] = append(p.latencyExemplarsData[key], e)
+	p.exemplarsData[key] = append(p.exemplarsData[key], e)
 }
 
 // resetExemplarData resets the entire exemplars map so the next trace will recreate all
 // the data structure. An exemplar is a punctual value that exists at specific moment in time
 // and should be not considered like a metrics that persist over time.
 func (p *processorImp) resetExemplarData() {
-	p.latencyExemplarsData = make(map[metricKey][]exemplarData)
+	p.exemplarsData = make(map[metricKey][]exemplarData)
 }
 
 // updateLatencyMetrics increments the histogram counts for the given metric key and bucket index.
@@ -436,6 +601,16 @@ func (p *processorImp) updateLatencyMetrics(key metricKey, latency float64, inde
 	p.latencyBucketCounts[key][index]++
 }
 
+// updateLatencyExponentialHistogram records the latency in the exponential histogram of the given metric key.
+func (p *processorImp) updateLatencyExponentialHistogram(key metricKey, latency float64) {
+	histogram, ok := p.latencyExpHistograms[key]
+	if !ok {
+		histogram = expohistogram.New(p.latencyExpMaxSize)
+		p.latencyExpHistograms[key] = histogram
+	}
+	histogram.Update(latency, 1)
+}
+
 func (p *processorImp) buildDimensionKVs(serviceName string, span ptrace.Span, optionalDims []Dimension, resourceAttrs pcommon.Map) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.UpsertString(serviceNameKey, serviceName)
@@ -481,6 +656,34 @@ func buildKey(serviceName string, span ptrace.Span, optionalDims []Dimension, re
 	return k
 }
 
+// buildEventKey builds the metric key of a span event from the key of the span's metrics, the event name and
+// the values of the configured event dimensions found in the event's attributes.
+func buildEventKey(spanKey metricKey, event ptrace.SpanEvent, eventDims []Dimension) metricKey {
+	var metricKeyBuilder strings.Builder
+	concatDimensionValue(&metricKeyBuilder, string(spanKey), false)
+	concatDimensionValue(&metricKeyBuilder, event.Name(), true)
+
+	for _, d := range eventDims {
+		if v, ok := getEventDimensionValue(d, event.Attributes()); ok {
+			concatDimensionValue(&metricKeyBuilder, v.AsString(), true)
+		}
+	}
+
+	return metricKey(metricKeyBuilder.String())
+}
+
+// getEventDimensionValue gets the dimension value for the given configured event dimension from the event's
+// attributes, falling back to the configured default value if provided.
+func getEventDimensionValue(d Dimension, eventAttr pcommon.Map) (v pcommon.Value, ok bool) {
+	if attr, exists := eventAttr.Get(d.Name); exists {
+		return attr, true
+	}
+	if d.Default != nil {
+		return pcommon.NewValueString(*d.Default), true
+	}
+	return v, ok
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
 // It searches through the span's attributes first, being the more specific;
 // falling back to searching in resource attributes if it can't be found in the span.
@@ -548,8 +751,8 @@ func sanitizeRune(r rune) rune {
 	return '_'
 }
 
-// setLatencyExemplars sets the histogram exemplars.
-func setLatencyExemplars(exemplarsData []exemplarData, timestamp pcommon.Timestamp, exemplars pmetric.ExemplarSlice) {
+// setExemplars sets the exemplars of a calls or latency data point.
+func setExemplars(exemplarsData []exemplarData, timestamp pcommon.Timestamp, exemplars pmetric.ExemplarSlice) {
 	es := pmetric.NewExemplarSlice()
 	es.EnsureCapacity(len(exemplarsData))
 
@@ -565,6 +768,8 @@ func setLatencyExemplars(exemplarsData []exemplarData, timestamp pcommon.Timesta
 
 		exemplar.SetDoubleVal(value)
 		exemplar.SetTimestamp(timestamp)
+		exemplar.SetTraceID(traceID)
+		exemplar.SetSpanID(ed.spanID)
 		exemplar.FilteredAttributes().Insert(traceIDKey, pcommon.NewValueString(traceID.HexString()))
 	}
 
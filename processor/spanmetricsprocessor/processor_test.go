@@ -130,6 +130,47 @@ func TestProcessorStart(t *testing.T) {
 	}
 }
 
+func TestProcessorStartWithMetricsReceiver(t *testing.T) {
+	// Prepare
+	sink := new(consumertest.MetricsSink)
+	receiverFactory := NewReceiverFactory()
+	receiverCfg := receiverFactory.CreateDefaultConfig()
+	receiverCfg.SetIDName("red")
+	r, err := receiverFactory.CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), receiverCfg, sink)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetricsReceiver = "spanmetrics/red"
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Test
+	require.NoError(t, traceProcessor.Start(context.Background(), &mocks.Host{}))
+	require.NoError(t, traceProcessor.ConsumeTraces(context.Background(), buildSampleTrace()))
+
+	// Verify
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProcessorStartWithMissingMetricsReceiver(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetricsReceiver = "spanmetrics/missing"
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Test
+	err = traceProcessor.Start(context.Background(), &mocks.Host{})
+
+	// Verify
+	assert.EqualError(t, err, "failed to find metrics receiver: 'spanmetrics/missing'; please add it to a metrics pipeline")
+}
+
 func TestProcessorShutdown(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
@@ -364,16 +405,17 @@ func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, de
 	return &processorImp{
 		logger:          logger,
 		config:          Config{AggregationTemporality: temporality},
-		metricsExporter: mexp,
+		metricsConsumer: mexp,
 		nextConsumer:    tcon,
 
-		startTime:            time.Now(),
-		callSum:              make(map[metricKey]int64),
-		latencySum:           make(map[metricKey]float64),
-		latencyCount:         make(map[metricKey]uint64),
-		latencyBucketCounts:  make(map[metricKey][]uint64),
-		latencyBounds:        defaultLatencyHistogramBucketsMs,
-		latencyExemplarsData: make(map[metricKey][]exemplarData),
+		startTime:           time.Now(),
+		callSum:             make(map[metricKey]int64),
+		latencySum:          make(map[metricKey]float64),
+		latencyCount:        make(map[metricKey]uint64),
+		latencyBucketCounts: make(map[metricKey][]uint64),
+		latencyBounds:       defaultLatencyHistogramBucketsMs,
+		exemplarsData:       make(map[metricKey][]exemplarData),
+		eventSum:            make(map[metricKey]int64),
 		dimensions: []Dimension{
 			// Set nil defaults to force a lookup for the attribute in the span.
 			{stringAttrName, nil},
@@ -535,9 +577,10 @@ func verifyMetricLabels(dp metricDataPoint, t testing.TB, seenMetricIDs map[metr
 }
 
 // buildSampleTrace builds the following trace:
-//   service-a/ping (server) ->
-//     service-a/ping (client) ->
-//       service-b/ping (server)
+//
+//	service-a/ping (server) ->
+//	  service-a/ping (client) ->
+//	    service-b/ping (server)
 func buildSampleTrace() ptrace.Traces {
 	traces := ptrace.NewTraces()
 
@@ -807,18 +850,19 @@ func TestSanitize(t *testing.T) {
 	require.Equal(t, "test__", sanitize("test_/", cfg.skipSanitizeLabel))
 }
 
-func TestSetLatencyExemplars(t *testing.T) {
+func TestSetExemplars(t *testing.T) {
 	// ----- conditions -------------------------------------------------------
 	traces := buildSampleTrace()
 	traceID := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+	spanID := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SpanID()
 	exemplarSlice := pmetric.NewExemplarSlice()
 	timestamp := pcommon.NewTimestampFromTime(time.Now())
 	value := float64(42)
 
-	ed := []exemplarData{{traceID: traceID, value: value}}
+	ed := []exemplarData{{traceID: traceID, spanID: spanID, value: value}}
 
 	// ----- call -------------------------------------------------------------
-	setLatencyExemplars(ed, timestamp, exemplarSlice)
+	setExemplars(ed, timestamp, exemplarSlice)
 
 	// ----- verify -----------------------------------------------------------
 	traceIDValue, exist := exemplarSlice.At(0).FilteredAttributes().Get(traceIDKey)
@@ -828,26 +872,29 @@ func TestSetLatencyExemplars(t *testing.T) {
 	assert.Equal(t, traceIDValue.AsString(), traceID.HexString())
 	assert.Equal(t, exemplarSlice.At(0).Timestamp(), timestamp)
 	assert.Equal(t, exemplarSlice.At(0).DoubleVal(), value)
+	assert.Equal(t, traceID, exemplarSlice.At(0).TraceID())
+	assert.Equal(t, spanID, exemplarSlice.At(0).SpanID())
 }
 
-func TestProcessorUpdateLatencyExemplars(t *testing.T) {
+func TestProcessorUpdateExemplars(t *testing.T) {
 	// ----- conditions -------------------------------------------------------
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 	traces := buildSampleTrace()
 	traceID := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+	spanID := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SpanID()
 	key := metricKey("metricKey")
 	next := new(consumertest.TracesSink)
 	p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
 	value := float64(42)
 
 	// ----- call -------------------------------------------------------------
-	p.updateLatencyExemplars(key, value, traceID)
+	p.updateExemplars(key, value, traceID, spanID)
 
 	// ----- verify -----------------------------------------------------------
 	assert.NoError(t, err)
-	assert.NotEmpty(t, p.latencyExemplarsData[key])
-	assert.Equal(t, p.latencyExemplarsData[key][0], exemplarData{traceID: traceID, value: value})
+	assert.NotEmpty(t, p.exemplarsData[key])
+	assert.Equal(t, p.exemplarsData[key][0], exemplarData{traceID: traceID, spanID: spanID, value: value})
 }
 
 func TestProcessorResetExemplarData(t *testing.T) {
@@ -864,5 +911,139 @@ func TestProcessorResetExemplarData(t *testing.T) {
 
 	// ----- verify -----------------------------------------------------------
 	assert.NoError(t, err)
-	assert.Empty(t, p.latencyExemplarsData[key])
+	assert.Empty(t, p.exemplarsData[key])
+}
+
+func TestProcessorExponentialHistogram(t *testing.T) {
+	// ----- conditions -------------------------------------------------------
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 10}
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+
+	// ----- call -------------------------------------------------------------
+	p.aggregateMetrics(buildSampleTrace())
+	md, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	// ----- verify -----------------------------------------------------------
+	var latencyMetrics, callMetrics int
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		switch m.Name() {
+		case "latency":
+			latencyMetrics++
+			require.Equal(t, pmetric.MetricDataTypeExponentialHistogram, m.DataType())
+			dp := m.ExponentialHistogram().DataPoints().At(0)
+			assert.NotZero(t, dp.Count())
+			assert.LessOrEqual(t, len(dp.Positive().BucketCounts().AsRaw()), 10)
+			assert.Equal(t, int(dp.Count()), dp.Exemplars().Len())
+		case "calls_total":
+			callMetrics++
+			dp := m.Sum().DataPoints().At(0)
+			assert.Equal(t, int(dp.IntVal()), dp.Exemplars().Len())
+			assert.False(t, dp.Exemplars().At(0).TraceID().IsEmpty())
+		}
+	}
+	assert.Equal(t, 3, latencyMetrics)
+	assert.Equal(t, 3, callMetrics)
+}
+
+func TestProcessorEventMetrics(t *testing.T) {
+	// ----- conditions -------------------------------------------------------
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Events = EventsConfig{
+		Enabled:    true,
+		Dimensions: []Dimension{{Name: "exception.type"}},
+	}
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+
+	traces := buildSampleTrace()
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	for _, exceptionType := range []string{"timeout", "timeout", "refused"} {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().InsertString("exception.type", exceptionType)
+	}
+
+	// ----- call -------------------------------------------------------------
+	p.aggregateMetrics(traces)
+	md, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	// ----- verify -----------------------------------------------------------
+	got := make(map[string]int64)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != "events_total" {
+			continue
+		}
+		require.Equal(t, pmetric.MetricDataTypeSum, m.DataType())
+		dp := m.Sum().DataPoints().At(0)
+
+		name, ok := dp.Attributes().Get(eventNameKey)
+		require.True(t, ok)
+		assert.Equal(t, "exception", name.StringVal())
+		serviceName, ok := dp.Attributes().Get(serviceNameKey)
+		require.True(t, ok)
+		assert.Equal(t, "service-a", serviceName.StringVal())
+		exceptionType, ok := dp.Attributes().Get("exception.type")
+		require.True(t, ok)
+		got[exceptionType.StringVal()] = dp.IntVal()
+	}
+	assert.Equal(t, map[string]int64{"timeout": 2, "refused": 1}, got)
+}
+
+func TestProcessorInvalidConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name: "explicit buckets with exponential histogram",
+			modify: func(cfg *Config) {
+				cfg.LatencyHistogramBuckets = []time.Duration{time.Millisecond}
+				cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+			},
+		},
+		{
+			name: "metrics exporter with metrics receiver",
+			modify: func(cfg *Config) {
+				cfg.MetricsExporter = "prometheus"
+				cfg.MetricsReceiver = "spanmetrics"
+			},
+		},
+		{
+			name: "negative exponential histogram max size",
+			modify: func(cfg *Config) {
+				cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: -1}
+			},
+		},
+		{
+			name: "exponential histogram max size of a single bucket",
+			modify: func(cfg *Config) {
+				cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 1}
+			},
+		},
+		{
+			name: "event dimension duplicates the event name",
+			modify: func(cfg *Config) {
+				cfg.Events = EventsConfig{Enabled: true, Dimensions: []Dimension{{Name: eventNameKey}}}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			tc.modify(cfg)
+
+			p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+			assert.Error(t, err)
+			assert.Nil(t, p)
+		})
+	}
 }
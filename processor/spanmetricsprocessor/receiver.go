@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// ReceiverConfig defines the configuration options for the spanmetrics receiver.
+type ReceiverConfig struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// metricsReceivers holds the created spanmetrics receivers, so that a spanmetrics processor can find the receiver
+// named by its metrics_receiver setting when it starts. All receivers are created before any component starts.
+var metricsReceivers = struct {
+	sync.Mutex
+	byID map[config.ComponentID]*metricsReceiver
+}{byID: make(map[config.ComponentID]*metricsReceiver)}
+
+// metricsReceiver feeds the metrics of the spanmetrics processors configured with its name into the metrics
+// pipelines it is part of, so that the metrics go through the processors of those pipelines.
+type metricsReceiver struct {
+	id           config.ComponentID
+	nextConsumer consumer.Metrics
+}
+
+// NewReceiverFactory creates a factory for the spanmetrics receiver, the metrics pipeline entry point of the
+// metrics generated by spanmetrics processors.
+func NewReceiverFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultReceiverConfig,
+		component.WithMetricsReceiverAndStabilityLevel(createMetricsReceiver, stability),
+	)
+}
+
+func createDefaultReceiverConfig() config.Receiver {
+	return &ReceiverConfig{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+	}
+}
+
+func createMetricsReceiver(_ context.Context, _ component.ReceiverCreateSettings, cfg config.Receiver, nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
+	r := &metricsReceiver{
+		id:           cfg.ID(),
+		nextConsumer: nextConsumer,
+	}
+
+	metricsReceivers.Lock()
+	defer metricsReceivers.Unlock()
+	metricsReceivers.byID[r.id] = r
+	return r, nil
+}
+
+// findMetricsReceiver returns the spanmetrics receiver with the given id.
+func findMetricsReceiver(id config.ComponentID) (*metricsReceiver, error) {
+	metricsReceivers.Lock()
+	defer metricsReceivers.Unlock()
+	r, ok := metricsReceivers.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to find metrics receiver: '%s'; please add it to a metrics pipeline", id)
+	}
+	return r, nil
+}
+
+// Start implements the component.Component interface.
+func (r *metricsReceiver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (r *metricsReceiver) Shutdown(context.Context) error {
+	metricsReceivers.Lock()
+	defer metricsReceivers.Unlock()
+	// A receiver with the same name may already have been created for a new configuration.
+	if metricsReceivers.byID[r.id] == r {
+		delete(metricsReceivers.byID, r.id)
+	}
+	return nil
+}
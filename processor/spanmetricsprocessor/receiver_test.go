@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateMetricsReceiver(t *testing.T) {
+	// Prepare
+	factory := NewReceiverFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, config.NewComponentID(typeStr), cfg.ID())
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+
+	// Test
+	sink := new(consumertest.MetricsSink)
+	r, err := factory.CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Verify
+	found, err := findMetricsReceiver(cfg.ID())
+	require.NoError(t, err)
+	assert.Equal(t, sink, found.nextConsumer)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	_, err = findMetricsReceiver(cfg.ID())
+	assert.EqualError(t, err, "failed to find metrics receiver: 'spanmetrics'; please add it to a metrics pipeline")
+}
+
+func TestMetricsReceiverShutdownKeepsNewerReceiver(t *testing.T) {
+	// Prepare
+	factory := NewReceiverFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := componenttest.NewNopReceiverCreateSettings()
+
+	oldReceiver, err := factory.CreateMetricsReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	newReceiver, err := factory.CreateMetricsReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Test
+	require.NoError(t, oldReceiver.Shutdown(context.Background()))
+
+	// Verify
+	found, err := findMetricsReceiver(cfg.ID())
+	require.NoError(t, err)
+	assert.Equal(t, newReceiver, found)
+	require.NoError(t, newReceiver.Shutdown(context.Background()))
+}
@@ -26,7 +26,13 @@ func getNewAndPolicy(logger *zap.Logger, config AndCfg) (sampling.PolicyEvaluato
 	var subPolicyEvaluators []sampling.PolicyEvaluator
 	for i := range config.SubPolicyCfg {
 		policyCfg := config.SubPolicyCfg[i]
-		policy, _ := getAndSubPolicyEvaluator(logger, &policyCfg)
+		policy, err := getAndSubPolicyEvaluator(logger, &policyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create and sub-policy %q: %w", policyCfg.Name, err)
+		}
+		if policyCfg.InvertMatch {
+			policy = sampling.NewInvert(policy)
+		}
 		subPolicyEvaluators = append(subPolicyEvaluators, policy)
 	}
 	return sampling.NewAnd(logger, subPolicyEvaluators), nil
@@ -54,6 +60,8 @@ func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		return getNewTQLConditionPolicy(logger, cfg.TQLConditionCfg)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
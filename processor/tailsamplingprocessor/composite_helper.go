@@ -27,7 +27,13 @@ func getNewCompositePolicy(logger *zap.Logger, config CompositeCfg) (sampling.Po
 	rateAllocationsMap := getRateAllocationMap(config)
 	for i := range config.SubPolicyCfg {
 		policyCfg := config.SubPolicyCfg[i]
-		policy, _ := getSubPolicyEvaluator(logger, &policyCfg)
+		policy, err := getSubPolicyEvaluator(logger, &policyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create composite sub-policy %q: %w", policyCfg.Name, err)
+		}
+		if policyCfg.InvertMatch {
+			policy = sampling.NewInvert(policy)
+		}
 
 		evalParams := sampling.SubPolicyEvalParams{
 			Evaluator:         policy,
@@ -85,6 +91,8 @@ func getSubPolicyEvaluator(logger *zap.Logger, cfg *SubPolicyCfg) (sampling.Poli
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		return getNewTQLConditionPolicy(logger, cfg.TQLConditionCfg)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
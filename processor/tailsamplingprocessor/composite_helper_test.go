@@ -65,14 +65,16 @@ func TestCompositeHelper(t *testing.T) {
 						{
 							Name:          "test-composite-policy-6",
 							Type:          StatusCode,
-							StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"200", "404"}},
+							StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"OK", "ERROR"}},
 						},
 						{
 							Name: "test-composite-policy-7",
 							Type: AlwaysSample,
 						},
 						{
-							Name: "test-composite-policy-8",
+							Name:         "test-composite-policy-8",
+							Type:         SpanCount,
+							SpanCountCfg: SpanCountCfg{MinSpans: 2},
 						},
 					},
 					RateAllocation: []RateAllocationCfg{
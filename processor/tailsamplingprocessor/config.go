@@ -48,6 +48,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// TQLCondition sample traces with spans or span events matching TQL conditions.
+	TQLCondition PolicyType = "tql_condition"
 )
 
 // SubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -74,6 +76,11 @@ type SubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state policy evaluator.
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for TQL condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
+	// InvertMatch inverts the decision of the policy: traces matched by the policy are not sampled, regardless of
+	// the decisions of the other policies, and all other traces are sampled.
+	InvertMatch bool `mapstructure:"invert_match"`
 }
 
 type AndSubPolicyCfg struct {
@@ -97,6 +104,11 @@ type AndSubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state filter sampling policy evaluator
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for TQL condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
+	// InvertMatch inverts the decision of the policy: traces matched by the policy are not sampled, regardless of
+	// the decisions of the other policies, and all other traces are sampled.
+	InvertMatch bool `mapstructure:"invert_match"`
 }
 
 type TraceStateCfg struct {
@@ -151,6 +163,11 @@ type PolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for TQL condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
+	// InvertMatch inverts the decision of the policy: traces matched by the policy are not sampled, regardless of
+	// the decisions of the other policies, and all other traces are sampled.
+	InvertMatch bool `mapstructure:"invert_match"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -209,6 +226,19 @@ type StringAttributeCfg struct {
 	InvertMatch bool `mapstructure:"invert_match"`
 }
 
+// TQLConditionCfg holds the configurable settings to create a TQL condition filter
+// sampling policy evaluator.
+type TQLConditionCfg struct {
+	// SpanConditions are the TQL conditions evaluated on each span.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are the TQL conditions evaluated on each span event. A span matches when any of
+	// its events matches.
+	SpanEventConditions []string `mapstructure:"span_event"`
+	// Match is either "any" to sample traces with any matching span, or "all" to sample traces whose spans all match.
+	// Defaults to "any".
+	Match string `mapstructure:"match"`
+}
+
 // RateLimitingCfg holds the configurable settings to create a rate limiting
 // sampling policy evaluator.
 type RateLimitingCfg struct {
@@ -238,4 +268,19 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache keeps the sampling decisions of the traces released from memory, so that spans
+	// arriving after the decision follow it instead of being evaluated as a new trace.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
+}
+
+// DecisionCacheCfg holds the configurable settings of the caches of sampling decisions.
+type DecisionCacheCfg struct {
+	// SampledCacheSize is the number of trace IDs of sampled traces kept in the cache.
+	// Defaults to zero, i.e.: no cache.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the number of trace IDs of not sampled traces kept in the cache.
+	// Defaults to zero, i.e.: no cache.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// StorageID is the ID of the storage extension persisting the caches across restarts. Optional.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
@@ -43,6 +43,10 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheCfg{
+				SampledCacheSize:    1000,
+				NonSampledCacheSize: 5000,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -88,6 +92,21 @@ func TestLoadConfig(t *testing.T) {
 					Type:          TraceState,
 					TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 				},
+				{
+					Name: "test-policy-10",
+					Type: TQLCondition,
+					TQLConditionCfg: TQLConditionCfg{
+						SpanConditions:      []string{`attributes["http.status_code"] == 500`},
+						SpanEventConditions: []string{`name == "exception"`},
+						Match:               "any",
+					},
+				},
+				{
+					Name:               "test-policy-11",
+					Type:               StringAttribute,
+					StringAttributeCfg: StringAttributeCfg{Key: "http.route", Values: []string{"/health"}},
+					InvertMatch:        true,
+				},
 				{
 					Name: "and-policy-1",
 					Type: And,
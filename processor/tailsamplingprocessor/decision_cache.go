@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	// sampledIDsKey is the storage key of the trace IDs of the sampled traces cache.
+	sampledIDsKey = "sampled_trace_ids"
+	// nonSampledIDsKey is the storage key of the trace IDs of the not sampled traces cache.
+	nonSampledIDsKey = "non_sampled_trace_ids"
+)
+
+// newDecisionCaches creates the caches of sampled and not sampled trace IDs, which are nil when their size is zero.
+func newDecisionCaches(cfg DecisionCacheCfg) (sampled *cache.Cache, nonSampled *cache.Cache, err error) {
+	if cfg.SampledCacheSize < 0 || cfg.NonSampledCacheSize < 0 {
+		return nil, nil, fmt.Errorf("invalid decision cache sizes: %d, %d, the sizes should not be negative",
+			cfg.SampledCacheSize, cfg.NonSampledCacheSize)
+	}
+	if cfg.SampledCacheSize > 0 {
+		if sampled, err = cache.New(cfg.SampledCacheSize); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cfg.NonSampledCacheSize > 0 {
+		if nonSampled, err = cache.New(cfg.NonSampledCacheSize); err != nil {
+			return nil, nil, err
+		}
+	}
+	return sampled, nonSampled, nil
+}
+
+// cacheDecision keeps the final decision of the trace once the trace is released from memory.
+func (tsp *tailSamplingSpanProcessor) cacheDecision(id pcommon.TraceID, decision sampling.Decision) {
+	switch {
+	case decision == sampling.Sampled && tsp.sampledIDCache != nil:
+		tsp.sampledIDCache.Put(id)
+	case decision == sampling.NotSampled && tsp.nonSampledIDCache != nil:
+		tsp.nonSampledIDCache.Put(id)
+	}
+}
+
+// followCachedDecision forwards the spans of a sampled trace and drops the spans of a not sampled trace,
+// according to the decision caches. It returns false when no decision was cached for the trace.
+func (tsp *tailSamplingSpanProcessor) followCachedDecision(id pcommon.TraceID, resourceSpans ptrace.ResourceSpans, spans []*ptrace.Span) bool {
+	if tsp.sampledIDCache != nil && tsp.sampledIDCache.Contains(id) {
+		if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, prepareTraceBatch(resourceSpans, spans)); err != nil {
+			tsp.logger.Warn("Error sending late arrived spans of a sampled trace to destination", zap.Error(err))
+		}
+		return true
+	}
+	return tsp.nonSampledIDCache != nil && tsp.nonSampledIDCache.Contains(id)
+}
+
+// loadDecisionCaches gets the client of the configured storage extension and restores the decision caches
+// persisted by the previous run.
+func (tsp *tailSamplingSpanProcessor) loadDecisionCaches(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[*tsp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", tsp.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", tsp.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, tsp.id, "")
+	if err != nil {
+		return err
+	}
+	tsp.storageClient = client
+
+	for key, c := range map[string]*cache.Cache{sampledIDsKey: tsp.sampledIDCache, nonSampledIDsKey: tsp.nonSampledIDCache} {
+		if c == nil {
+			continue
+		}
+		data, err := client.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if err = c.UnmarshalBinary(data); err != nil {
+			tsp.logger.Warn("Ignoring invalid persisted decision cache", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// persistDecisionCaches saves the decision caches in the storage and closes the storage client.
+func (tsp *tailSamplingSpanProcessor) persistDecisionCaches(ctx context.Context) error {
+	var ops []storage.Operation
+	for key, c := range map[string]*cache.Cache{sampledIDsKey: tsp.sampledIDCache, nonSampledIDsKey: tsp.nonSampledIDCache} {
+		if c == nil {
+			ops = append(ops, storage.DeleteOperation(key))
+			continue
+		}
+		data, err := c.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		ops = append(ops, storage.SetOperation(key, data))
+	}
+	return multierr.Append(tsp.storageClient.Batch(ctx, ops...), tsp.storageClient.Close(ctx))
+}
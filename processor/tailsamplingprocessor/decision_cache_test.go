@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestLateSpansFollowCachedDecision(t *testing.T) {
+	const maxSize = 1
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	sampledIDCache, nonSampledIDCache, err := newDecisionCaches(DecisionCacheCfg{SampledCacheSize: 10, NonSampledCacheSize: 10})
+	require.NoError(t, err)
+	tsp := &tailSamplingSpanProcessor{
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(1),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      &manualTTicker{},
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    atomic.NewUint64(0),
+		sampledIDCache:    sampledIDCache,
+		nonSampledIDCache: nonSampledIDCache,
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	sampledID := pcommon.NewTraceID([16]byte{1})
+	nonSampledID := pcommon.NewTraceID([16]byte{2})
+
+	// Sample the first trace.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.samplingPolicyOnTick()
+	mpe.NextDecision = sampling.Sampled
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, msp.SpanCount())
+
+	// Don't sample the second trace, which releases the first one from memory.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(nonSampledID)))
+	_, ok := tsp.idToTrace.Load(sampledID)
+	require.False(t, ok, "the sampled trace should have been released from memory")
+	mpe.NextDecision = sampling.NotSampled
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 2, mpe.EvaluationCount)
+
+	// Late spans follow the cached decisions without being evaluated again.
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(nonSampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	assert.Equal(t, 2, msp.SpanCount())
+	assert.Equal(t, 2, mpe.EvaluationCount)
+	_, ok = tsp.idToTrace.Load(sampledID)
+	assert.False(t, ok, "late spans of a cached trace should not be kept in memory")
+}
+
+func TestDecisionCachesArePersisted(t *testing.T) {
+	storageID := config.NewComponentID("memory")
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{storageID: &memoryStorage{data: map[string][]byte{}}},
+	}
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:      time.Second,
+		NumTraces:         10,
+		DecisionCache: DecisionCacheCfg{
+			SampledCacheSize:    10,
+			NonSampledCacheSize: 10,
+			StorageID:           &storageID,
+		},
+	}
+	sampledID := pcommon.NewTraceID([16]byte{1})
+	nonSampledID := pcommon.NewTraceID([16]byte{2})
+
+	first, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(context.Background(), host))
+	first.(*tailSamplingSpanProcessor).cacheDecision(sampledID, sampling.Sampled)
+	first.(*tailSamplingSpanProcessor).cacheDecision(nonSampledID, sampling.NotSampled)
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	second, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, second.Shutdown(context.Background()))
+	}()
+
+	tsp := second.(*tailSamplingSpanProcessor)
+	assert.True(t, tsp.sampledIDCache.Contains(sampledID))
+	assert.False(t, tsp.sampledIDCache.Contains(nonSampledID))
+	assert.True(t, tsp.nonSampledIDCache.Contains(nonSampledID))
+}
+
+func TestDecisionCacheStorageNotFound(t *testing.T) {
+	storageID := config.NewComponentID("missing")
+	tsp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), Config{
+		DecisionWait:  time.Second,
+		NumTraces:     10,
+		DecisionCache: DecisionCacheCfg{SampledCacheSize: 10, StorageID: &storageID},
+	})
+	require.NoError(t, err)
+	assert.EqualError(t, tsp.Start(context.Background(), componenttest.NewNopHost()), `storage extension "missing" not found`)
+	tsp.(*tailSamplingSpanProcessor).decisionBatcher.Stop()
+}
+
+func TestNewDecisionCachesInvalidSize(t *testing.T) {
+	_, _, err := newDecisionCaches(DecisionCacheCfg{SampledCacheSize: -1})
+	assert.Error(t, err)
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// memoryStorage is a storage extension keeping the data of its clients in memory.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return m, nil
+}
+
+func (m *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	return m.data[key], nil
+}
+
+func (m *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryStorage) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = m.Get(ctx, op.Key)
+		case storage.Set:
+			err = m.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = m.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStorage) Close(context.Context) error {
+	return nil
+}
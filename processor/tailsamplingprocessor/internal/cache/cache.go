@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache implements the LRU caches of trace IDs that keep the sampling decisions
+// of the traces released from memory.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const traceIDSize = 16
+
+// Cache is a fixed size set of trace IDs evicting the least recently used trace ID when it is full.
+// It is safe for concurrent use.
+type Cache struct {
+	mu   sync.Mutex
+	size int
+	ll   *list.List
+	ids  map[pcommon.TraceID]*list.Element
+}
+
+// New creates a Cache keeping at most size trace IDs.
+func New(size int) (*Cache, error) {
+	if size <= 0 {
+		return nil, errors.New("cache size must be positive")
+	}
+	return &Cache{
+		size: size,
+		ll:   list.New(),
+		ids:  make(map[pcommon.TraceID]*list.Element, size),
+	}, nil
+}
+
+// Put adds the trace ID to the cache, marking it as the most recently used.
+func (c *Cache) Put(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.ids[id]; ok {
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.ids[id] = c.ll.PushFront(id)
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.ids, oldest.Value.(pcommon.TraceID))
+	}
+}
+
+// Contains returns whether the trace ID is in the cache, marking it as the most recently used.
+func (c *Cache) Contains(id pcommon.TraceID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.ids[id]
+	if ok {
+		c.ll.MoveToFront(e)
+	}
+	return ok
+}
+
+// Len returns the number of trace IDs in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// MarshalBinary encodes the trace IDs of the cache, from the least to the most recently used.
+func (c *Cache) MarshalBinary() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := make([]byte, 0, c.ll.Len()*traceIDSize)
+	for e := c.ll.Back(); e != nil; e = e.Prev() {
+		id := e.Value.(pcommon.TraceID).Bytes()
+		data = append(data, id[:]...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary adds the trace IDs encoded by MarshalBinary to the cache, keeping their order of use.
+func (c *Cache) UnmarshalBinary(data []byte) error {
+	if len(data)%traceIDSize != 0 {
+		return errors.New("invalid trace IDs encoding")
+	}
+	for i := 0; i < len(data); i += traceIDSize {
+		var id [traceIDSize]byte
+		copy(id[:], data[i:i+traceIDSize])
+		c.Put(pcommon.NewTraceID(id))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c, err := New(2)
+	require.NoError(t, err)
+
+	id1 := pcommon.NewTraceID([16]byte{1})
+	id2 := pcommon.NewTraceID([16]byte{2})
+	id3 := pcommon.NewTraceID([16]byte{3})
+
+	c.Put(id1)
+	c.Put(id2)
+	// Using id1 makes id2 the least recently used trace ID.
+	assert.True(t, c.Contains(id1))
+	c.Put(id3)
+
+	assert.Equal(t, 2, c.Len())
+	assert.True(t, c.Contains(id1))
+	assert.False(t, c.Contains(id2))
+	assert.True(t, c.Contains(id3))
+}
+
+func TestCacheMarshalBinary(t *testing.T) {
+	c, err := New(2)
+	require.NoError(t, err)
+	id1 := pcommon.NewTraceID([16]byte{1})
+	id2 := pcommon.NewTraceID([16]byte{2})
+	c.Put(id1)
+	c.Put(id2)
+
+	data, err := c.MarshalBinary()
+	require.NoError(t, err)
+
+	restored, err := New(2)
+	require.NoError(t, err)
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, 2, restored.Len())
+
+	// The order of use is kept, so id1 is still the least recently used trace ID.
+	restored.Put(pcommon.NewTraceID([16]byte{3}))
+	assert.False(t, restored.Contains(id1))
+	assert.True(t, restored.Contains(id2))
+}
+
+func TestCacheUnmarshalBinaryInvalid(t *testing.T) {
+	c, err := New(1)
+	require.NoError(t, err)
+	assert.Error(t, c.UnmarshalBinary([]byte{1, 2, 3}))
+	assert.NoError(t, c.UnmarshalBinary(nil))
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestNewInvalidSize(t *testing.T) {
+	_, err := New(0)
+	assert.Error(t, err)
+}
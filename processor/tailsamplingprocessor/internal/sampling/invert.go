@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type invert struct {
+	evaluator PolicyEvaluator
+}
+
+var _ PolicyEvaluator = (*invert)(nil)
+
+// NewInvert creates a policy evaluator that inverts the decisions of the given evaluator.
+// As for the invert match of the string attribute filter, a trace sampled by the given evaluator is
+// InvertNotSampled, which takes precedence over the decisions of the other policies, and any other
+// trace is InvertSampled.
+func NewInvert(evaluator PolicyEvaluator) PolicyEvaluator {
+	return &invert{
+		evaluator: evaluator,
+	}
+}
+
+// Evaluate looks at the trace data and returns the inverted decision of the wrapped evaluator.
+func (i *invert) Evaluate(traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, err := i.evaluator.Evaluate(traceID, trace)
+	if err != nil {
+		return decision, err
+	}
+
+	switch decision {
+	case Sampled, InvertSampled:
+		return InvertNotSampled, nil
+	case NotSampled, InvertNotSampled:
+		return InvertSampled, nil
+	}
+	return decision, nil
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type staticEvaluator struct {
+	decision Decision
+	err      error
+}
+
+func (e staticEvaluator) Evaluate(pcommon.TraceID, *TraceData) (Decision, error) {
+	return e.decision, e.err
+}
+
+func TestEvaluate_Invert(t *testing.T) {
+	cases := []struct {
+		Decision         Decision
+		InvertedDecision Decision
+	}{
+		{Sampled, InvertNotSampled},
+		{InvertSampled, InvertNotSampled},
+		{NotSampled, InvertSampled},
+		{InvertNotSampled, InvertSampled},
+		{Unspecified, Unspecified},
+		{Dropped, Dropped},
+	}
+
+	for _, c := range cases {
+		decision, err := NewInvert(staticEvaluator{decision: c.Decision}).Evaluate(newTraceID(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, c.InvertedDecision, decision)
+	}
+}
+
+func TestEvaluate_InvertError(t *testing.T) {
+	expectedErr := errors.New("evaluation failed")
+	decision, err := NewInvert(staticEvaluator{decision: Error, err: expectedErr}).Evaluate(newTraceID(), nil)
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, Error, decision)
+}
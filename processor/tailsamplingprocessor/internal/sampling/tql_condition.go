@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type tqlConditionFilter struct {
+	logger              *zap.Logger
+	spanConditions      []tql.BoolExpressionEvaluator
+	spanEventConditions []tql.BoolExpressionEvaluator
+	matchAll            bool
+}
+
+var _ PolicyEvaluator = (*tqlConditionFilter)(nil)
+
+// NewTQLConditionFilter creates a policy evaluator that samples traces based on TQL conditions.
+// A span matches when it matches any of the span conditions, or when any of its events matches any of the span
+// event conditions. Traces are sampled when any of their spans matches, or when all of them match if matchAll is set.
+func NewTQLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string, matchAll bool) (PolicyEvaluator, error) {
+	spans, err := tql.ParseConditions(spanConditions, tqlotel.Converters(), tqltraces.ParsePath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, err
+	}
+	spanEvents, err := tql.ParseConditions(spanEventConditions, tqlotel.Converters(), tqlspanevents.ParsePath, tqlspanevents.ParseEnum)
+	if err != nil {
+		return nil, err
+	}
+	return &tqlConditionFilter{
+		logger:              logger,
+		spanConditions:      spans,
+		spanEventConditions: spanEvents,
+		matchAll:            matchAll,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (tcf *tqlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	tcf.logger.Debug("Evaluating spans with TQL conditions filter")
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	var spanCount int
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				for k := 0; k < ils.Spans().Len(); k++ {
+					spanCount++
+					matches, err := tcf.matchesSpan(ils.Spans().At(k), ils.Scope(), rs.Resource())
+					if err != nil {
+						return Error, err
+					}
+					if matches && !tcf.matchAll {
+						return Sampled, nil
+					}
+					if !matches && tcf.matchAll {
+						return NotSampled, nil
+					}
+				}
+			}
+		}
+	}
+
+	if tcf.matchAll && spanCount > 0 {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (tcf *tqlConditionFilter) matchesSpan(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) (bool, error) {
+	if len(tcf.spanConditions) > 0 {
+		matches, err := matchesAnyCondition(tcf.spanConditions, tqltraces.NewTransformContext(span, il, resource))
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+
+	for i := 0; i < span.Events().Len() && len(tcf.spanEventConditions) > 0; i++ {
+		matches, err := matchesAnyCondition(tcf.spanEventConditions, tqlspanevents.NewTransformContext(span.Events().At(i), span, il, resource))
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+// matchesAnyCondition returns true as soon as one of the conditions is true for ctx.
+func matchesAnyCondition(conditions []tql.BoolExpressionEvaluator, ctx tql.TransformContext) (bool, error) {
+	for _, condition := range conditions {
+		matches, err := condition(ctx)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+func TestEvaluate_TQLCondition(t *testing.T) {
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		MatchAll            bool
+		Decision            Decision
+	}{
+		{
+			Desc:           "any span matches",
+			SpanConditions: []string{`name == "operationB"`},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "no span matches",
+			SpanConditions: []string{`name == "operationC"`},
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "not all spans match",
+			SpanConditions: []string{`name == "operationB"`},
+			MatchAll:       true,
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "all spans match",
+			SpanConditions: []string{`resource.attributes["service.name"] == "frontend"`},
+			MatchAll:       true,
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "span event matches",
+			SpanEventConditions: []string{`name == "exception" and span.kind == SPAN_KIND_SERVER`},
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "spans match either a span or a span event condition",
+			SpanConditions:      []string{`name == "operationB"`},
+			SpanEventConditions: []string{`attributes["exception.type"] == "timeout"`},
+			MatchAll:            true,
+			Decision:            Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewTQLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions, c.MatchAll)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(traceID, newTraceForTQLConditions())
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestEvaluate_TQLConditionWithoutSpans(t *testing.T) {
+	filter, err := NewTQLConditionFilter(zap.NewNop(), []string{`name == "operationA"`}, nil, true)
+	require.NoError(t, err)
+
+	decision, err := filter.Evaluate(pcommon.NewTraceID([16]byte{1}), &TraceData{SpanCount: atomic.NewInt64(0)})
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestNewTQLConditionFilterInvalidCondition(t *testing.T) {
+	_, err := NewTQLConditionFilter(zap.NewNop(), []string{`unknown_path == "operationA"`}, nil, false)
+	assert.Error(t, err)
+
+	_, err = NewTQLConditionFilter(zap.NewNop(), nil, []string{`name ==`}, false)
+	assert.Error(t, err)
+}
+
+func newTraceForTQLConditions() *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "frontend")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	spanA := spans.AppendEmpty()
+	spanA.SetName("operationA")
+	spanA.SetKind(ptrace.SpanKindServer)
+	event := spanA.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().InsertString("exception.type", "timeout")
+
+	spanB := spans.AppendEmpty()
+	spanB.SetName("operationB")
+	spanB.SetKind(ptrace.SpanKindClient)
+
+	return &TraceData{
+		SpanCount:       atomic.NewInt64(2),
+		ReceivedBatches: []ptrace.Traces{traces},
+	}
+}
@@ -24,13 +24,16 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -60,6 +63,16 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	// id is the ID of the processor, used to get its storage client.
+	id config.ComponentID
+	// sampledIDCache and nonSampledIDCache keep the decisions of the traces released from memory.
+	// They are nil when disabled.
+	sampledIDCache    *cache.Cache
+	nonSampledIDCache *cache.Cache
+	// storageID is the ID of the storage extension persisting the decision caches, if any.
+	storageID     *config.ComponentID
+	storageClient storage.Client
 }
 
 const (
@@ -73,12 +86,6 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		return nil, component.ErrNilNextConsumer
 	}
 
-	numDecisionBatches := uint64(cfg.DecisionWait.Seconds())
-	inBatcher, err := idbatcher.New(numDecisionBatches, cfg.ExpectedNewTracesPerSec, uint64(2*runtime.NumCPU()))
-	if err != nil {
-		return nil, err
-	}
-
 	ctx := context.Background()
 	var policies []*policy
 	for i := range cfg.PolicyCfgs {
@@ -91,6 +98,9 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		if err != nil {
 			return nil, err
 		}
+		if policyCfg.InvertMatch {
+			eval = sampling.NewInvert(eval)
+		}
 		p := &policy{
 			name:      policyCfg.Name,
 			evaluator: eval,
@@ -99,6 +109,18 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies = append(policies, p)
 	}
 
+	sampledIDCache, nonSampledIDCache, err := newDecisionCaches(cfg.DecisionCache)
+	if err != nil {
+		return nil, err
+	}
+
+	// The batcher starts a goroutine, so it is only created once the configuration is known to be valid
+	numDecisionBatches := uint64(cfg.DecisionWait.Seconds())
+	inBatcher, err := idbatcher.New(numDecisionBatches, cfg.ExpectedNewTracesPerSec, uint64(2*runtime.NumCPU()))
+	if err != nil {
+		return nil, err
+	}
+
 	tsp := &tailSamplingSpanProcessor{
 		ctx:             ctx,
 		nextConsumer:    nextConsumer,
@@ -108,6 +130,11 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		id:                cfg.ID(),
+		sampledIDCache:    sampledIDCache,
+		nonSampledIDCache: nonSampledIDCache,
+		storageID:         cfg.DecisionCache.StorageID,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -150,6 +177,8 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		return getNewTQLConditionPolicy(logger, cfg.TQLConditionCfg)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -178,6 +207,8 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
 
 		// Sampled or not, remove the batches
+		tsp.cacheDecision(id, decision)
+
 		trace.Lock()
 		traceBatches := trace.ReceivedBatches
 		trace.ReceivedBatches = nil
@@ -325,6 +356,10 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		if tsp.followCachedDecision(id, resourceSpans, spans) {
+			continue
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -408,15 +443,23 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		if err := tsp.loadDecisionCaches(ctx, host); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.storageClient != nil {
+		return tsp.persistDecisionCaches(ctx)
+	}
 	return nil
 }
 
@@ -0,0 +1,41 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	// tqlConditionMatchAny samples traces with any span matching the TQL conditions.
+	tqlConditionMatchAny = "any"
+	// tqlConditionMatchAll samples traces with all spans matching the TQL conditions.
+	tqlConditionMatchAll = "all"
+)
+
+func getNewTQLConditionPolicy(logger *zap.Logger, config TQLConditionCfg) (sampling.PolicyEvaluator, error) {
+	switch config.Match {
+	case "", tqlConditionMatchAny:
+		return sampling.NewTQLConditionFilter(logger, config.SpanConditions, config.SpanEventConditions, false)
+	case tqlConditionMatchAll:
+		return sampling.NewTQLConditionFilter(logger, config.SpanConditions, config.SpanEventConditions, true)
+	default:
+		return nil, fmt.Errorf("unknown tql_condition match %q, expected %q or %q", config.Match, tqlConditionMatchAny, tqlConditionMatchAll)
+	}
+}
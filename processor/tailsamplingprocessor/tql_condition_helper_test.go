@@ -0,0 +1,139 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestTQLConditionHelper(t *testing.T) {
+	for _, match := range []string{"", "any", "all"} {
+		policy, err := getNewTQLConditionPolicy(zap.NewNop(), TQLConditionCfg{
+			SpanConditions: []string{`name == "operation"`},
+			Match:          match,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, policy)
+	}
+
+	_, err := getNewTQLConditionPolicy(zap.NewNop(), TQLConditionCfg{
+		SpanConditions: []string{`name == "operation"`},
+		Match:          "most",
+	})
+	assert.EqualError(t, err, `unknown tql_condition match "most", expected "any" or "all"`)
+}
+
+func TestInvalidTQLConditionSubPolicy(t *testing.T) {
+	invalid := TQLConditionCfg{SpanConditions: []string{`name ==`}}
+
+	_, err := getNewAndPolicy(zap.NewNop(), AndCfg{SubPolicyCfg: []AndSubPolicyCfg{
+		{Name: "always", Type: AlwaysSample},
+		{Name: "invalid", Type: TQLCondition, TQLConditionCfg: invalid},
+	}})
+	assert.ErrorContains(t, err, `failed to create and sub-policy "invalid"`)
+
+	_, err = getNewCompositePolicy(zap.NewNop(), CompositeCfg{
+		MaxTotalSpansPerSecond: 100,
+		SubPolicyCfg: []SubPolicyCfg{
+			{Name: "invalid", Type: TQLCondition, TQLConditionCfg: invalid},
+		},
+	})
+	assert.ErrorContains(t, err, `failed to create composite sub-policy "invalid"`)
+
+	_, err = newTracesProcessor(zap.NewNop(), consumertest.NewNop(), Config{
+		DecisionWait: time.Second,
+		NumTraces:    1,
+		PolicyCfgs: []PolicyCfg{
+			{
+				Name: "and",
+				Type: And,
+				AndCfg: AndCfg{SubPolicyCfg: []AndSubPolicyCfg{
+					{Name: "invalid", Type: TQLCondition, TQLConditionCfg: invalid},
+				}},
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestInvertMatchPolicies(t *testing.T) {
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("/health")
+	trace := &sampling.TraceData{
+		SpanCount:       atomic.NewInt64(1),
+		ReceivedBatches: []ptrace.Traces{traces},
+	}
+	healthCheck := TQLConditionCfg{SpanConditions: []string{`name == "/health"`}}
+
+	cfg := Config{
+		PolicyCfgs: []PolicyCfg{
+			{
+				Name:            "not-health-check",
+				Type:            TQLCondition,
+				TQLConditionCfg: healthCheck,
+				InvertMatch:     true,
+			},
+			{
+				Name: "and",
+				Type: And,
+				AndCfg: AndCfg{SubPolicyCfg: []AndSubPolicyCfg{
+					{Name: "always", Type: AlwaysSample},
+					{Name: "not-health-check", Type: TQLCondition, TQLConditionCfg: healthCheck, InvertMatch: true},
+				}},
+			},
+			{
+				Name: "composite",
+				Type: Composite,
+				CompositeCfg: CompositeCfg{
+					MaxTotalSpansPerSecond: 100,
+					SubPolicyCfg: []SubPolicyCfg{
+						{Name: "not-health-check", Type: TQLCondition, TQLConditionCfg: healthCheck, InvertMatch: true},
+					},
+					RateAllocation: []RateAllocationCfg{{Policy: "not-health-check", Percent: 100}},
+				},
+			},
+		},
+	}
+
+	want := []sampling.Decision{sampling.InvertNotSampled, sampling.NotSampled, sampling.NotSampled}
+	for i := range cfg.PolicyCfgs {
+		processor, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), Config{
+			DecisionWait: time.Second,
+			NumTraces:    1,
+			PolicyCfgs:   cfg.PolicyCfgs[i : i+1],
+		})
+		require.NoError(t, err)
+		require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, processor.Shutdown(context.Background()))
+		policy := processor.(*tailSamplingSpanProcessor).policies[0]
+
+		decision, err := policy.evaluator.Evaluate(pcommon.NewTraceID([16]byte{1}), trace)
+		require.NoError(t, err)
+		assert.Equal(t, want[i], decision, cfg.PolicyCfgs[i].Name)
+	}
+}
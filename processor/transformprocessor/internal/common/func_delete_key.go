@@ -21,15 +21,18 @@ import (
 )
 
 func deleteKey(target tql.Getter, key string) (tql.ExprFunc, error) {
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 
 		if attrs, ok := val.(pcommon.Map); ok {
 			attrs.Remove(key)
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
@@ -31,8 +31,8 @@ func Test_deleteKey(t *testing.T) {
 	input.InsertBool("test3", true)
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
 	}
 
@@ -84,7 +84,8 @@ func Test_deleteKey(t *testing.T) {
 			}
 
 			exprFunc, _ := deleteKey(tt.target, tt.key)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewMap()
 			tt.want(expected)
@@ -101,18 +102,20 @@ func Test_deleteKey_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	key := "anything"
 
 	exprFunc, _ := deleteKey(target, key)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueString("not a map"), input)
 }
@@ -123,16 +126,18 @@ func Test_deleteKey_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	key := "anything"
 
 	exprFunc, _ := deleteKey(target, key)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
@@ -28,10 +28,13 @@ func deleteMatchingKeys(target tql.Getter, pattern string) (tql.ExprFunc, error)
 	if err != nil {
 		return nil, fmt.Errorf("the regex pattern supplied to delete_matching_keys is not a valid pattern: %w", err)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 
 		if attrs, ok := val.(pcommon.Map); ok {
@@ -39,6 +42,6 @@ func deleteMatchingKeys(target tql.Getter, pattern string) (tql.ExprFunc, error)
 				return compiledPattern.MatchString(key)
 			})
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
@@ -31,8 +31,8 @@ func Test_deleteMatchingKeys(t *testing.T) {
 	input.InsertBool("test3", true)
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
 	}
 
@@ -82,7 +82,8 @@ func Test_deleteMatchingKeys(t *testing.T) {
 			}
 
 			exprFunc, _ := deleteMatchingKeys(tt.target, tt.pattern)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewMap()
 			tt.want(expected)
@@ -99,14 +100,15 @@ func Test_deleteMatchingKeys_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
 	}
 
 	exprFunc, err := deleteMatchingKeys(target, "anything")
 	assert.Nil(t, err)
-	exprFunc(ctx)
+	_, err = exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueInt(1), input)
 }
@@ -117,20 +119,21 @@ func Test_deleteMatchingKeys_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
 	}
 
 	exprFunc, _ := deleteMatchingKeys(target, "anything")
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
 
 func Test_deleteMatchingKeys_invalid_pattern(t *testing.T) {
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			t.Errorf("nothing should be received in this scenario")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -26,10 +26,13 @@ func keepKeys(target tql.GetSetter, keys []string) (tql.ExprFunc, error) {
 		keySet[key] = struct{}{}
 	}
 
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 
 		if attrs, ok := val.(pcommon.Map); ok {
@@ -42,8 +45,10 @@ func keepKeys(target tql.GetSetter, keys []string) (tql.ExprFunc, error) {
 				}
 				return true
 			})
-			target.Set(ctx, filtered)
+			if err = target.Set(ctx, filtered); err != nil {
+				return nil, err
+			}
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
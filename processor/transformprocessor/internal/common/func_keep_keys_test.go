@@ -31,12 +31,13 @@ func Test_keepKeys(t *testing.T) {
 	input.InsertBool("test3", true)
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Map).Clear()
 			val.(pcommon.Map).CopyTo(ctx.GetItem().(pcommon.Map))
+			return nil
 		},
 	}
 
@@ -100,7 +101,8 @@ func Test_keepKeys(t *testing.T) {
 			}
 
 			exprFunc, _ := keepKeys(tt.target, tt.keys)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewMap()
 			tt.want(expected)
@@ -117,18 +119,20 @@ func Test_keepKeys_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	keys := []string{"anything"}
 
 	exprFunc, _ := keepKeys(target, keys)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueString("not a map"), input)
 }
@@ -139,16 +143,18 @@ func Test_keepKeys_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	keys := []string{"anything"}
 
 	exprFunc, _ := keepKeys(target, keys)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
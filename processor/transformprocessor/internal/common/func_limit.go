@@ -26,15 +26,18 @@ func limit(target tql.GetSetter, limit int64) (tql.ExprFunc, error) {
 	if limit < 0 {
 		return nil, fmt.Errorf("invalid limit for limit function, %d cannot be negative", limit)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 
 		if attrs, ok := val.(pcommon.Map); ok {
 			if int64(attrs.Len()) <= limit {
-				return nil
+				return nil, nil
 			}
 
 			updated := pcommon.NewMap()
@@ -48,10 +51,12 @@ func limit(target tql.GetSetter, limit int64) (tql.ExprFunc, error) {
 				}
 				return false
 			})
-			target.Set(ctx, updated)
+			if err = target.Set(ctx, updated); err != nil {
+				return nil, err
+			}
 			// TODO: Write log when limiting is performed
 			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/9730
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
@@ -31,12 +31,13 @@ func Test_limit(t *testing.T) {
 	input.InsertBool("test3", true)
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Map).Clear()
 			val.(pcommon.Map).CopyTo(ctx.GetItem().(pcommon.Map))
+			return nil
 		},
 	}
 
@@ -96,7 +97,8 @@ func Test_limit(t *testing.T) {
 			}
 
 			exprFunc, _ := limit(tt.target, tt.limit)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewMap()
 			tt.want(expected)
@@ -133,16 +135,18 @@ func Test_limit_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := limit(target, 1)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueString("not a map"), input)
 }
@@ -153,14 +157,16 @@ func Test_limit_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := limit(target, 1)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
@@ -0,0 +1,70 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+const (
+	insert = "insert"
+	update = "update"
+	upsert = "upsert"
+)
+
+// mergeMaps merges the source map into the target map. The strategy determines how the keys which are in
+// both maps are handled:
+// - insert only adds the keys which are not in the target map.
+// - update only updates the keys which are already in the target map.
+// - upsert adds and updates all the keys.
+func mergeMaps(target tql.Getter, source tql.Getter, strategy string) (tql.ExprFunc, error) {
+	if strategy != insert && strategy != update && strategy != upsert {
+		return nil, fmt.Errorf("invalid value for strategy, %v, must be %q, %q or %q", strategy, insert, update, upsert)
+	}
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		targetVal, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sourceVal, err := source.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		targetMap, ok := targetVal.(pcommon.Map)
+		if !ok {
+			return nil, nil
+		}
+		sourceMap, ok := sourceVal.(pcommon.Map)
+		if !ok {
+			return nil, nil
+		}
+		sourceMap.Range(func(key string, value pcommon.Value) bool {
+			switch strategy {
+			case insert:
+				targetMap.Insert(key, value)
+			case update:
+				targetMap.Update(key, value)
+			default:
+				targetMap.Upsert(key, value)
+			}
+			return true
+		})
+		return nil, nil
+	}, nil
+}
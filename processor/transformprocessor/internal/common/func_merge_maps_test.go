@@ -0,0 +1,113 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_mergeMaps(t *testing.T) {
+	input := pcommon.NewMap()
+	input.InsertString("attr1", "value1")
+	input.InsertString("attr2", "value2")
+
+	source := pcommon.NewMap()
+	source.InsertString("attr2", "new2")
+	source.InsertString("attr3", "new3")
+
+	target := &testGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		want     func(pcommon.Map)
+	}{
+		{
+			name:     "insert",
+			strategy: insert,
+			want: func(expected pcommon.Map) {
+				expected.InsertString("attr1", "value1")
+				expected.InsertString("attr2", "value2")
+				expected.InsertString("attr3", "new3")
+			},
+		},
+		{
+			name:     "update",
+			strategy: update,
+			want: func(expected pcommon.Map) {
+				expected.InsertString("attr1", "value1")
+				expected.InsertString("attr2", "new2")
+			},
+		},
+		{
+			name:     "upsert",
+			strategy: upsert,
+			want: func(expected pcommon.Map) {
+				expected.InsertString("attr1", "value1")
+				expected.InsertString("attr2", "new2")
+				expected.InsertString("attr3", "new3")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			exprFunc, err := mergeMaps(target, testLiteral{value: source}, tt.strategy)
+			assert.NoError(t, err)
+
+			_, err = exprFunc(tqltest.TestTransformContext{Item: scenarioMap})
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.Sort(), scenarioMap.Sort())
+		})
+	}
+}
+
+func Test_mergeMaps_bad_input(t *testing.T) {
+	input := pcommon.NewValueString("not a map")
+
+	target := &testGetSetter{
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
+		},
+	}
+
+	exprFunc, err := mergeMaps(target, testLiteral{value: pcommon.NewMap()}, upsert)
+	assert.NoError(t, err)
+
+	_, err = exprFunc(tqltest.TestTransformContext{Item: input})
+	assert.NoError(t, err)
+	assert.Equal(t, pcommon.NewValueString("not a map"), input)
+}
+
+func Test_mergeMaps_validation(t *testing.T) {
+	_, err := mergeMaps(&testGetSetter{}, testLiteral{value: pcommon.NewMap()}, "replace")
+	assert.Error(t, err)
+}
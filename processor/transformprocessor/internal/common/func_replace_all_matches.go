@@ -28,10 +28,13 @@ func replaceAllMatches(target tql.GetSetter, pattern string, replacement string)
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to replace_match is not a valid pattern: %w", err)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 		if attrs, ok := val.(pcommon.Map); ok {
 			updated := pcommon.NewMap()
@@ -44,8 +47,10 @@ func replaceAllMatches(target tql.GetSetter, pattern string, replacement string)
 				}
 				return true
 			})
-			target.Set(ctx, updated)
+			if err = target.Set(ctx, updated); err != nil {
+				return nil, err
+			}
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
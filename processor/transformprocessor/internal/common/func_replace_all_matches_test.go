@@ -31,12 +31,13 @@ func Test_replaceAllMatches(t *testing.T) {
 	input.InsertString("test3", "goodbye")
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Map).Clear()
 			val.(pcommon.Map).CopyTo(ctx.GetItem().(pcommon.Map))
+			return nil
 		},
 	}
 
@@ -82,7 +83,8 @@ func Test_replaceAllMatches(t *testing.T) {
 			}
 
 			exprFunc, _ := replaceAllMatches(tt.target, tt.pattern, tt.replacement)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewMap()
 			tt.want(expected)
@@ -99,16 +101,18 @@ func Test_replaceAllMatches_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := replaceAllMatches(target, "*", "{replacement}")
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueString("not a map"), input)
 }
@@ -119,14 +123,16 @@ func Test_replaceAllMatches_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := replaceAllMatches(target, "*", "{anything}")
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
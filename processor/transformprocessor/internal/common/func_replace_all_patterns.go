@@ -28,10 +28,13 @@ func replaceAllPatterns(target tql.GetSetter, regexPattern string, replacement s
 	if err != nil {
 		return nil, fmt.Errorf("the regex pattern supplied to replace_all_patterns is not a valid pattern: %w", err)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 		if attrs, ok := val.(pcommon.Map); ok {
 			updated := pcommon.NewMap()
@@ -45,8 +48,10 @@ func replaceAllPatterns(target tql.GetSetter, regexPattern string, replacement s
 				}
 				return true
 			})
-			target.Set(ctx, updated)
+			if err = target.Set(ctx, updated); err != nil {
+				return nil, err
+			}
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
@@ -31,12 +31,13 @@ func Test_replaceAllPatterns(t *testing.T) {
 	input.InsertString("test3", "goodbye world1 and world2")
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Map).Clear()
 			val.(pcommon.Map).CopyTo(ctx.GetItem().(pcommon.Map))
+			return nil
 		},
 	}
 
@@ -94,7 +95,8 @@ func Test_replaceAllPatterns(t *testing.T) {
 			}
 
 			exprFunc, _ := replaceAllPatterns(tt.target, tt.pattern, tt.replacement)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewMap()
 			tt.want(expected)
@@ -111,18 +113,20 @@ func Test_replaceAllPatterns_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, err := replaceAllPatterns(target, "regexpattern", "{replacement}")
 	assert.Nil(t, err)
 
-	exprFunc(ctx)
+	_, err = exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueString("not a map"), input)
 }
@@ -133,27 +137,30 @@ func Test_replaceAllPatterns_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, err := replaceAllPatterns(target, "regexp", "{anything}")
 	assert.Nil(t, err)
-	exprFunc(ctx)
+	_, err = exprFunc(ctx)
+	assert.NoError(t, err)
 }
 
 func Test_replaceAllPatterns_invalid_pattern(t *testing.T) {
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			t.Errorf("nothing should be received in this scenario")
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
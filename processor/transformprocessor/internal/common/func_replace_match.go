@@ -27,16 +27,21 @@ func replaceMatch(target tql.GetSetter, pattern string, replacement string) (tql
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to replace_match is not a valid pattern: %w", err)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		val := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 		if valStr, ok := val.(string); ok {
 			if glob.Match(valStr) {
-				target.Set(ctx, replacement)
+				if err = target.Set(ctx, replacement); err != nil {
+					return nil, err
+				}
 			}
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
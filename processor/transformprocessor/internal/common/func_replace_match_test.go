@@ -28,11 +28,12 @@ func Test_replaceMatch(t *testing.T) {
 	input := pcommon.NewValueString("hello world")
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(pcommon.Value).StringVal()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(pcommon.Value).StringVal(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Value).SetStringVal(val.(string))
+			return nil
 		},
 	}
 
@@ -71,7 +72,8 @@ func Test_replaceMatch(t *testing.T) {
 			}
 
 			exprFunc, _ := replaceMatch(tt.target, tt.pattern, tt.replacement)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewValueString("")
 			tt.want(expected)
@@ -88,16 +90,18 @@ func Test_replaceMatch_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := replaceAllMatches(target, "*", "{replacement}")
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueInt(1), input)
 }
@@ -108,14 +112,16 @@ func Test_replaceMatch_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := replaceMatch(target, "*", "{anything}")
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
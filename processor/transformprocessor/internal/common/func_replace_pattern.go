@@ -26,17 +26,22 @@ func replacePattern(target tql.GetSetter, regexPattern string, replacement strin
 	if err != nil {
 		return nil, fmt.Errorf("the regex pattern supplied to replace_pattern is not a valid pattern: %w", err)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		originalVal := target.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		originalVal, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if originalVal == nil {
-			return nil
+			return nil, nil
 		}
 		if originalValStr, ok := originalVal.(string); ok {
 			if compiledPattern.MatchString(originalValStr) {
 				updatedStr := compiledPattern.ReplaceAllLiteralString(originalValStr, replacement)
-				target.Set(ctx, updatedStr)
+				if err = target.Set(ctx, updatedStr); err != nil {
+					return nil, err
+				}
 			}
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
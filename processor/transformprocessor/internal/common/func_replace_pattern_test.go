@@ -28,11 +28,12 @@ func Test_replacePattern(t *testing.T) {
 	input := pcommon.NewValueString("application passwd=sensitivedtata otherarg=notsensitive key1 key2")
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem().(pcommon.Value).StringVal()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem().(pcommon.Value).StringVal(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Value).SetStringVal(val.(string))
+			return nil
 		},
 	}
 
@@ -80,7 +81,8 @@ func Test_replacePattern(t *testing.T) {
 			}
 
 			exprFunc, _ := replacePattern(tt.target, tt.pattern, tt.replacement)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewValueString("")
 			tt.want(expected)
@@ -97,17 +99,19 @@ func Test_replacePattern_bad_input(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, err := replacePattern(target, "regexp", "{replacement}")
 	assert.Nil(t, err)
-	exprFunc(ctx)
+	_, err = exprFunc(ctx)
+	assert.NoError(t, err)
 
 	assert.Equal(t, pcommon.NewValueInt(1), input)
 }
@@ -118,26 +122,29 @@ func Test_replacePattern_get_nil(t *testing.T) {
 	}
 
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	exprFunc, _ := replacePattern(target, `nomatch\=[^\s]*(\s?)`, "{anything}")
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
 
 func Test_replacePatterns_invalid_pattern(t *testing.T) {
 	target := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
 			t.Errorf("nothing should be received in this scenario")
-			return nil
+			return nil, nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
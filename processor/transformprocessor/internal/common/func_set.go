@@ -17,11 +17,16 @@ package common // import "github.com/open-telemetry/opentelemetry-collector-cont
 import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 
 func set(target tql.Setter, value tql.Getter) (tql.ExprFunc, error) {
-	return func(ctx tql.TransformContext) interface{} {
-		val := value.Get(ctx)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		val, err := value.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val != nil {
-			target.Set(ctx, val)
+			if err = target.Set(ctx, val); err != nil {
+				return nil, err
+			}
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
@@ -28,8 +28,9 @@ func Test_set(t *testing.T) {
 	input := pcommon.NewValueString("original name")
 
 	target := &testGetSetter{
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			ctx.GetItem().(pcommon.Value).SetStringVal(val.(string))
+			return nil
 		},
 	}
 
@@ -65,7 +66,8 @@ func Test_set(t *testing.T) {
 			}
 
 			exprFunc, _ := set(tt.setter, tt.getter)
-			exprFunc(ctx)
+			_, err := exprFunc(ctx)
+			assert.NoError(t, err)
 
 			expected := pcommon.NewValueString("")
 			tt.want(expected)
@@ -81,17 +83,19 @@ func Test_set_get_nil(t *testing.T) {
 	}
 
 	setter := &testGetSetter{
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		setter: func(ctx tql.TransformContext, val interface{}) error {
 			t.Errorf("nothing should be set in this scenario")
+			return nil
 		},
 	}
 
 	getter := &testGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetItem()
+		getter: func(ctx tql.TransformContext) (interface{}, error) {
+			return ctx.GetItem(), nil
 		},
 	}
 
 	exprFunc, _ := set(setter, getter)
-	exprFunc(ctx)
+	_, err := exprFunc(ctx)
+	assert.NoError(t, err)
 }
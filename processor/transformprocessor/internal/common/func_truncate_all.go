@@ -26,14 +26,17 @@ func truncateAll(target tql.GetSetter, limit int64) (tql.ExprFunc, error) {
 	if limit < 0 {
 		return nil, fmt.Errorf("invalid limit for truncate_all function, %d cannot be negative", limit)
 	}
-	return func(ctx tql.TransformContext) interface{} {
+	return func(ctx tql.TransformContext) (interface{}, error) {
 		if limit < 0 {
-			return nil
+			return nil, nil
 		}
 
-		val := target.Get(ctx)
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if val == nil {
-			return nil
+			return nil, nil
 		}
 
 		if attrs, ok := val.(pcommon.Map); ok {
@@ -48,10 +51,12 @@ func truncateAll(target tql.GetSetter, limit int64) (tql.ExprFunc, error) {
 				}
 				return true
 			})
-			target.Set(ctx, updated)
+			if err = target.Set(ctx, updated); err != nil {
+				return nil, err
+			}
 			// TODO: Write log when truncation is performed
 			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/9730
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
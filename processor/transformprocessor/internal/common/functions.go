@@ -14,10 +14,22 @@
 
 package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+)
+
 var registry = map[string]interface{}{
-	"TraceID":              traceID,
-	"SpanID":               spanID,
-	"IsMatch":              isMatch,
+	"TraceID":              tqlotel.TraceID,
+	"SpanID":               tqlotel.SpanID,
+	"IsMatch":              tqlotel.IsMatch,
+	"ParseJSON":            tqlotel.ParseJSON,
+	"Split":                tqlotel.Split,
+	"Concat":               tqlotel.Concat,
+	"SHA256":               tqlotel.SHA256,
+	"Int":                  tqlotel.Int,
+	"Double":               tqlotel.Double,
+	"String":               tqlotel.String,
+	"Substring":            tqlotel.Substring,
 	"keep_keys":            keepKeys,
 	"set":                  set,
 	"truncate_all":         truncateAll,
@@ -28,6 +40,7 @@ var registry = map[string]interface{}{
 	"replace_all_patterns": replaceAllPatterns,
 	"delete_key":           deleteKey,
 	"delete_matching_keys": deleteMatchingKeys,
+	"merge_maps":           mergeMaps,
 }
 
 func DefaultFunctions() map[string]interface{} {
@@ -19,21 +19,21 @@ import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryq
 // testGetSetter is a getSetter for testing.
 type testGetSetter struct {
 	getter tql.ExprFunc
-	setter func(ctx tql.TransformContext, val interface{})
+	setter func(ctx tql.TransformContext, val interface{}) error
 }
 
-func (path testGetSetter) Get(ctx tql.TransformContext) interface{} {
+func (path testGetSetter) Get(ctx tql.TransformContext) (interface{}, error) {
 	return path.getter(ctx)
 }
 
-func (path testGetSetter) Set(ctx tql.TransformContext, val interface{}) {
-	path.setter(ctx, val)
+func (path testGetSetter) Set(ctx tql.TransformContext, val interface{}) error {
+	return path.setter(ctx, val)
 }
 
 type testLiteral struct {
 	value interface{}
 }
 
-func (t testLiteral) Get(ctx tql.TransformContext) interface{} {
-	return t.value
+func (t testLiteral) Get(ctx tql.TransformContext) (interface{}, error) {
+	return t.value, nil
 }
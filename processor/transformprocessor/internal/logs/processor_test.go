@@ -20,12 +20,17 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 var (
+	traceID = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID  = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+
 	TestLogTime      = time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC)
 	TestLogTimestamp = pcommon.NewTimestampFromTime(TestLogTime)
 
@@ -136,6 +141,65 @@ func TestProcess(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("http.url", "http://localhost/health")
 			},
 		},
+		{
+			query: `set(attributes["http"]["method"], attributes["http.method"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				http := pcommon.NewValueMap()
+				http.MapVal().InsertString("method", "get")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("http", http)
+			},
+		},
+		{
+			query: `set(attributes["test"], flags * 2 + 1) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertInt("test", 3)
+			},
+		},
+		{
+			query: `set(attributes["test"], "pass") where severity_number + 1 == 2`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "pass")
+			},
+		},
+		{
+			query: `set(attributes["test"], Concat(" ", attributes["http.method"], Substring(body, 0, 9))) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "get operation")
+			},
+		},
+		{
+			query: `set(attributes["test"], Split(attributes["http.url"], "/")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				parts := pcommon.NewValueSlice()
+				for _, part := range []string{"http:", "", "localhost", "health"} {
+					parts.SliceVal().AppendEmpty().SetStringVal(part)
+				}
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("test", parts)
+			},
+		},
+		{
+			query: `set(attributes["test"], Int("5") + Int(Double("2.5"))) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertInt("test", 7)
+			},
+		},
+		{
+			query: `merge_maps(attributes, ParseJSON("{\"http.method\":\"post\",\"json\":true}"), "upsert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().UpdateString("http.method", "post")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertBool("json", true)
+			},
+		},
+		{
+			query: `set(attributes["test"], ParseJSON("{\"nested\":{\"key\":1}}")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				parsed := pcommon.NewValueMap()
+				nested := pcommon.NewValueMap()
+				nested.MapVal().InsertDouble("key", 1)
+				parsed.MapVal().Insert("nested", nested)
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("test", parsed)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -155,6 +219,34 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcess_skipsFailedStatements(t *testing.T) {
+	td := constructLogs()
+	logs := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	logs.At(0).Attributes().InsertInt("count", 4)
+	logs.At(1).Attributes().InsertString("count", "four")
+
+	processor, err := NewProcessor([]string{
+		`set(attributes["half"], attributes["count"] / 2)`,
+		`set(attributes["test"], "pass") where attributes["http.path"][0] == "/"`,
+		`set(attributes["test"], "pass")`,
+	}, DefaultFunctions(), componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessLogs(context.Background(), td)
+	require.NoError(t, err)
+
+	half, ok := logs.At(0).Attributes().Get("half")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), half.IntVal())
+	_, ok = logs.At(1).Attributes().Get("half")
+	assert.False(t, ok)
+	for i := 0; i < logs.Len(); i++ {
+		test, ok := logs.At(i).Attributes().Get("test")
+		require.True(t, ok)
+		assert.Equal(t, "pass", test.StringVal())
+	}
+}
+
 func constructLogs() plog.Logs {
 	td := plog.NewLogs()
 	rs0 := td.ResourceLogs().AppendEmpty()
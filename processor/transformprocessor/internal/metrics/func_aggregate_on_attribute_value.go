@@ -0,0 +1,49 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func aggregateOnAttributeValue(aggregation string, attribute string, newValue string, values []string) (tql.ExprFunc, error) {
+	if err := validateAggregation(aggregation); err != nil {
+		return nil, err
+	}
+	replaced := make(map[string]bool, len(values))
+	for _, v := range values {
+		replaced[v] = true
+	}
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
+		if !ok {
+			return nil, nil
+		}
+
+		aggregateDataPoints(mtc.GetMetric(), aggregation, func(attrs pcommon.Map) {
+			val, ok := attrs.Get(attribute)
+			if !ok || val.Type() != pcommon.ValueTypeString {
+				return
+			}
+			if replaced[val.StringVal()] {
+				val.SetStringVal(newValue)
+			}
+		})
+		return nil, nil
+	}, nil
+}
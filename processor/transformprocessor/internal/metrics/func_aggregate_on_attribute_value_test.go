@@ -0,0 +1,121 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+)
+
+func Test_aggregateOnAttributeValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation string
+		newValue    string
+		values      []string
+		want        func(pmetric.NumberDataPointSlice)
+	}{
+		{
+			name:        "sum",
+			aggregation: "sum",
+			newValue:    "unused",
+			values:      []string{"free", "cached"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "a")
+				dp.Attributes().InsertString("state", "used")
+				dp.SetIntVal(2)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "b")
+				dp.Attributes().InsertString("state", "used")
+				dp.SetIntVal(5)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "a")
+				dp.Attributes().InsertString("state", "unused")
+				dp.SetIntVal(4)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "b")
+				dp.Attributes().InsertString("state", "unused")
+				dp.SetIntVal(1)
+			},
+		},
+		{
+			name:        "max",
+			aggregation: "max",
+			newValue:    "used",
+			values:      []string{"free"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "a")
+				dp.Attributes().InsertString("state", "used")
+				dp.SetIntVal(4)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "b")
+				dp.Attributes().InsertString("state", "used")
+				dp.SetIntVal(5)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "b")
+				dp.Attributes().InsertString("state", "cached")
+				dp.SetIntVal(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := getTestAggregationSumMetric()
+
+			exprFunc, err := aggregateOnAttributeValue(tt.aggregation, "state", tt.newValue, tt.values)
+			assert.NoError(t, err)
+			_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetric()
+			expected.SetDataType(pmetric.MetricDataTypeSum)
+			expected.SetName("sum_metric")
+			tt.want(expected.Sum().DataPoints())
+
+			assert.Equal(t, expected, metric)
+		})
+	}
+}
+
+func Test_aggregateOnAttributeValue_unsupported(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeSummary)
+	for _, state := range []string{"free", "cached"} {
+		dp := metric.Summary().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("state", state)
+	}
+
+	exprFunc, err := aggregateOnAttributeValue("sum", "state", "unused", []string{"free", "cached"})
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+
+	dps := metric.Summary().DataPoints()
+	assert.Equal(t, 2, dps.Len())
+	assert.Equal(t, map[string]interface{}{"state": "free"}, dps.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"state": "cached"}, dps.At(1).Attributes().AsRaw())
+}
+
+func Test_aggregateOnAttributeValue_validation(t *testing.T) {
+	_, err := aggregateOnAttributeValue("avg", "state", "unused", []string{"free"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+const (
+	sumAggregation  = "sum"
+	meanAggregation = "mean"
+	minAggregation  = "min"
+	maxAggregation  = "max"
+)
+
+func aggregateOnAttributes(aggregation string, attributes []string) (tql.ExprFunc, error) {
+	if err := validateAggregation(aggregation); err != nil {
+		return nil, err
+	}
+	keep := make(map[string]bool, len(attributes))
+	for _, attr := range attributes {
+		keep[attr] = true
+	}
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
+		if !ok {
+			return nil, nil
+		}
+
+		aggregateDataPoints(mtc.GetMetric(), aggregation, func(attrs pcommon.Map) {
+			attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+				return !keep[k]
+			})
+		})
+		return nil, nil
+	}, nil
+}
+
+func validateAggregation(aggregation string) error {
+	switch aggregation {
+	case sumAggregation, meanAggregation, minAggregation, maxAggregation:
+		return nil
+	default:
+		return fmt.Errorf("unknown aggregation function: %s, must be %q, %q, %q or %q",
+			aggregation, sumAggregation, meanAggregation, minAggregation, maxAggregation)
+	}
+}
+
+// aggregateDataPoints updates the attributes of the data points of the metric and merges the data points which
+// end up with the same attributes and timestamps. The data points are merged in place into the first data point of
+// each group, so aggregating a metric a second time leaves it unchanged.
+// Attributes are only updated on data points which can be merged, since updating the others would output several
+// series with the same identity. Histograms are only merged by the sum aggregation, and only when every data point
+// of a group has the same bucket bounds; exponential histograms and summaries are left unchanged.
+func aggregateDataPoints(metric pmetric.Metric, aggregation string, update func(pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		aggregateNumberDataPoints(metric.Gauge().DataPoints(), aggregation, update)
+	case pmetric.MetricDataTypeSum:
+		aggregateNumberDataPoints(metric.Sum().DataPoints(), aggregation, update)
+	case pmetric.MetricDataTypeHistogram:
+		if aggregation == sumAggregation {
+			aggregateHistogramDataPoints(metric.Histogram().DataPoints(), update)
+		}
+	}
+}
+
+// dataPointGroup holds the indexes of the data points which have the same attributes, once updated, and timestamps.
+type dataPointGroup struct {
+	attributes pcommon.Map
+	indexes    []int
+}
+
+// groupDataPoints groups the n data points returned by dataPoint, in the order of their first data point.
+func groupDataPoints(n int, dataPoint func(i int) (pcommon.Map, pcommon.Timestamp, pcommon.Timestamp), update func(pcommon.Map)) []*dataPointGroup {
+	var groups []*dataPointGroup
+	byKey := map[string]*dataPointGroup{}
+	for i := 0; i < n; i++ {
+		attrs, start, ts := dataPoint(i)
+		updated := pcommon.NewMap()
+		attrs.CopyTo(updated)
+		update(updated)
+		key := dataPointKey(updated, start, ts)
+		group, ok := byKey[key]
+		if !ok {
+			group = &dataPointGroup{attributes: updated}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.indexes = append(group.indexes, i)
+	}
+	return groups
+}
+
+func aggregateNumberDataPoints(dps pmetric.NumberDataPointSlice, aggregation string, update func(pcommon.Map)) {
+	groups := groupDataPoints(dps.Len(), func(i int) (pcommon.Map, pcommon.Timestamp, pcommon.Timestamp) {
+		dp := dps.At(i)
+		return dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()
+	}, update)
+
+	removed := make([]bool, dps.Len())
+	for _, group := range groups {
+		first := dps.At(group.indexes[0])
+		group.attributes.CopyTo(first.Attributes())
+		for _, i := range group.indexes[1:] {
+			mergeNumberDataPoint(first, dps.At(i), aggregation)
+			removed[i] = true
+		}
+
+		count := len(group.indexes)
+		if aggregation != meanAggregation || count <= 1 {
+			continue
+		}
+		switch first.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			first.SetIntVal(first.IntVal() / int64(count))
+		case pmetric.NumberDataPointValueTypeDouble:
+			first.SetDoubleVal(first.DoubleVal() / float64(count))
+		}
+	}
+
+	i := 0
+	dps.RemoveIf(func(pmetric.NumberDataPoint) bool {
+		remove := removed[i]
+		i++
+		return remove
+	})
+}
+
+func mergeNumberDataPoint(to pmetric.NumberDataPoint, from pmetric.NumberDataPoint, aggregation string) {
+	switch to.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		val := intVal(from)
+		switch aggregation {
+		case sumAggregation, meanAggregation:
+			to.SetIntVal(to.IntVal() + val)
+		case minAggregation:
+			if val < to.IntVal() {
+				to.SetIntVal(val)
+			}
+		case maxAggregation:
+			if val > to.IntVal() {
+				to.SetIntVal(val)
+			}
+		}
+	case pmetric.NumberDataPointValueTypeDouble:
+		val := doubleVal(from)
+		switch aggregation {
+		case sumAggregation, meanAggregation:
+			to.SetDoubleVal(to.DoubleVal() + val)
+		case minAggregation:
+			to.SetDoubleVal(math.Min(to.DoubleVal(), val))
+		case maxAggregation:
+			to.SetDoubleVal(math.Max(to.DoubleVal(), val))
+		}
+	}
+}
+
+func aggregateHistogramDataPoints(dps pmetric.HistogramDataPointSlice, update func(pcommon.Map)) {
+	groups := groupDataPoints(dps.Len(), func(i int) (pcommon.Map, pcommon.Timestamp, pcommon.Timestamp) {
+		dp := dps.At(i)
+		return dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()
+	}, update)
+
+	removed := make([]bool, dps.Len())
+	for _, group := range groups {
+		first := dps.At(group.indexes[0])
+		if !sameExplicitBounds(dps, group.indexes) {
+			continue
+		}
+		group.attributes.CopyTo(first.Attributes())
+		for _, i := range group.indexes[1:] {
+			mergeHistogramDataPoint(first, dps.At(i))
+			removed[i] = true
+		}
+	}
+
+	i := 0
+	dps.RemoveIf(func(pmetric.HistogramDataPoint) bool {
+		remove := removed[i]
+		i++
+		return remove
+	})
+}
+
+// sameExplicitBounds reports if the histogram data points at indexes all have the same bucket bounds,
+// since only those can be merged.
+func sameExplicitBounds(dps pmetric.HistogramDataPointSlice, indexes []int) bool {
+	bounds := dps.At(indexes[0]).ExplicitBounds()
+	for _, i := range indexes[1:] {
+		other := dps.At(i).ExplicitBounds()
+		if other.Len() != bounds.Len() {
+			return false
+		}
+		for b := 0; b < bounds.Len(); b++ {
+			if other.At(b) != bounds.At(b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func mergeHistogramDataPoint(to pmetric.HistogramDataPoint, from pmetric.HistogramDataPoint) {
+	if from.Count() == 0 {
+		return
+	}
+	to.SetCount(to.Count() + from.Count())
+	if from.HasSum() {
+		to.SetSum(to.Sum() + from.Sum())
+	}
+	if from.HasMin() && (!to.HasMin() || from.Min() < to.Min()) {
+		to.SetMin(from.Min())
+	}
+	if from.HasMax() && (!to.HasMax() || from.Max() > to.Max()) {
+		to.SetMax(from.Max())
+	}
+	counts := to.BucketCounts().AsRaw()
+	if len(counts) == from.BucketCounts().Len() {
+		for b := range counts {
+			counts[b] += from.BucketCounts().At(b)
+		}
+		to.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+	}
+	from.Exemplars().MoveAndAppendTo(to.Exemplars())
+}
+
+func dataPointKey(attrs pcommon.Map, start pcommon.Timestamp, ts pcommon.Timestamp) string {
+	key, _ := json.Marshal([]interface{}{attrs.AsRaw(), start.String(), ts.String()})
+	return string(key)
+}
+
+func intVal(dp pmetric.NumberDataPoint) int64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeDouble {
+		return int64(dp.DoubleVal())
+	}
+	return dp.IntVal()
+}
+
+func doubleVal(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
@@ -0,0 +1,260 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+)
+
+func getTestAggregationSumMetric() pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeSum)
+	metric.SetName("sum_metric")
+
+	for _, v := range []struct {
+		host  string
+		state string
+		val   int64
+	}{
+		{host: "a", state: "used", val: 2},
+		{host: "b", state: "used", val: 5},
+		{host: "a", state: "free", val: 4},
+		{host: "b", state: "cached", val: 1},
+	} {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("host", v.host)
+		dp.Attributes().InsertString("state", v.state)
+		dp.SetIntVal(v.val)
+	}
+	return metric
+}
+
+func Test_aggregateOnAttributes(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation string
+		attributes  []string
+		want        func(pmetric.NumberDataPointSlice)
+	}{
+		{
+			name:        "sum",
+			aggregation: "sum",
+			attributes:  []string{"state"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().InsertString("state", "used")
+				dp.SetIntVal(7)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("state", "free")
+				dp.SetIntVal(4)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("state", "cached")
+				dp.SetIntVal(1)
+			},
+		},
+		{
+			name:        "mean",
+			aggregation: "mean",
+			attributes:  []string{"host"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "a")
+				dp.SetIntVal(3)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "b")
+				dp.SetIntVal(3)
+			},
+		},
+		{
+			name:        "min",
+			aggregation: "min",
+			attributes:  []string{"host"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "a")
+				dp.SetIntVal(2)
+				dp = dps.AppendEmpty()
+				dp.Attributes().InsertString("host", "b")
+				dp.SetIntVal(1)
+			},
+		},
+		{
+			name:        "max of all data points",
+			aggregation: "max",
+			attributes:  []string{},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				// All attributes were removed from the data point.
+				dp.Attributes().InsertString("host", "a")
+				dp.Attributes().Remove("host")
+				dp.SetIntVal(5)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := getTestAggregationSumMetric()
+			ctx := tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource())
+
+			exprFunc, err := aggregateOnAttributes(tt.aggregation, tt.attributes)
+			assert.NoError(t, err)
+			_, err = exprFunc(ctx)
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetric()
+			expected.SetDataType(pmetric.MetricDataTypeSum)
+			expected.SetName("sum_metric")
+			tt.want(expected.Sum().DataPoints())
+
+			assert.Equal(t, expected, metric)
+
+			// Aggregating again, e.g. for the next data point of the metric, is a no-op.
+			_, err = exprFunc(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, metric)
+		})
+	}
+}
+
+func Test_aggregateOnAttributes_double(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	dp := metric.Gauge().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("host", "a")
+	dp.SetDoubleVal(1.5)
+	dp = metric.Gauge().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("host", "b")
+	dp.SetIntVal(2)
+
+	exprFunc, err := aggregateOnAttributes("mean", nil)
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, metric.Gauge().DataPoints().Len())
+	assert.Equal(t, 1.75, metric.Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 0, metric.Gauge().DataPoints().At(0).Attributes().Len())
+}
+
+func Test_aggregateOnAttributes_timestamps(t *testing.T) {
+	metric := getTestAggregationSumMetric()
+	metric.Sum().DataPoints().At(1).SetTimestamp(pcommon.Timestamp(1))
+
+	exprFunc, err := aggregateOnAttributes("sum", nil)
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+
+	dps := metric.Sum().DataPoints()
+	assert.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(7), dps.At(0).IntVal())
+	assert.Equal(t, int64(5), dps.At(1).IntVal())
+}
+
+func Test_aggregateOnAttributes_histogram(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeHistogram)
+	for i, host := range []string{"a", "b", "c"} {
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("host", host)
+		dp.Attributes().InsertString("region", "eu")
+		dp.SetCount(uint64(i + 1))
+		dp.SetSum(float64(i + 1))
+		dp.SetMin(float64(i))
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1}))
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{uint64(i), 1}))
+	}
+	metric.Histogram().DataPoints().At(2).Attributes().UpdateString("region", "us")
+
+	exprFunc, err := aggregateOnAttributes("max", []string{"region"})
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, metric.Histogram().DataPoints().Len())
+	assert.Equal(t, 2, metric.Histogram().DataPoints().At(0).Attributes().Len(), "attributes are kept when the data points can't be merged")
+
+	exprFunc, err = aggregateOnAttributes("sum", []string{"region"})
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+
+	dps := metric.Histogram().DataPoints()
+	assert.Equal(t, 2, dps.Len())
+	assert.Equal(t, map[string]interface{}{"region": "eu"}, dps.At(0).Attributes().AsRaw())
+	assert.Equal(t, uint64(3), dps.At(0).Count())
+	assert.Equal(t, 3.0, dps.At(0).Sum())
+	assert.Equal(t, 0.0, dps.At(0).Min())
+	assert.Equal(t, []uint64{1, 2}, dps.At(0).BucketCounts().AsRaw())
+	assert.Equal(t, map[string]interface{}{"region": "us"}, dps.At(1).Attributes().AsRaw())
+	assert.Equal(t, uint64(3), dps.At(1).Count())
+}
+
+func Test_aggregateOnAttributes_histogramBounds(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeHistogram)
+	for i, host := range []string{"a", "b"} {
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("host", host)
+		dp.SetCount(1)
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{float64(i + 1)}))
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 0}))
+	}
+
+	exprFunc, err := aggregateOnAttributes("sum", nil)
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+
+	dps := metric.Histogram().DataPoints()
+	assert.Equal(t, 2, dps.Len())
+	assert.Equal(t, map[string]interface{}{"host": "a"}, dps.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"host": "b"}, dps.At(1).Attributes().AsRaw())
+}
+
+func Test_aggregateOnAttributes_unsupported(t *testing.T) {
+	summary := pmetric.NewMetric()
+	summary.SetDataType(pmetric.MetricDataTypeSummary)
+	expHistogram := pmetric.NewMetric()
+	expHistogram.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	for _, host := range []string{"a", "b"} {
+		dp := summary.Summary().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("host", host)
+		edp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+		edp.Attributes().InsertString("host", host)
+	}
+
+	exprFunc, err := aggregateOnAttributes("sum", nil)
+	assert.NoError(t, err)
+	for _, metric := range []pmetric.Metric{summary, expHistogram} {
+		_, err = exprFunc(tqlmetrics.NewTransformContext(nil, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, map[string]interface{}{"host": "a"}, summary.Summary().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"host": "b"}, summary.Summary().DataPoints().At(1).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"host": "a"}, expHistogram.ExponentialHistogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"host": "b"}, expHistogram.ExponentialHistogram().DataPoints().At(1).Attributes().AsRaw())
+}
+
+func Test_aggregateOnAttributes_validation(t *testing.T) {
+	_, err := aggregateOnAttributes("median", []string{"host"})
+	assert.Error(t, err)
+}
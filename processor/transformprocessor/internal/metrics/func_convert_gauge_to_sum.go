@@ -19,6 +19,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
@@ -33,15 +34,15 @@ func convertGaugeToSum(stringAggTemp string, monotonic bool) (tql.ExprFunc, erro
 		return nil, fmt.Errorf("unknown aggregation temporality: %s", stringAggTemp)
 	}
 
-	return func(ctx tql.TransformContext) interface{} {
-		mtc, ok := ctx.(metricTransformContext)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
 		if !ok {
-			return nil
+			return nil, nil
 		}
 
 		metric := mtc.GetMetric()
 		if metric.DataType() != pmetric.MetricDataTypeGauge {
-			return nil
+			return nil, nil
 		}
 
 		dps := metric.Gauge().DataPoints()
@@ -53,6 +54,6 @@ func convertGaugeToSum(stringAggTemp string, monotonic bool) (tql.ExprFunc, erro
 		// Setting the data type removed all the data points, so we must copy them back to the metric.
 		dps.CopyTo(metric.Sum().DataPoints())
 
-		return nil
+		return nil, nil
 	}, nil
 }
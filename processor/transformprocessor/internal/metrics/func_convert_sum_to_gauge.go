@@ -17,19 +17,20 @@ package metrics // import "github.com/open-telemetry/opentelemetry-collector-con
 import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 func convertSumToGauge() (tql.ExprFunc, error) {
-	return func(ctx tql.TransformContext) interface{} {
-		mtc, ok := ctx.(metricTransformContext)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
 		if !ok {
-			return nil
+			return nil, nil
 		}
 
 		metric := mtc.GetMetric()
 		if metric.DataType() != pmetric.MetricDataTypeSum {
-			return nil
+			return nil, nil
 		}
 
 		dps := metric.Sum().DataPoints()
@@ -38,6 +39,6 @@ func convertSumToGauge() (tql.ExprFunc, error) {
 		// Setting the data type removed all the data points, so we must copy them back to the metric.
 		dps.CopyTo(metric.Gauge().DataPoints())
 
-		return nil
+		return nil, nil
 	}, nil
 }
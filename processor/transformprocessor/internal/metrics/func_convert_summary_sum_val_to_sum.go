@@ -19,6 +19,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
@@ -32,15 +33,15 @@ func convertSummarySumValToSum(stringAggTemp string, monotonic bool) (tql.ExprFu
 	default:
 		return nil, fmt.Errorf("unknown aggregation temporality: %s", stringAggTemp)
 	}
-	return func(ctx tql.TransformContext) interface{} {
-		mtc, ok := ctx.(metricTransformContext)
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
 		if !ok {
-			return nil
+			return nil, nil
 		}
 
 		metric := mtc.GetMetric()
 		if metric.DataType() != pmetric.MetricDataTypeSummary {
-			return nil
+			return nil, nil
 		}
 
 		sumMetric := mtc.GetMetrics().AppendEmpty()
@@ -61,6 +62,6 @@ func convertSummarySumValToSum(stringAggTemp string, monotonic bool) (tql.ExprFu
 			sumDp.SetStartTimestamp(dp.StartTimestamp())
 			sumDp.SetTimestamp(dp.Timestamp())
 		}
-		return nil
+		return nil, nil
 	}, nil
 }
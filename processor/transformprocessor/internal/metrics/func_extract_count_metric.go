@@ -0,0 +1,95 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func extractCountMetric(monotonic bool) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
+		if !ok {
+			return nil, nil
+		}
+
+		var count uint64
+		switch dp := mtc.GetItem().(type) {
+		case pmetric.HistogramDataPoint:
+			count = dp.Count()
+		case pmetric.ExponentialHistogramDataPoint:
+			count = dp.Count()
+		default:
+			return nil, nil
+		}
+
+		sumDp := appendExtractedDataPoint(mtc, "_count", monotonic)
+		sumDp.SetIntVal(int64(count))
+		return nil, nil
+	}, nil
+}
+
+// appendExtractedDataPoint appends a data point with the attributes and timestamps of the current histogram
+// data point to the sum metric named after the histogram and the suffix. The sum metric is created the first
+// time a data point of the histogram is extracted, so extracting every data point of the histogram produces
+// a single metric.
+func appendExtractedDataPoint(mtc tqlmetrics.TransformContext, suffix string, monotonic bool) pmetric.NumberDataPoint {
+	metric := mtc.GetMetric()
+	var aggTemp pmetric.MetricAggregationTemporality
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeHistogram:
+		aggTemp = metric.Histogram().AggregationTemporality()
+	case pmetric.MetricDataTypeExponentialHistogram:
+		aggTemp = metric.ExponentialHistogram().AggregationTemporality()
+	}
+
+	name := metric.Name() + suffix
+	metrics := mtc.GetMetrics()
+	var sumMetric pmetric.Metric
+	found := false
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() == name && m.DataType() == pmetric.MetricDataTypeSum {
+			sumMetric = m
+			found = true
+			break
+		}
+	}
+	if !found {
+		sumMetric = metrics.AppendEmpty()
+		sumMetric.SetDescription(metric.Description())
+		sumMetric.SetName(name)
+		sumMetric.SetUnit(metric.Unit())
+		sumMetric.SetDataType(pmetric.MetricDataTypeSum)
+		sumMetric.Sum().SetAggregationTemporality(aggTemp)
+		sumMetric.Sum().SetIsMonotonic(monotonic)
+	}
+
+	sumDp := sumMetric.Sum().DataPoints().AppendEmpty()
+	switch dp := mtc.GetItem().(type) {
+	case pmetric.HistogramDataPoint:
+		dp.Attributes().CopyTo(sumDp.Attributes())
+		sumDp.SetStartTimestamp(dp.StartTimestamp())
+		sumDp.SetTimestamp(dp.Timestamp())
+	case pmetric.ExponentialHistogramDataPoint:
+		dp.Attributes().CopyTo(sumDp.Attributes())
+		sumDp.SetStartTimestamp(dp.StartTimestamp())
+		sumDp.SetTimestamp(dp.Timestamp())
+	}
+	return sumDp
+}
@@ -0,0 +1,155 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+)
+
+func getTestHistogramMetric() pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeHistogram)
+	metric.SetName("histogram_metric")
+	metric.SetDescription("a histogram")
+	metric.SetUnit("ms")
+	metric.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+
+	dp := metric.Histogram().DataPoints().AppendEmpty()
+	dp.SetCount(10)
+	dp.SetSum(12.5)
+	dp.SetTimestamp(pcommon.Timestamp(1))
+	getTestAttributes().CopyTo(dp.Attributes())
+
+	dp = metric.Histogram().DataPoints().AppendEmpty()
+	dp.SetCount(3)
+	dp.SetTimestamp(pcommon.Timestamp(2))
+	return metric
+}
+
+func getTestExponentialHistogramMetric() pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	metric.SetName("exponential_histogram_metric")
+	metric.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(4)
+	dp.SetSum(8)
+	getTestAttributes().CopyTo(dp.Attributes())
+	return metric
+}
+
+// runOnDataPoints calls exprFunc once per data point of the first metric in metrics, the way the processor does.
+func runOnDataPoints(t *testing.T, metrics pmetric.MetricSlice, exprFunc func(ctx tqlmetrics.TransformContext)) {
+	metric := metrics.At(0)
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			exprFunc(tqlmetrics.NewTransformContext(metric.Histogram().DataPoints().At(i), metric, metrics, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			exprFunc(tqlmetrics.NewTransformContext(metric.ExponentialHistogram().DataPoints().At(i), metric, metrics, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		}
+	case pmetric.MetricDataTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			exprFunc(tqlmetrics.NewTransformContext(metric.Gauge().DataPoints().At(i), metric, metrics, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		}
+	default:
+		t.Fatalf("unexpected metric type %v", metric.DataType())
+	}
+}
+
+func Test_extractCountMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     pmetric.Metric
+		monotonic bool
+		want      func(pmetric.MetricSlice)
+	}{
+		{
+			name:      "histogram",
+			input:     getTestHistogramMetric(),
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				sumMetric := metrics.AppendEmpty()
+				sumMetric.SetDataType(pmetric.MetricDataTypeSum)
+				sumMetric.SetName("histogram_metric_count")
+				sumMetric.SetDescription("a histogram")
+				sumMetric.SetUnit("ms")
+				sumMetric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+				sumMetric.Sum().SetIsMonotonic(true)
+
+				dp := sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntVal(10)
+				dp.SetTimestamp(pcommon.Timestamp(1))
+				getTestAttributes().CopyTo(dp.Attributes())
+
+				dp = sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntVal(3)
+				dp.SetTimestamp(pcommon.Timestamp(2))
+			},
+		},
+		{
+			name:      "exponential histogram",
+			input:     getTestExponentialHistogramMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestExponentialHistogramMetric().CopyTo(metrics.AppendEmpty())
+				sumMetric := metrics.AppendEmpty()
+				sumMetric.SetDataType(pmetric.MetricDataTypeSum)
+				sumMetric.SetName("exponential_histogram_metric_count")
+				sumMetric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+				sumMetric.Sum().SetIsMonotonic(false)
+
+				dp := sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntVal(4)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "noop for gauge",
+			input:     getTestGaugeMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			exprFunc, err := extractCountMetric(tt.monotonic)
+			assert.NoError(t, err)
+			runOnDataPoints(t, actualMetrics, func(ctx tqlmetrics.TransformContext) {
+				_, err = exprFunc(ctx)
+				assert.NoError(t, err)
+			})
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func extractSumMetric(monotonic bool) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) (interface{}, error) {
+		mtc, ok := ctx.(tqlmetrics.TransformContext)
+		if !ok {
+			return nil, nil
+		}
+
+		var sum float64
+		switch dp := mtc.GetItem().(type) {
+		case pmetric.HistogramDataPoint:
+			if !dp.HasSum() {
+				return nil, nil
+			}
+			sum = dp.Sum()
+		case pmetric.ExponentialHistogramDataPoint:
+			if !dp.HasSum() {
+				return nil, nil
+			}
+			sum = dp.Sum()
+		default:
+			return nil, nil
+		}
+
+		sumDp := appendExtractedDataPoint(mtc, "_sum", monotonic)
+		sumDp.SetDoubleVal(sum)
+		return nil, nil
+	}, nil
+}
@@ -0,0 +1,98 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+)
+
+func Test_extractSumMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     pmetric.Metric
+		monotonic bool
+		want      func(pmetric.MetricSlice)
+	}{
+		{
+			name:      "histogram",
+			input:     getTestHistogramMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				sumMetric := metrics.AppendEmpty()
+				sumMetric.SetDataType(pmetric.MetricDataTypeSum)
+				sumMetric.SetName("histogram_metric_sum")
+				sumMetric.SetDescription("a histogram")
+				sumMetric.SetUnit("ms")
+				sumMetric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+				sumMetric.Sum().SetIsMonotonic(false)
+
+				// The second data point has no sum and is skipped.
+				dp := sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetDoubleVal(12.5)
+				dp.SetTimestamp(pcommon.Timestamp(1))
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "exponential histogram",
+			input:     getTestExponentialHistogramMetric(),
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestExponentialHistogramMetric().CopyTo(metrics.AppendEmpty())
+				sumMetric := metrics.AppendEmpty()
+				sumMetric.SetDataType(pmetric.MetricDataTypeSum)
+				sumMetric.SetName("exponential_histogram_metric_sum")
+				sumMetric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+				sumMetric.Sum().SetIsMonotonic(true)
+
+				dp := sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetDoubleVal(8)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "noop for gauge",
+			input:     getTestGaugeMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			exprFunc, err := extractSumMetric(tt.monotonic)
+			assert.NoError(t, err)
+			runOnDataPoints(t, actualMetrics, func(ctx tqlmetrics.TransformContext) {
+				_, err = exprFunc(ctx)
+				assert.NoError(t, err)
+			})
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
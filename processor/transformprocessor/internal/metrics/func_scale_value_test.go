@@ -0,0 +1,93 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+)
+
+func Test_scaleValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor float64
+		input  func(pmetric.NumberDataPoint)
+		want   func(pmetric.NumberDataPoint)
+	}{
+		{
+			name:   "int",
+			factor: 1000,
+			input: func(dp pmetric.NumberDataPoint) {
+				dp.SetIntVal(5)
+			},
+			want: func(dp pmetric.NumberDataPoint) {
+				dp.SetIntVal(5000)
+			},
+		},
+		{
+			name:   "int truncated",
+			factor: 0.5,
+			input: func(dp pmetric.NumberDataPoint) {
+				dp.SetIntVal(5)
+			},
+			want: func(dp pmetric.NumberDataPoint) {
+				dp.SetIntVal(2)
+			},
+		},
+		{
+			name:   "double",
+			factor: 0.001,
+			input: func(dp pmetric.NumberDataPoint) {
+				dp.SetDoubleVal(1500)
+			},
+			want: func(dp pmetric.NumberDataPoint) {
+				dp.SetDoubleVal(1.5)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := pmetric.NewNumberDataPoint()
+			tt.input(dp)
+
+			exprFunc, err := scaleValue(tt.factor)
+			assert.NoError(t, err)
+			_, err = exprFunc(tqlmetrics.NewTransformContext(dp, pmetric.NewMetric(), pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			assert.NoError(t, err)
+
+			expected := pmetric.NewNumberDataPoint()
+			tt.want(expected)
+
+			assert.Equal(t, expected, dp)
+		})
+	}
+}
+
+func Test_scaleValue_noop(t *testing.T) {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.SetSum(10)
+
+	exprFunc, err := scaleValue(2)
+	assert.NoError(t, err)
+	_, err = exprFunc(tqlmetrics.NewTransformContext(dp, pmetric.NewMetric(), pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 10.0, dp.Sum())
+}
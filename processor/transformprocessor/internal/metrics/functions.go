@@ -24,6 +24,18 @@ var registry = map[string]interface{}{
 	"convert_gauge_to_sum":             convertGaugeToSum,
 	"convert_summary_sum_val_to_sum":   convertSummarySumValToSum,
 	"convert_summary_count_val_to_sum": convertSummaryCountValToSum,
+	"aggregate_on_attributes":          aggregateOnAttributes,
+	"aggregate_on_attribute_value":     aggregateOnAttributeValue,
+	"scale_value":                      scaleValue,
+	"extract_count_metric":             extractCountMetric,
+	"extract_sum_metric":               extractSumMetric,
+}
+
+// metricFunctions are the functions which apply to all the data points of a metric. They are called once per
+// metric, after the statements calling the other functions were called for each data point of the metric.
+var metricFunctions = map[string]bool{
+	"aggregate_on_attributes":      true,
+	"aggregate_on_attribute_value": true,
 }
 
 func init() {
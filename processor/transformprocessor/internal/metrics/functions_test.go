@@ -28,6 +28,11 @@ func Test_DefaultFunctions(t *testing.T) {
 	expectedFunctions["convert_gauge_to_sum"] = convertGaugeToSum
 	expectedFunctions["convert_summary_sum_val_to_sum"] = convertSummarySumValToSum
 	expectedFunctions["convert_summary_count_val_to_sum"] = convertSummaryCountValToSum
+	expectedFunctions["aggregate_on_attributes"] = aggregateOnAttributes
+	expectedFunctions["aggregate_on_attribute_value"] = aggregateOnAttributeValue
+	expectedFunctions["scale_value"] = scaleValue
+	expectedFunctions["extract_count_metric"] = extractCountMetric
+	expectedFunctions["extract_sum_metric"] = extractSumMetric
 
 	actual := DefaultFunctions()
 
@@ -18,90 +18,142 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type Processor struct {
 	queries []tql.Query
-	logger  *zap.Logger
+	// metricQueries call the metricFunctions, once per metric
+	metricQueries []tql.Query
+	logger        *zap.Logger
 }
 
 func NewProcessor(statements []string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
-	queries, err := tql.ParseQueries(statements, functions, ParsePath, ParseEnum)
+	queries, err := tql.ParseQueries(statements, functions, tqlmetrics.ParsePath, tqlmetrics.ParseEnum)
 	if err != nil {
 		return nil, err
 	}
-	return &Processor{
-		queries: queries,
-		logger:  settings.Logger,
-	}, nil
+	p := &Processor{
+		logger: settings.Logger,
+	}
+	for _, query := range queries {
+		if metricFunctions[query.FunctionName] {
+			p.metricQueries = append(p.metricQueries, query)
+		} else {
+			p.queries = append(p.queries, query)
+		}
+	}
+	return p, nil
 }
 
 func (p *Processor) ProcessMetrics(_ context.Context, td pmetric.Metrics) (pmetric.Metrics, error) {
-	ctx := metricTransformContext{}
 	for i := 0; i < td.ResourceMetrics().Len(); i++ {
 		rmetrics := td.ResourceMetrics().At(i)
-		ctx.resource = rmetrics.Resource()
 		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
 			smetrics := rmetrics.ScopeMetrics().At(j)
-			ctx.il = smetrics.Scope()
 			metrics := smetrics.Metrics()
-			ctx.metrics = metrics
 			for k := 0; k < metrics.Len(); k++ {
-				ctx.metric = metrics.At(k)
-				switch ctx.metric.DataType() {
+				metric := metrics.At(k)
+				switch metric.DataType() {
 				case pmetric.MetricDataTypeSum:
-					p.handleNumberDataPoints(ctx, ctx.metric.Sum().DataPoints())
+					p.handleNumberDataPoints(metric.Sum().DataPoints(), metric, metrics, smetrics.Scope(), rmetrics.Resource())
 				case pmetric.MetricDataTypeGauge:
-					p.handleNumberDataPoints(ctx, ctx.metric.Gauge().DataPoints())
+					p.handleNumberDataPoints(metric.Gauge().DataPoints(), metric, metrics, smetrics.Scope(), rmetrics.Resource())
 				case pmetric.MetricDataTypeHistogram:
-					p.handleHistogramDataPoints(ctx, ctx.metric.Histogram().DataPoints())
+					p.handleHistogramDataPoints(metric.Histogram().DataPoints(), metric, metrics, smetrics.Scope(), rmetrics.Resource())
 				case pmetric.MetricDataTypeExponentialHistogram:
-					p.handleExponetialHistogramDataPoints(ctx, ctx.metric.ExponentialHistogram().DataPoints())
+					p.handleExponetialHistogramDataPoints(metric.ExponentialHistogram().DataPoints(), metric, metrics, smetrics.Scope(), rmetrics.Resource())
 				case pmetric.MetricDataTypeSummary:
-					p.handleSummaryDataPoints(ctx, ctx.metric.Summary().DataPoints())
+					p.handleSummaryDataPoints(metric.Summary().DataPoints(), metric, metrics, smetrics.Scope(), rmetrics.Resource())
 				}
+				p.handleMetric(metric, metrics, smetrics.Scope(), rmetrics.Resource())
 			}
 		}
 	}
 	return td, nil
 }
 
-func (p *Processor) handleNumberDataPoints(ctx metricTransformContext, dps pmetric.NumberDataPointSlice) {
+func (p *Processor) handleNumberDataPoints(dps pmetric.NumberDataPointSlice, metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) {
 	for i := 0; i < dps.Len(); i++ {
-		ctx.dataPoint = dps.At(i)
-		p.callFunctions(ctx)
+		ctx := tqlmetrics.NewTransformContext(dps.At(i), metric, metrics, il, resource)
+		p.callFunctions(p.queries, ctx)
 	}
 }
 
-func (p *Processor) handleHistogramDataPoints(ctx metricTransformContext, dps pmetric.HistogramDataPointSlice) {
+func (p *Processor) handleHistogramDataPoints(dps pmetric.HistogramDataPointSlice, metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) {
 	for i := 0; i < dps.Len(); i++ {
-		ctx.dataPoint = dps.At(i)
-		p.callFunctions(ctx)
+		ctx := tqlmetrics.NewTransformContext(dps.At(i), metric, metrics, il, resource)
+		p.callFunctions(p.queries, ctx)
 	}
 }
 
-func (p *Processor) handleExponetialHistogramDataPoints(ctx metricTransformContext, dps pmetric.ExponentialHistogramDataPointSlice) {
+func (p *Processor) handleExponetialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) {
 	for i := 0; i < dps.Len(); i++ {
-		ctx.dataPoint = dps.At(i)
-		p.callFunctions(ctx)
+		ctx := tqlmetrics.NewTransformContext(dps.At(i), metric, metrics, il, resource)
+		p.callFunctions(p.queries, ctx)
 	}
 }
 
-func (p *Processor) handleSummaryDataPoints(ctx metricTransformContext, dps pmetric.SummaryDataPointSlice) {
+func (p *Processor) handleSummaryDataPoints(dps pmetric.SummaryDataPointSlice, metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) {
 	for i := 0; i < dps.Len(); i++ {
-		ctx.dataPoint = dps.At(i)
-		p.callFunctions(ctx)
+		ctx := tqlmetrics.NewTransformContext(dps.At(i), metric, metrics, il, resource)
+		p.callFunctions(p.queries, ctx)
 	}
 }
 
-func (p *Processor) callFunctions(ctx metricTransformContext) {
-	for _, statement := range p.queries {
-		if statement.Condition(ctx) {
-			statement.Function(ctx)
+// handleMetric calls the metric queries once for the metric. Their conditions are evaluated against the first data
+// point of the metric, and they are not called for metrics without data points.
+func (p *Processor) handleMetric(metric pmetric.Metric, metrics pmetric.MetricSlice, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+	if len(p.metricQueries) == 0 {
+		return
+	}
+	var dataPoint interface{}
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeSum:
+		if dps := metric.Sum().DataPoints(); dps.Len() > 0 {
+			dataPoint = dps.At(0)
+		}
+	case pmetric.MetricDataTypeGauge:
+		if dps := metric.Gauge().DataPoints(); dps.Len() > 0 {
+			dataPoint = dps.At(0)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		if dps := metric.Histogram().DataPoints(); dps.Len() > 0 {
+			dataPoint = dps.At(0)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		if dps := metric.ExponentialHistogram().DataPoints(); dps.Len() > 0 {
+			dataPoint = dps.At(0)
+		}
+	case pmetric.MetricDataTypeSummary:
+		if dps := metric.Summary().DataPoints(); dps.Len() > 0 {
+			dataPoint = dps.At(0)
+		}
+	}
+	if dataPoint == nil {
+		return
+	}
+	p.callFunctions(p.metricQueries, tqlmetrics.NewTransformContext(dataPoint, metric, metrics, il, resource))
+}
+
+// callFunctions calls the queries whose condition is met. A query which fails is logged and skipped, so that a single
+// data point which cannot be transformed does not drop the rest of the batch.
+func (p *Processor) callFunctions(queries []tql.Query, ctx tqlmetrics.TransformContext) {
+	for _, statement := range queries {
+		condition, err := statement.Condition(ctx)
+		if err != nil {
+			p.logger.Warn("failed to evaluate condition, skipping statement", zap.String("function", statement.FunctionName), zap.Error(err))
+			continue
+		}
+		if condition {
+			if _, err = statement.Function(ctx); err != nil {
+				p.logger.Warn("failed to call function", zap.String("function", statement.FunctionName), zap.Error(err))
+			}
 		}
 	}
 }
@@ -20,7 +20,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -266,6 +268,51 @@ func TestProcess(t *testing.T) {
 				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().InsertString("attr1", "test1")
 			},
 		},
+		{
+			query: []string{`aggregate_on_attributes("sum", "attr1") where metric.name == "operationA"`},
+			want: func(td pmetric.Metrics) {
+				dps := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+				dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+					return dp.ValueType() == pmetric.NumberDataPointValueTypeNone
+				})
+				dps.At(0).Attributes().Remove("attr2")
+				dps.At(0).Attributes().Remove("attr3")
+			},
+		},
+		{
+			query: []string{`scale_value(10.0) where metric.name == "operationA"`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetDoubleVal(10.0)
+			},
+		},
+		{
+			query: []string{`replace_pattern(metric.name, "^operation", "op") where metric.name == "operationA"`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("opA")
+			},
+		},
+		{
+			query: []string{`extract_count_metric(true) where metric.name == "operationB"`},
+			want: func(td pmetric.Metrics) {
+				histogramMetric := pmetric.NewMetric()
+				fillMetricTwo(histogramMetric)
+
+				countMetric := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+				countMetric.SetDataType(pmetric.MetricDataTypeSum)
+				countMetric.SetName(histogramMetric.Name() + "_count")
+				countMetric.SetDescription(histogramMetric.Description())
+				countMetric.SetUnit(histogramMetric.Unit())
+				countMetric.Sum().SetIsMonotonic(true)
+
+				histogramDps := histogramMetric.Histogram().DataPoints()
+				for i := 0; i < histogramDps.Len(); i++ {
+					countDp := countMetric.Sum().DataPoints().AppendEmpty()
+					histogramDps.At(i).Attributes().CopyTo(countDp.Attributes())
+					countDp.SetIntVal(int64(histogramDps.At(i).Count()))
+					countDp.SetStartTimestamp(StartTimestamp)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -285,6 +332,63 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcessMetricFunctionsAfterDataPointStatements(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("operationA")
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	for i, value := range []float64{3, 6, 9} {
+		dp := metric.Gauge().DataPoints().AppendEmpty()
+		dp.SetDoubleVal(value)
+		dp.Attributes().InsertInt("attr", int64(i))
+	}
+
+	processor, err := NewProcessor([]string{
+		`set(attributes["x"], "y")`,
+		`aggregate_on_attributes("mean", "x")`,
+	}, DefaultFunctions(), component.ProcessorCreateSettings{})
+	require.NoError(t, err)
+
+	_, err = processor.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	dps := metric.Gauge().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 6.0, dps.At(0).DoubleVal())
+	assert.Equal(t, map[string]interface{}{"x": "y"}, dps.At(0).Attributes().AsRaw())
+}
+
+func TestProcess_skipsFailedStatements(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("operationA")
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	dps := metric.Gauge().DataPoints()
+	dps.AppendEmpty().Attributes().InsertInt("count", 4)
+	dps.AppendEmpty().Attributes().InsertString("count", "four")
+
+	processor, err := NewProcessor([]string{
+		`set(attributes["half"], attributes["count"] / 2)`,
+		`set(attributes["test"], "pass") where attributes["count"][0] == "f"`,
+		`set(attributes["test"], "pass")`,
+	}, DefaultFunctions(), componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	half, ok := dps.At(0).Attributes().Get("half")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), half.IntVal())
+	_, ok = dps.At(1).Attributes().Get("half")
+	assert.False(t, ok)
+	for i := 0; i < dps.Len(); i++ {
+		test, ok := dps.At(i).Attributes().Get("test")
+		require.True(t, ok)
+		assert.Equal(t, "pass", test.StringVal())
+	}
+}
+
 func constructMetrics() pmetric.Metrics {
 	td := pmetric.NewMetrics()
 	rm0 := td.ResourceMetrics().AppendEmpty()
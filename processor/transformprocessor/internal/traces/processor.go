@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
@@ -30,7 +31,7 @@ type Processor struct {
 }
 
 func NewProcessor(statements []string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
-	queries, err := tql.ParseQueries(statements, functions, ParsePath, ParseEnum)
+	queries, err := tql.ParseQueries(statements, functions, tqltraces.ParsePath, tqltraces.ParseEnum)
 	if err != nil {
 		return nil, err
 	}
@@ -41,20 +42,24 @@ func NewProcessor(statements []string, functions map[string]interface{}, setting
 }
 
 func (p *Processor) ProcessTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	ctx := spanTransformContext{}
 	for i := 0; i < td.ResourceSpans().Len(); i++ {
 		rspans := td.ResourceSpans().At(i)
-		ctx.resource = rspans.Resource()
 		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
 			sspan := rspans.ScopeSpans().At(j)
-			ctx.il = sspan.Scope()
 			spans := sspan.Spans()
 			for k := 0; k < spans.Len(); k++ {
-				ctx.span = spans.At(k)
+				ctx := tqltraces.NewTransformContext(spans.At(k), sspan.Scope(), rspans.Resource())
 
 				for _, statement := range p.queries {
-					if statement.Condition(ctx) {
-						statement.Function(ctx)
+					condition, err := statement.Condition(ctx)
+					if err != nil {
+						p.logger.Warn("failed to evaluate condition, skipping statement", zap.String("function", statement.FunctionName), zap.Error(err))
+						continue
+					}
+					if condition {
+						if _, err = statement.Function(ctx); err != nil {
+							p.logger.Warn("failed to call function", zap.String("function", statement.FunctionName), zap.Error(err))
+						}
 					}
 				}
 			}
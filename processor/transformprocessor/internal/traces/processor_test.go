@@ -20,12 +20,18 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 var (
+	traceID = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID  = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2 = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+
 	TestSpanStartTime      = time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC)
 	TestSpanStartTimestamp = pcommon.NewTimestampFromTime(TestSpanStartTime)
 
@@ -161,6 +167,12 @@ func TestProcess(t *testing.T) {
 				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetKind(2)
 			},
 		},
+		{
+			query: `set(attributes["test"], (end_time_unix_nano - start_time_unix_nano) / 1000000) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertInt("test", TestSpanEndTime.Sub(TestSpanStartTime).Milliseconds())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -180,6 +192,34 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcess_skipsFailedStatements(t *testing.T) {
+	td := constructTraces()
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	spans.At(0).Attributes().InsertInt("count", 4)
+	spans.At(1).Attributes().InsertString("count", "four")
+
+	processor, err := NewProcessor([]string{
+		`set(attributes["half"], attributes["count"] / 2)`,
+		`set(attributes["test"], "pass") where attributes["http.path"][0] == "/"`,
+		`set(attributes["test"], "pass")`,
+	}, DefaultFunctions(), componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	half, ok := spans.At(0).Attributes().Get("half")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), half.IntVal())
+	_, ok = spans.At(1).Attributes().Get("half")
+	assert.False(t, ok)
+	for i := 0; i < spans.Len(); i++ {
+		test, ok := spans.At(i).Attributes().Get("test")
+		require.True(t, ok)
+		assert.Equal(t, "pass", test.StringVal())
+	}
+}
+
 func BenchmarkTwoSpans(b *testing.B) {
 	tests := []struct {
 		name    string
@@ -17,7 +17,7 @@ package filelogreceiver // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"gopkg.in/yaml.v2"
+	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -51,10 +51,10 @@ func createDefaultConfig() *FileLogConfig {
 	return &FileLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 			Converter:        adapter.ConverterConfig{},
 		},
-		Input: adapter.InputConfig{},
+		InputConfig: *file.NewConfig("file_input"),
 	}
 }
 
@@ -66,16 +66,18 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) adapter.BaseConfig {
 // FileLogConfig defines configuration for the filelog receiver
 type FileLogConfig struct {
 	adapter.BaseConfig `mapstructure:",squash"`
-	Input              adapter.InputConfig `mapstructure:",remain"`
+	InputConfig        file.Config `mapstructure:",squash"`
 }
 
-// DecodeInputConfig unmarshals the input operator
-func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
-	logConfig := cfg.(*FileLogConfig)
-	yamlBytes, _ := yaml.Marshal(logConfig.Input)
-	inputCfg := file.NewConfig("file_input")
-	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
-		return nil, err
+// Unmarshal decodes the config, including its input and operators, through mapstructure
+func (cfg *FileLogConfig) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
 	}
-	return &operator.Config{Builder: inputCfg}, nil
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
+}
+
+// InputConfig returns the input operator config
+func (f ReceiverType) InputConfig(cfg config.Receiver) operator.Config {
+	return operator.Config{Builder: &cfg.(*FileLogConfig).InputConfig}
 }
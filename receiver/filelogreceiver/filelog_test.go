@@ -30,12 +30,17 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -57,14 +62,30 @@ func TestLoadConfig(t *testing.T) {
 
 	assert.Equal(t, len(cfg.Receivers), 1)
 
-	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewComponentID("filelog")])
+	assert.Equal(t, testdataConfigYaml(), cfg.Receivers[config.NewComponentID("filelog")])
+}
+
+func TestUnmarshalInvalidOperatorConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]interface{}{
+		"include": []interface{}{"testdata/simple.log"},
+		"operators": []interface{}{
+			map[string]interface{}{
+				"type":   "regex_parser",
+				"regexp": "^(?P<msg>.*)$",
+			},
+		},
+	}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal to regex_parser")
+	require.Contains(t, err.Error(), "regexp")
 }
 
 func TestCreateWithInvalidInputConfig(t *testing.T) {
 	t.Parallel()
 
-	cfg := testdataConfigYamlAsMap()
-	cfg.Input["include"] = "not an array"
+	cfg := testdataConfigYaml()
+	cfg.InputConfig.StartAt = "middle"
 
 	_, err := NewFactory().CreateLogsReceiver(
 		context.Background(),
@@ -83,7 +104,7 @@ func TestReadStaticFile(t *testing.T) {
 	f := NewFactory()
 	sink := new(consumertest.LogsSink)
 
-	cfg := testdataConfigYamlAsMap()
+	cfg := testdataConfigYaml()
 	cfg.Converter.MaxFlushCount = 10
 	cfg.Converter.FlushInterval = time.Millisecond
 
@@ -172,7 +193,7 @@ func (rt *rotationTest) Run(t *testing.T) {
 	f := NewFactory()
 	sink := new(consumertest.LogsSink)
 
-	cfg := testdataRotateTestYamlAsMap(tempDir)
+	cfg := rotationTestConfig(tempDir)
 	cfg.Converter.MaxFlushCount = 1
 	cfg.Converter.FlushInterval = time.Millisecond
 
@@ -252,21 +273,26 @@ func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
 	return func() bool { return sink.LogRecordCount() == expected }
 }
 
-func testdataConfigYamlAsMap() *FileLogConfig {
+func testdataConfigYaml() *FileLogConfig {
 	return &FileLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators: adapter.OperatorConfigs{
-				map[string]interface{}{
-					"type":  "regex_parser",
-					"regex": "^(?P<time>\\d{4}-\\d{2}-\\d{2}) (?P<sev>[A-Z]*) (?P<msg>.*)$",
-					"severity": map[string]interface{}{
-						"parse_from": "attributes.sev",
-					},
-					"timestamp": map[string]interface{}{
-						"layout":     "%Y-%m-%d",
-						"parse_from": "attributes.time",
-					},
+			Operators: []operator.Config{
+				{
+					Builder: func() *regex.Config {
+						cfg := regex.NewConfig("")
+						cfg.Regex = "^(?P<time>\\d{4}-\\d{2}-\\d{2}) (?P<sev>[A-Z]*) (?P<msg>.*)$"
+						sevField := entry.NewAttributeField("sev")
+						sevCfg := helper.NewSeverityConfig()
+						sevCfg.ParseFrom = &sevField
+						cfg.Config = &sevCfg
+						timeField := entry.NewAttributeField("time")
+						cfg.TimeParser = &helper.TimeParser{
+							Layout:    "%Y-%m-%d",
+							ParseFrom: &timeField,
+						}
+						return cfg
+					}(),
 				},
 			},
 			Converter: adapter.ConverterConfig{
@@ -274,39 +300,42 @@ func testdataConfigYamlAsMap() *FileLogConfig {
 				FlushInterval: 100 * time.Millisecond,
 			},
 		},
-		Input: adapter.InputConfig{
-			"include": []interface{}{
-				"testdata/simple.log",
-			},
-			"start_at": "beginning",
-		},
+		InputConfig: func() file.Config {
+			c := file.NewConfig("file_input")
+			c.Include = []string{"testdata/simple.log"}
+			c.StartAt = "beginning"
+			return *c
+		}(),
 	}
 }
 
-func testdataRotateTestYamlAsMap(tempDir string) *FileLogConfig {
+func rotationTestConfig(tempDir string) *FileLogConfig {
 	return &FileLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators: adapter.OperatorConfigs{
-				map[string]interface{}{
-					"type":  "regex_parser",
-					"regex": "^(?P<ts>\\d{4}-\\d{2}-\\d{2}) (?P<msg>[^\n]+)",
-					"timestamp": map[interface{}]interface{}{
-						"layout":     "%Y-%m-%d",
-						"parse_from": "body.ts",
-					},
+			Operators: []operator.Config{
+				{
+					Builder: func() *regex.Config {
+						cfg := regex.NewConfig("")
+						cfg.Regex = "^(?P<ts>\\d{4}-\\d{2}-\\d{2}) (?P<msg>[^\n]+)"
+						timeField := entry.NewBodyField("ts")
+						cfg.TimeParser = &helper.TimeParser{
+							Layout:    "%Y-%m-%d",
+							ParseFrom: &timeField,
+						}
+						return cfg
+					}(),
 				},
 			},
 			Converter: adapter.ConverterConfig{},
 		},
-		Input: adapter.InputConfig{
-			"type": "file_input",
-			"include": []interface{}{
-				fmt.Sprintf("%s/*", tempDir),
-			},
-			"include_file_name": false,
-			"poll_interval":     "10ms",
-			"start_at":          "beginning",
-		},
+		InputConfig: func() file.Config {
+			c := file.NewConfig("file_input")
+			c.Include = []string{fmt.Sprintf("%s/*", tempDir)}
+			c.StartAt = "beginning"
+			c.PollInterval = helper.Duration{Duration: 10 * time.Millisecond}
+			c.IncludeFileName = false
+			return *c
+		}(),
 	}
 }
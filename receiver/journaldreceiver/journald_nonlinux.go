@@ -23,9 +23,11 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 const (
@@ -43,16 +45,23 @@ func NewFactory() component.ReceiverFactory {
 
 type JournaldConfig struct {
 	adapter.BaseConfig `mapstructure:",squash"`
-	Input              adapter.InputConfig `mapstructure:",remain"`
+	Input              map[string]interface{} `mapstructure:",remain"`
+}
+
+func (cfg *JournaldConfig) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
+	}
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
 }
 
 func createDefaultConfig() config.Receiver {
 	return &JournaldConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 		},
-		Input: adapter.InputConfig{},
+		Input: map[string]interface{}{},
 	}
 }
 
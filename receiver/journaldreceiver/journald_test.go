@@ -26,10 +26,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -44,40 +47,43 @@ func TestLoadConfig(t *testing.T) {
 
 	assert.Equal(t, len(cfg.Receivers), 1)
 
-	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewComponentID("journald")])
+	assert.Equal(t, testdataConfigYaml(), cfg.Receivers[config.NewComponentID("journald")])
 }
 
 func TestDecodeInputConfigFailure(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*JournaldConfig)
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]interface{}{
+		"units":     map[string]interface{}{"unit": "ssh"},
+		"priority":  "info",
+		"directory": "/run/log/journal",
+	}))
+	require.Error(t, err, "config unmarshaling should fail if input config isn't valid")
+	require.Contains(t, err.Error(), "units")
+}
+
+func TestCreateWithInvalidInputConfig(t *testing.T) {
 	sink := new(consumertest.LogsSink)
 	factory := NewFactory()
-	badCfg := &JournaldConfig{
-		BaseConfig: adapter.BaseConfig{
-			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"units":     map[string]interface{}{},
-			"priority":  "info",
-			"directory": "/run/log/journal",
-		},
-	}
+	badCfg := factory.CreateDefaultConfig().(*JournaldConfig)
+	badCfg.InputConfig.StartAt = "middle"
 	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), badCfg, sink)
 	require.Error(t, err, "receiver creation should fail if input config isn't valid")
 	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
 }
 
-func testdataConfigYamlAsMap() *JournaldConfig {
+func testdataConfigYaml() *JournaldConfig {
 	return &JournaldConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"units": []interface{}{
-				"ssh",
-			},
-			"directory": "/run/log/journal",
-			"priority":  "info",
+			Operators:        []operator.Config{},
 		},
+		InputConfig: func() journald.Config {
+			c := journald.NewConfig("journald_input")
+			c.Units = []string{"ssh"}
+			c.Priority = "info"
+			dir := "/run/log/journal"
+			c.Directory = &dir
+			return *c
+		}(),
 	}
 }
@@ -0,0 +1,99 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkpointKey is the storage key of the checkpoint
+const checkpointKey = "checkpoint"
+
+// checkpoint identifies the last event processed by the receiver
+type checkpoint struct {
+	ResourceVersion string    `json:"resource_version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// loadCheckpoint gets the client of the configured storage extension and restores the checkpoint
+// persisted by the previous run, if any.
+func (kr *k8seventsReceiver) loadCheckpoint(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[*kr.config.StorageID]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", kr.config.StorageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", kr.config.StorageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindReceiver, kr.config.ID(), "")
+	if err != nil {
+		return err
+	}
+	kr.storageClient = client
+
+	data, err := client.Get(ctx, checkpointKey)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	var cp checkpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		kr.settings.Logger.Warn("Ignoring invalid persisted checkpoint", zap.Error(err))
+		return nil
+	}
+	kr.checkpoint = &cp
+	kr.settings.Logger.Info("Resuming from the persisted checkpoint",
+		zap.String("resource_version", cp.ResourceVersion), zap.Time("timestamp", cp.Timestamp))
+	return nil
+}
+
+// updateCheckpoint moves the checkpoint to the event if it is not older than the current one, and persists it.
+func (kr *k8seventsReceiver) updateCheckpoint(ev *corev1.Event) {
+	if kr.storageClient == nil {
+		return
+	}
+
+	kr.checkpointMu.Lock()
+	defer kr.checkpointMu.Unlock()
+
+	eventTimestamp := getEventTimestamp(ev)
+	if kr.checkpoint != nil && eventTimestamp.Before(kr.checkpoint.Timestamp) {
+		return
+	}
+	kr.checkpoint = &checkpoint{
+		ResourceVersion: ev.ResourceVersion,
+		Timestamp:       eventTimestamp,
+	}
+
+	data, err := json.Marshal(kr.checkpoint)
+	if err != nil {
+		kr.settings.Logger.Error("Failed to marshal the checkpoint", zap.Error(err))
+		return
+	}
+	if err = kr.storageClient.Set(kr.ctx, checkpointKey, data); err != nil {
+		kr.settings.Logger.Error("Failed to persist the checkpoint", zap.Error(err))
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newStorageReceiver(t *testing.T, sink *consumertest.LogsSink) *k8seventsReceiver {
+	storageID := config.NewComponentID("test_storage")
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.Namespaces = []string{"test"}
+	rCfg.StorageID = &storageID
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, sink, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	return r.(*k8seventsReceiver)
+}
+
+func TestCheckpointResume(t *testing.T) {
+	host := &storageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID("test_storage"): &memoryStorage{data: map[string][]byte{}},
+		},
+	}
+
+	sink := new(consumertest.LogsSink)
+	first := newStorageReceiver(t, sink)
+	require.NoError(t, first.Start(context.Background(), host))
+	processed := getEvent()
+	processed.ResourceVersion = "10"
+	first.handleEvent(processed)
+	require.Equal(t, 1, sink.LogRecordCount())
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	// Events which arrived while the receiver was down are older than the start of the next run
+	time.Sleep(10 * time.Millisecond)
+	missed := getEvent()
+	missed.ResourceVersion = "11"
+	missed.FirstTimestamp = v1.NewTime(processed.FirstTimestamp.Add(time.Millisecond))
+
+	second := newStorageReceiver(t, sink)
+	require.NoError(t, second.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, second.Shutdown(context.Background()))
+	}()
+	assert.True(t, processed.FirstTimestamp.Time.Equal(second.startTime))
+
+	assert.False(t, second.allowEvent(processed), "the event of the checkpoint was already processed")
+	assert.True(t, second.allowEvent(missed), "events after the checkpoint are processed")
+
+	older := getEvent()
+	older.ResourceVersion = "9"
+	older.FirstTimestamp = v1.NewTime(processed.FirstTimestamp.Add(-time.Millisecond))
+	assert.False(t, second.allowEvent(older), "events older than the checkpoint are dropped")
+
+	sameTime := getEvent()
+	sameTime.ResourceVersion = "12"
+	sameTime.FirstTimestamp = processed.FirstTimestamp
+	assert.True(t, second.allowEvent(sameTime), "other events at the time of the checkpoint are processed")
+
+	second.handleEvent(missed)
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.Equal(t, "11", second.checkpoint.ResourceVersion)
+	assert.True(t, missed.FirstTimestamp.Time.Equal(second.checkpoint.Timestamp))
+
+	// The checkpoint does not go back to older events
+	second.handleEvent(sameTime)
+	assert.Equal(t, 3, sink.LogRecordCount())
+	assert.Equal(t, "11", second.checkpoint.ResourceVersion)
+}
+
+func TestCheckpointInvalid(t *testing.T) {
+	host := &storageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID("test_storage"): &memoryStorage{data: map[string][]byte{checkpointKey: []byte("{")}},
+		},
+	}
+
+	r := newStorageReceiver(t, new(consumertest.LogsSink))
+	startTime := r.startTime
+	require.NoError(t, r.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+	assert.Nil(t, r.checkpoint)
+	assert.Equal(t, startTime, r.startTime)
+}
+
+func TestCheckpointStorageNotFound(t *testing.T) {
+	r := newStorageReceiver(t, new(consumertest.LogsSink))
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), `storage extension "test_storage" not found`)
+}
+
+func TestCheckpointNotStorageExtension(t *testing.T) {
+	host := &storageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID("test_storage"): &nopExtension{},
+		},
+	}
+	r := newStorageReceiver(t, new(consumertest.LogsSink))
+	assert.EqualError(t, r.Start(context.Background(), host), `extension "test_storage" is not a storage extension`)
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// memoryStorage is a storage extension keeping the data of its clients in memory.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return m, nil
+}
+
+func (m *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	return m.data[key], nil
+}
+
+func (m *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryStorage) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = m.Get(ctx, op.Key)
+		case storage.Set:
+			err = m.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = m.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStorage) Close(context.Context) error {
+	return nil
+}
@@ -29,6 +29,10 @@ type Config struct {
 	// List of ‘namespaces’ to collect events from.
 	Namespaces []string `mapstructure:"namespaces"`
 
+	// StorageID is the ID of the storage extension, such as file_storage, keeping the checkpoint
+	// of the last processed event across restarts. Optional.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
 	// For mocking
 	makeClient func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
 }
@@ -45,10 +45,12 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, r1, factory.CreateDefaultConfig())
 
 	r2 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "all_settings")].(*Config)
+	storageID := config.NewComponentID("file_storage")
 	assert.Equal(t, r2,
 		&Config{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "all_settings")),
 			Namespaces:       []string{"default", "my_namespace"},
+			StorageID:        &storageID,
 			APIConfig: k8sconfig.APIConfig{
 				AuthType: k8sconfig.AuthTypeServiceAccount,
 			},
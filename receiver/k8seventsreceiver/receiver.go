@@ -16,10 +16,12 @@ package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/obsreport"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -37,6 +39,13 @@ type k8seventsReceiver struct {
 	ctx             context.Context
 	cancel          context.CancelFunc
 	obsrecv         *obsreport.Receiver
+
+	// storageClient persists the checkpoint when a storage extension is configured
+	storageClient storage.Client
+	// resumeVersion is the resourceVersion of the last event processed by the previous run
+	resumeVersion string
+	checkpoint    *checkpoint
+	checkpointMu  sync.Mutex
 }
 
 // newReceiver creates the Kubernetes events receiver with the given configuration.
@@ -65,6 +74,16 @@ func newReceiver(
 func (kr *k8seventsReceiver) Start(ctx context.Context, host component.Host) error {
 	kr.ctx, kr.cancel = context.WithCancel(ctx)
 
+	if kr.config.StorageID != nil {
+		if err := kr.loadCheckpoint(ctx, host); err != nil {
+			return err
+		}
+		if kr.checkpoint != nil {
+			kr.startTime = kr.checkpoint.Timestamp
+			kr.resumeVersion = kr.checkpoint.ResourceVersion
+		}
+	}
+
 	kr.settings.Logger.Info("starting to watch namespaces for the events.")
 	if len(kr.config.Namespaces) == 0 {
 		kr.startWatch(corev1.NamespaceAll)
@@ -77,12 +96,15 @@ func (kr *k8seventsReceiver) Start(ctx context.Context, host component.Host) err
 	return nil
 }
 
-func (kr *k8seventsReceiver) Shutdown(context.Context) error {
+func (kr *k8seventsReceiver) Shutdown(ctx context.Context) error {
 	// Stop watching all the namespaces by closing all the stopper channels.
 	for _, stopperChan := range kr.stopperChanList {
 		close(stopperChan)
 	}
 	kr.cancel()
+	if kr.storageClient != nil {
+		return kr.storageClient.Close(ctx)
+	}
 	return nil
 }
 
@@ -111,6 +133,9 @@ func (kr *k8seventsReceiver) handleEvent(ev *corev1.Event) {
 		ctx := kr.obsrecv.StartLogsOp(kr.ctx)
 		consumerErr := kr.logsConsumer.ConsumeLogs(ctx, ld)
 		kr.obsrecv.EndLogsOp(ctx, typeStr, 1, consumerErr)
+		if consumerErr == nil {
+			kr.updateCheckpoint(ev)
+		}
 	}
 }
 
@@ -129,11 +154,15 @@ func (kr *k8seventsReceiver) startWatchingNamespace(
 }
 
 // Allow events with eventTimestamp(EventTime/LastTimestamp/FirstTimestamp)
-// not older than the receiver start time so that
-// event flood can be avoided upon startup.
+// not older than the receiver start time, or than the persisted checkpoint,
+// so that event flood can be avoided upon startup.
 func (kr *k8seventsReceiver) allowEvent(ev *corev1.Event) bool {
 	eventTimestamp := getEventTimestamp(ev)
-	return !eventTimestamp.Before(kr.startTime)
+	if eventTimestamp.Before(kr.startTime) {
+		return false
+	}
+	// The event of the checkpoint was already processed by the previous run
+	return kr.resumeVersion == "" || ev.ResourceVersion != kr.resumeVersion || !eventTimestamp.Equal(kr.startTime)
 }
 
 // Return the EventTimestamp based on the populated k8s event timestamps.
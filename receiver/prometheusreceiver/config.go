@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -28,6 +29,7 @@ import (
 	commonconfig "github.com/prometheus/common/config"
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery/file"
+	promHTTP "github.com/prometheus/prometheus/discovery/http"
 	"github.com/prometheus/prometheus/discovery/kubernetes"
 	"github.com/prometheus/prometheus/discovery/targetgroup"
 	"go.opentelemetry.io/collector/config"
@@ -38,6 +40,12 @@ import (
 const (
 	// The key for Prometheus scraping configs.
 	prometheusConfigKey = "config"
+	// The key for the target allocator configs.
+	targetAllocatorConfigKey = "target_allocator"
+	// The key for the HTTP service discovery configs of the target allocator.
+	targetAllocatorHTTPSDConfigKey = "http_sd_config"
+
+	defaultTargetAllocatorInterval = 30 * time.Second
 )
 
 // Config defines configuration for Prometheus receiver.
@@ -59,6 +67,29 @@ type Config struct {
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
 	ConfigPlaceholder interface{} `mapstructure:"config"`
+
+	// TargetAllocator configures the target allocator the receiver regularly pulls its
+	// scrape jobs and targets from, in addition to the ones of the Prometheus config.
+	TargetAllocator *TargetAllocator `mapstructure:"target_allocator"`
+}
+
+// TargetAllocator defines the configuration of the target allocator.
+type TargetAllocator struct {
+	// Endpoint is the base URL of the target allocator, e.g.: http://target-allocator:80.
+	Endpoint string `mapstructure:"endpoint"`
+	// Interval is the period between two syncs of the scrape jobs. Defaults to 30s.
+	Interval time.Duration `mapstructure:"interval"`
+	// CollectorID identifies the receiver to the target allocator, which only returns
+	// the targets assigned to it.
+	CollectorID string `mapstructure:"collector_id"`
+	// HTTPSDConfig is the Prometheus HTTP service discovery config used to retrieve
+	// the targets of every job. Its URL is ignored, as it is set for each job.
+	HTTPSDConfig *promHTTP.SDConfig `mapstructure:"-"`
+
+	// ConfigPlaceholder is just an entry to make the configuration pass a check
+	// that requires that all keys present in the config actually exist on the
+	// structure, ie.: it will error if an unknown key is present.
+	ConfigPlaceholder interface{} `mapstructure:"http_sd_config"`
 }
 
 var _ config.Receiver = (*Config)(nil)
@@ -128,12 +159,17 @@ func checkSDFile(filename string) error {
 
 // Validate checks the receiver configuration is valid.
 func (cfg *Config) Validate() error {
+	if cfg.TargetAllocator != nil {
+		if err := cfg.TargetAllocator.validate(); err != nil {
+			return err
+		}
+	}
 	promConfig := cfg.PrometheusConfig
 	if promConfig == nil {
-		return nil // noop receiver
+		return nil // noop receiver, or scrape jobs assigned by the target allocator only
 	}
-	if len(promConfig.ScrapeConfigs) == 0 {
-		return errors.New("no Prometheus scrape_configs")
+	if len(promConfig.ScrapeConfigs) == 0 && cfg.TargetAllocator == nil {
+		return errors.New("no Prometheus scrape_configs or target_allocator")
 	}
 
 	// Reject features that Prometheus supports but that the receiver doesn't support:
@@ -209,6 +245,29 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+func (ta *TargetAllocator) validate() error {
+	if ta.Endpoint == "" {
+		return errors.New("target_allocator endpoint must be set")
+	}
+	endpoint, err := url.Parse(ta.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid target_allocator endpoint %q: %w", ta.Endpoint, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return fmt.Errorf("target_allocator endpoint %q must use the http or https scheme", ta.Endpoint)
+	}
+	if ta.CollectorID == "" {
+		return errors.New("target_allocator collector_id must be set")
+	}
+	if ta.Interval <= 0 {
+		return fmt.Errorf("target_allocator interval must be positive, got %v", ta.Interval)
+	}
+	if ta.HTTPSDConfig != nil {
+		return checkTLSConfig(ta.HTTPSDConfig.HTTPClientConfig.TLSConfig)
+	}
+	return nil
+}
+
 // Unmarshal a config.Parser into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
@@ -222,6 +281,10 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 		return fmt.Errorf("prometheus receiver failed to parse config: %w", err)
 	}
 
+	if err = cfg.unmarshalTargetAllocator(componentParser); err != nil {
+		return err
+	}
+
 	// Unmarshal prometheus's config values. Since prometheus uses `yaml` tags, so use `yaml`.
 	promCfg, err := componentParser.Sub(prometheusConfigKey)
 	if err != nil || len(promCfg.ToStringMap()) == 0 {
@@ -239,3 +302,37 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 
 	return nil
 }
+
+// unmarshalTargetAllocator sets the defaults of the target allocator config and unmarshals
+// its HTTP service discovery config, which also defines its own YAML unmarshaling routines.
+func (cfg *Config) unmarshalTargetAllocator(componentParser *confmap.Conf) error {
+	if cfg.TargetAllocator == nil {
+		return nil
+	}
+	if cfg.TargetAllocator.Interval == 0 {
+		cfg.TargetAllocator.Interval = defaultTargetAllocatorInterval
+	}
+
+	taCfg, err := componentParser.Sub(targetAllocatorConfigKey)
+	if err != nil {
+		return err
+	}
+	httpSDCfg, err := taCfg.Sub(targetAllocatorHTTPSDConfigKey)
+	if err != nil {
+		return err
+	}
+	httpSDMap := httpSDCfg.ToStringMap()
+	if len(httpSDMap) == 0 {
+		return nil
+	}
+	// The URL is required by the Prometheus unmarshaling but replaced for every job.
+	httpSDMap["url"] = "http://placeholder"
+	out, err := yaml.Marshal(httpSDMap)
+	if err != nil {
+		return fmt.Errorf("prometheus receiver failed to marshal target allocator config to yaml: %w", err)
+	}
+	if err = yaml.UnmarshalStrict(out, &cfg.TargetAllocator.HTTPSDConfig); err != nil {
+		return fmt.Errorf("prometheus receiver failed to unmarshal yaml to target allocator http_sd_config: %w", err)
+	}
+	return nil
+}
@@ -50,6 +50,46 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, r1.StartTimeMetricRegex, "^(.+_)*process_start_time_seconds$")
 }
 
+func TestLoadTargetAllocatorConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config_target_allocator.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	assert.Nil(t, r0.PrometheusConfig)
+	require.NotNil(t, r0.TargetAllocator)
+	assert.Equal(t, "http://localhost:8080", r0.TargetAllocator.Endpoint)
+	assert.Equal(t, 30*time.Second, r0.TargetAllocator.Interval)
+	assert.Equal(t, "collector-1", r0.TargetAllocator.CollectorID)
+	require.NotNil(t, r0.TargetAllocator.HTTPSDConfig)
+	assert.Equal(t, 60*time.Second, time.Duration(r0.TargetAllocator.HTTPSDConfig.RefreshInterval))
+	require.NotNil(t, r0.TargetAllocator.HTTPSDConfig.HTTPClientConfig.BasicAuth)
+	assert.Equal(t, "prometheus", r0.TargetAllocator.HTTPSDConfig.HTTPClientConfig.BasicAuth.Username)
+}
+
+func TestTargetAllocatorInvalidConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfig(filepath.Join("testdata", "invalid-config-prometheus-target-allocator.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	err = cfg.Validate()
+	require.NotNil(t, err, "Expected a non-nil error")
+
+	wantErrMsg := `receiver "prometheus" has invalid configuration: target_allocator endpoint "localhost:8080" must use the http or https scheme`
+
+	gotErrMsg := err.Error()
+	require.Equal(t, wantErrMsg, gotErrMsg)
+}
+
 func TestLoadConfigFailsOnUnknownSection(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	assert.NoError(t, err)
@@ -20,7 +20,9 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/storage"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -105,6 +107,17 @@ func (t *transaction) AppendExemplar(ref storage.SeriesRef, l labels.Labels, e e
 	return 0, nil
 }
 
+// AppendHistogram ignores native histograms, which are only scraped when the native histograms
+// feature of the scrape manager is enabled.
+func (t *transaction) AppendHistogram(ref storage.SeriesRef, l labels.Labels, atMs int64, h *histogram.Histogram) (storage.SeriesRef, error) {
+	return 0, nil
+}
+
+// UpdateMetadata is a no-op, the metadata of the metrics is retrieved from the scrape context.
+func (t *transaction) UpdateMetadata(ref storage.SeriesRef, l labels.Labels, m metadata.Metadata) (storage.SeriesRef, error) {
+	return 0, nil
+}
+
 func (t *transaction) initTransaction(labels labels.Labels) error {
 	metadataCache, err := getMetadataCache(t.ctx)
 	if err != nil {
@@ -16,10 +16,17 @@ package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
 	"time"
 
+	commonconfig "github.com/prometheus/common/config"
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
+	promHTTP "github.com/prometheus/prometheus/discovery/http"
 	"github.com/prometheus/prometheus/scrape"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -39,8 +46,14 @@ type pReceiver struct {
 	consumer   consumer.Metrics
 	cancelFunc context.CancelFunc
 
-	settings      component.ReceiverCreateSettings
-	scrapeManager *scrape.Manager
+	settings         component.ReceiverCreateSettings
+	scrapeManager    *scrape.Manager
+	discoveryManager *discovery.Manager
+}
+
+// linkJSON is the link to the targets of a job in the response of the target allocator.
+type linkJSON struct {
+	Link string `json:"_link"`
 }
 
 // New creates a new prometheus.Receiver reference.
@@ -61,43 +74,149 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 
 	logger := internal.NewZapToGokitLogAdapter(r.settings.Logger)
 
-	discoveryManager := discovery.NewManager(discoveryCtx, logger)
-	discoveryCfg := make(map[string]discovery.Configs)
-	for _, scrapeConfig := range r.cfg.PrometheusConfig.ScrapeConfigs {
-		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
+	baseCfg := r.cfg.PrometheusConfig
+	if baseCfg == nil {
+		baseCfg = &config.Config{GlobalConfig: config.DefaultGlobalConfig}
 	}
-	if err := discoveryManager.ApplyConfig(discoveryCfg); err != nil {
-		return err
-	}
-	go func() {
-		if err := discoveryManager.Run(); err != nil {
-			r.settings.Logger.Error("Discovery manager failed", zap.Error(err))
-			host.ReportFatalError(err)
-		}
-	}()
 
+	r.discoveryManager = discovery.NewManager(discoveryCtx, logger)
 	store := internal.NewAppendable(
 		r.consumer,
 		r.settings,
-		gcInterval(r.cfg.PrometheusConfig),
+		gcInterval(baseCfg),
 		r.cfg.UseStartTimeMetric,
 		r.cfg.StartTimeMetricRegex,
 		r.cfg.ID(),
-		r.cfg.PrometheusConfig.GlobalConfig.ExternalLabels,
+		baseCfg.GlobalConfig.ExternalLabels,
 	)
 	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, store)
-	if err := r.scrapeManager.ApplyConfig(r.cfg.PrometheusConfig); err != nil {
+	if err := r.applyCfg(baseCfg); err != nil {
 		return err
 	}
+
+	go func() {
+		if err := r.discoveryManager.Run(); err != nil {
+			r.settings.Logger.Error("Discovery manager failed", zap.Error(err))
+			host.ReportFatalError(err)
+		}
+	}()
 	go func() {
-		if err := r.scrapeManager.Run(discoveryManager.SyncCh()); err != nil {
+		if err := r.scrapeManager.Run(r.discoveryManager.SyncCh()); err != nil {
 			r.settings.Logger.Error("Scrape manager failed", zap.Error(err))
 			host.ReportFatalError(err)
 		}
 	}()
+
+	if allocConf := r.cfg.TargetAllocator; allocConf != nil {
+		client, err := newTargetAllocatorClient(allocConf)
+		if err != nil {
+			return err
+		}
+		go r.runTargetAllocatorSync(discoveryCtx, client, allocConf, baseCfg)
+	}
 	return nil
 }
 
+// applyCfg applies the scrape configs to the discovery and scrape managers.
+func (r *pReceiver) applyCfg(cfg *config.Config) error {
+	discoveryCfg := make(map[string]discovery.Configs)
+	for _, scrapeConfig := range cfg.ScrapeConfigs {
+		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
+	}
+	if err := r.discoveryManager.ApplyConfig(discoveryCfg); err != nil {
+		return err
+	}
+	return r.scrapeManager.ApplyConfig(cfg)
+}
+
+func newTargetAllocatorClient(allocConf *TargetAllocator) (*http.Client, error) {
+	httpClientConfig := commonconfig.DefaultHTTPClientConfig
+	if allocConf.HTTPSDConfig != nil {
+		httpClientConfig = allocConf.HTTPSDConfig.HTTPClientConfig
+	}
+	return commonconfig.NewClientFromConfig(httpClientConfig, "target_allocator")
+}
+
+// runTargetAllocatorSync syncs the scrape jobs with the target allocator right away,
+// then every interval until the context is done.
+func (r *pReceiver) runTargetAllocatorSync(ctx context.Context, client *http.Client, allocConf *TargetAllocator, baseCfg *config.Config) {
+	ticker := time.NewTicker(allocConf.Interval)
+	defer ticker.Stop()
+
+	var jobs map[string]linkJSON
+	for {
+		newJobs, err := r.syncTargetAllocator(ctx, client, jobs, allocConf, baseCfg)
+		if err != nil {
+			r.settings.Logger.Error("Failed to sync the scrape jobs of the target allocator", zap.Error(err))
+		} else {
+			jobs = newJobs
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncTargetAllocator retrieves the scrape jobs from the target allocator and, when they differ from
+// the current jobs, applies them along with the scrape configs of baseCfg. It returns the retrieved jobs.
+func (r *pReceiver) syncTargetAllocator(ctx context.Context, client *http.Client, currentJobs map[string]linkJSON, allocConf *TargetAllocator, baseCfg *config.Config) (map[string]linkJSON, error) {
+	r.settings.Logger.Debug("Syncing target allocator jobs")
+	jobs, err := getJobResponse(ctx, client, allocConf.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if currentJobs != nil && reflect.DeepEqual(jobs, currentJobs) {
+		return jobs, nil
+	}
+
+	cfg := *baseCfg
+	cfg.ScrapeConfigs = append([]*config.ScrapeConfig{}, baseCfg.ScrapeConfigs...)
+	for jobName, link := range jobs {
+		httpSD := promHTTP.DefaultSDConfig
+		if allocConf.HTTPSDConfig != nil {
+			httpSD = *allocConf.HTTPSDConfig
+		}
+		httpSD.URL = fmt.Sprintf("%s%s?collector_id=%s", allocConf.Endpoint, link.Link, url.QueryEscape(allocConf.CollectorID))
+
+		scrapeCfg := config.DefaultScrapeConfig
+		scrapeCfg.JobName = jobName
+		scrapeCfg.ScrapeInterval = cfg.GlobalConfig.ScrapeInterval
+		scrapeCfg.ScrapeTimeout = cfg.GlobalConfig.ScrapeTimeout
+		scrapeCfg.ServiceDiscoveryConfigs = discovery.Configs{&httpSD}
+		cfg.ScrapeConfigs = append(cfg.ScrapeConfigs, &scrapeCfg)
+	}
+
+	if err = r.applyCfg(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to apply the scrape jobs of the target allocator: %w", err)
+	}
+	return jobs, nil
+}
+
+// getJobResponse retrieves the scrape jobs, and the links to their targets, from the target allocator.
+func getJobResponse(ctx context.Context, client *http.Client, endpoint string) (map[string]linkJSON, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/jobs", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q retrieving the jobs of the target allocator", resp.Status)
+	}
+
+	jobs := make(map[string]linkJSON)
+	if err = json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode the jobs of the target allocator: %w", err)
+	}
+	return jobs, nil
+}
+
 // gcInterval returns the longest scrape interval used by a scrape config,
 // plus a delta to prevent race conditions.
 // This ensures jobs are not garbage collected between scrapes.
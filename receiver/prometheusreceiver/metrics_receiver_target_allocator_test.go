@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promConfig "github.com/prometheus/prometheus/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+// mockTargetAllocator serves the jobs and targets assigned to a collector, like the target allocator.
+type mockTargetAllocator struct {
+	t           *testing.T
+	collectorID string
+	mu          sync.Mutex
+	// jobs maps the job names to their targets.
+	jobs map[string][]string
+}
+
+func (m *mockTargetAllocator) setJobs(jobs map[string][]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs = jobs
+}
+
+func (m *mockTargetAllocator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/jobs" {
+		links := make(map[string]linkJSON, len(m.jobs))
+		for job := range m.jobs {
+			links[job] = linkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(job))}
+		}
+		assert.NoError(m.t, json.NewEncoder(w).Encode(links))
+		return
+	}
+
+	for job, targets := range m.jobs {
+		if r.URL.Path != fmt.Sprintf("/jobs/%s/targets", job) {
+			continue
+		}
+		assert.Equal(m.t, m.collectorID, r.URL.Query().Get("collector_id"))
+		groups := []map[string]interface{}{{"targets": targets, "labels": map[string]string{}}}
+		assert.NoError(m.t, json.NewEncoder(w).Encode(groups))
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func TestTargetAllocatorJobsAreScraped(t *testing.T) {
+	metricsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# TYPE go_threads gauge\ngo_threads 19\n"))
+	}))
+	defer metricsServer.Close()
+	metricsURL, err := url.Parse(metricsServer.URL)
+	require.NoError(t, err)
+
+	allocator := &mockTargetAllocator{
+		t:           t,
+		collectorID: "collector-1",
+		jobs:        map[string][]string{"job1": {metricsURL.Host}},
+	}
+	allocatorServer := httptest.NewServer(allocator)
+	defer allocatorServer.Close()
+
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		PrometheusConfig: &promConfig.Config{
+			GlobalConfig: promConfig.GlobalConfig{
+				ScrapeInterval: model.Duration(100 * time.Millisecond),
+				ScrapeTimeout:  model.Duration(100 * time.Millisecond),
+			},
+		},
+		TargetAllocator: &TargetAllocator{
+			Endpoint:    allocatorServer.URL,
+			Interval:    100 * time.Millisecond,
+			CollectorID: "collector-1",
+		},
+	}
+	sink := new(consumertest.MetricsSink)
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(receiver.scrapeManager.TargetsActive()["job1"]) == 1 && sink.DataPointCount() > 0
+	}, 30*time.Second, 100*time.Millisecond, "the job assigned by the target allocator should be scraped")
+
+	// The scrape manager is reloaded when the target allocator assigns other jobs.
+	allocator.setJobs(map[string][]string{"job2": {metricsURL.Host}})
+	assert.Eventually(t, func() bool {
+		targets := receiver.scrapeManager.TargetsActive()
+		_, hasJob1 := targets["job1"]
+		return !hasJob1 && len(targets["job2"]) == 1
+	}, 30*time.Second, 100*time.Millisecond, "the jobs should follow the target allocator")
+}
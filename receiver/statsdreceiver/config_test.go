@@ -53,8 +53,12 @@ func TestLoadConfig(t *testing.T) {
 			Endpoint:  "localhost:12345",
 			Transport: "custom_transport",
 		},
-		AggregationInterval:   70 * time.Second,
-		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}},
+		AggregationInterval: 70 * time.Second,
+		TimerHistogramMapping: []protocol.TimerHistogramMapping{
+			{StatsdType: "histogram", ObserverType: "gauge"},
+			{StatsdType: "timing", ObserverType: "gauge"},
+			{StatsdType: "distribution", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: 170}},
+		},
 	}, r1)
 }
 
@@ -70,6 +74,8 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		histogramWithoutObserverErr    = "histogram configuration requires observer_type: histogram"
+		histogramMaxSizeErr            = "histogram max_size must be at least 2: %d"
 	)
 
 	tests := []test{
@@ -123,6 +129,26 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "HistogramConfigWithoutHistogramObserver",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "summary", Histogram: protocol.HistogramConfig{MaxSize: 100}},
+				},
+			},
+			expectedErr: histogramWithoutObserverErr,
+		},
+		{
+			name: "HistogramMaxSizeTooSmall",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "distribution", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: 1}},
+				},
+			},
+			expectedErr: fmt.Sprintf(histogramMaxSizeErr, 1),
+		},
 	}
 
 	for _, test := range tests {
@@ -21,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"gonum.org/v1/gonum/stat"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohistogram"
 )
 
 var (
@@ -102,6 +104,36 @@ func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, sta
 	}
 }
 
+func buildExponentialHistogramMetric(desc statsDMetricDescription, histogram *expohistogram.Histogram, startTime, timeNow time.Time, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	nm.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	nm.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+
+	dp := nm.ExponentialHistogram().DataPoints().AppendEmpty()
+	histogram.CopyTo(dp)
+
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().InsertString(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
+// buildSetMetric reports the number of unique values of the set received during the interval.
+func buildSetMetric(desc statsDMetricDescription, uniqueValues int, timeNow time.Time, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	nm.SetDataType(pmetric.MetricDataTypeGauge)
+
+	dp := nm.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(int64(uniqueValues))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().InsertString(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
 func (s statsDMetric) counterValue() int64 {
 	x := s.asFloat
 	// Note statds counters are always represented as integers.
@@ -23,6 +23,8 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohistogram"
 )
 
 var (
@@ -33,33 +35,46 @@ var (
 type (
 	MetricType   string // From the statsd line e.g., "c", "g", "h"
 	TypeName     string // How humans describe the MetricTypes ("counter", "gauge")
-	ObserverType string // How the server will aggregate histogram and timings ("gauge", "summary")
+	ObserverType string // How the server will aggregate histogram and timings ("gauge", "summary", "histogram")
 )
 
 const (
 	tagMetricType = "metric_type"
 
-	CounterType   MetricType = "c"
-	GaugeType     MetricType = "g"
-	HistogramType MetricType = "h"
-	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
-
-	GaugeObserver   ObserverType = "gauge"
-	SummaryObserver ObserverType = "summary"
-	DisableObserver ObserverType = "disabled"
+	CounterType      MetricType = "c"
+	GaugeType        MetricType = "g"
+	HistogramType    MetricType = "h"
+	TimingType       MetricType = "ms"
+	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
+
+	GaugeObserver     ObserverType = "gauge"
+	SummaryObserver   ObserverType = "summary"
+	HistogramObserver ObserverType = "histogram"
+	DisableObserver   ObserverType = "disabled"
 
 	DefaultObserverType = DisableObserver
 )
 
 type TimerHistogramMapping struct {
-	StatsdType   TypeName     `mapstructure:"statsd_type"`
-	ObserverType ObserverType `mapstructure:"observer_type"`
+	StatsdType   TypeName        `mapstructure:"statsd_type"`
+	ObserverType ObserverType    `mapstructure:"observer_type"`
+	Histogram    HistogramConfig `mapstructure:"histogram"`
+}
+
+// HistogramConfig configures the exponential histograms of the histogram observer.
+type HistogramConfig struct {
+	// MaxSize is the maximum number of buckets of the positive and the negative
+	// ranges of the histograms. The default is expohistogram.DefaultMaxSize.
+	MaxSize int32 `mapstructure:"max_size"`
 }
 
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
@@ -67,11 +82,15 @@ type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
+	histograms             map[statsDMetricDescription]*expohistogram.Histogram
+	sets                   map[statsDMetricDescription]map[string]struct{}
 	timersAndDistributions []pmetric.ScopeMetrics
 	enableMetricType       bool
 	isMonotonicCounter     bool
 	observeTimer           ObserverType
 	observeHistogram       ObserverType
+	observeDistribution    ObserverType
+	histogramMaxSizes      map[MetricType]int32
 	lastIntervalTime       time.Time
 }
 
@@ -88,6 +107,7 @@ type summaryMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
+	setValue    string
 	addition    bool
 	unit        string
 	sampleRate  float64
@@ -109,6 +129,10 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case DistributionType:
+		return DistributionTypeName
+	case SetType:
+		return SetTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -119,9 +143,13 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.timersAndDistributions = make([]pmetric.ScopeMetrics, 0)
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]*expohistogram.Histogram)
+	p.sets = make(map[statsDMetricDescription]map[string]struct{})
 
 	p.observeHistogram = DefaultObserverType
 	p.observeTimer = DefaultObserverType
+	p.observeDistribution = DefaultObserverType
+	p.histogramMaxSizes = make(map[MetricType]int32)
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).vaidate()
@@ -129,8 +157,13 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		switch eachMap.StatsdType {
 		case HistogramTypeName:
 			p.observeHistogram = eachMap.ObserverType
+			p.histogramMaxSizes[HistogramType] = eachMap.Histogram.MaxSize
 		case TimingTypeName, TimingAltTypeName:
 			p.observeTimer = eachMap.ObserverType
+			p.histogramMaxSizes[TimingType] = eachMap.Histogram.MaxSize
+		case DistributionTypeName:
+			p.observeDistribution = eachMap.ObserverType
+			p.histogramMaxSizes[DistributionType] = eachMap.Histogram.MaxSize
 		}
 	}
 	return nil
@@ -164,10 +197,26 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 		)
 	}
 
+	for desc, histogram := range p.histograms {
+		buildExponentialHistogramMetric(
+			desc,
+			histogram,
+			p.lastIntervalTime,
+			timeNowFunc(),
+			rm.ScopeMetrics().AppendEmpty(),
+		)
+	}
+
+	for desc, values := range p.sets {
+		buildSetMetric(desc, len(values), timeNowFunc(), rm.ScopeMetrics().AppendEmpty())
+	}
+
 	p.gauges = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.timersAndDistributions = make([]pmetric.ScopeMetrics, 0)
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]*expohistogram.Histogram)
+	p.sets = make(map[statsDMetricDescription]map[string]struct{})
 	return metrics
 }
 
@@ -179,6 +228,8 @@ func (p *StatsDParser) observerTypeFor(t MetricType) ObserverType {
 		return p.observeHistogram
 	case TimingType:
 		return p.observeTimer
+	case DistributionType:
+		return p.observeDistribution
 	}
 	return DisableObserver
 }
@@ -214,7 +265,15 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntVal(point.IntVal() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case SetType:
+		values, ok := p.sets[parsedMetric.description]
+		if !ok {
+			values = make(map[string]struct{})
+			p.sets[parsedMetric.description] = values
+		}
+		values[parsedMetric.setValue] = struct{}{}
+
+	case TimingType, HistogramType, DistributionType:
 		switch p.observerTypeFor(parsedMetric.description.metricType) {
 		case GaugeObserver:
 			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
@@ -231,6 +290,15 @@ func (p *StatsDParser) Aggregate(line string) error {
 					weights: append(existing.weights, raw.count),
 				}
 			}
+		case HistogramObserver:
+			raw := parsedMetric.summaryValue()
+			histogram, ok := p.histograms[parsedMetric.description]
+			if !ok {
+				histogram = expohistogram.New(p.histogramMaxSizes[parsedMetric.description.metricType])
+				p.histograms[parsedMetric.description] = histogram
+			}
+			// Note: the count is rounded here, see note in counterValue().
+			histogram.Update(raw.value, uint64(raw.count))
 		case DisableObserver:
 			// No action.
 		}
@@ -266,7 +334,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -305,10 +373,16 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+	// Set values are only compared with each other, they can be any string.
+	if result.description.metricType == SetType {
+		result.setValue = valueStr
+		result.addition = false
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
@@ -82,6 +82,29 @@ func Test_ParseMessageToMetric(t *testing.T) {
 			input: "test.metric:42.abc|c",
 			err:   errors.New("parse metric value string: 42.abc"),
 		},
+		{
+			name:  "distribution metric",
+			input: "test.metric:42.5|d|#key:value",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42.5,
+				false,
+				"d",
+				0,
+				[]string{"key"},
+				[]string{"value"}),
+		},
+		{
+			name:  "set metric with a non numeric value",
+			input: "test.metric:-user1|s",
+			wantMetric: statsDMetric{
+				description: statsDMetricDescription{
+					name:       "test.metric",
+					metricType: "s",
+				},
+				setValue: "-user1",
+			},
+		},
 		{
 			name:  "unhandled metric type",
 			input: "test.metric:42|unhandled_type",
@@ -1004,6 +1027,85 @@ func TestStatsDParser_Mappings(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_AggregateSets(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, nil)
+	for _, line := range []string{
+		"statsdTestMetric1:user1|s|#mykey:myvalue",
+		"statsdTestMetric1:user2|s|#mykey:myvalue",
+		"statsdTestMetric1:user1|s|#mykey:myvalue",
+		"statsdTestMetric2:1|s",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	metrics := p.GetMetrics()
+	values := map[string]int64{}
+	ilm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < ilm.Len(); i++ {
+		m := ilm.At(i).Metrics().At(0)
+		assert.Equal(t, pmetric.MetricDataTypeGauge, m.DataType())
+		values[m.Name()] = m.Gauge().DataPoints().At(0).IntVal()
+	}
+	assert.Equal(t, map[string]int64{"statsdTestMetric1": 2, "statsdTestMetric2": 1}, values)
+
+	// The sets are reset after every interval.
+	assert.Equal(t, 0, p.GetMetrics().ResourceMetrics().At(0).ScopeMetrics().Len())
+}
+
+func TestStatsDParser_AggregateWithHistogramObserver(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, []TimerHistogramMapping{
+		{StatsdType: "timer", ObserverType: "histogram"},
+		{StatsdType: "distribution", ObserverType: "histogram", Histogram: HistogramConfig{MaxSize: 10}},
+	})
+	for _, line := range []string{
+		"statsdTestMetric1:1|ms|#mykey:myvalue",
+		"statsdTestMetric1:2|ms|@0.5|#mykey:myvalue",
+		"statsdTestMetric1:0|ms|#mykey:myvalue",
+		"statsdTestMetric2:1|d",
+		"statsdTestMetric2:1000000|d",
+		"statsdTestMetric2:-4|d",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	metrics := p.GetMetrics()
+	points := map[string]pmetric.ExponentialHistogramDataPoint{}
+	ilm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < ilm.Len(); i++ {
+		m := ilm.At(i).Metrics().At(0)
+		assert.Equal(t, pmetric.MetricDataTypeExponentialHistogram, m.DataType())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, m.ExponentialHistogram().AggregationTemporality())
+		points[m.Name()] = m.ExponentialHistogram().DataPoints().At(0)
+	}
+	assert.Len(t, points, 2)
+
+	dp := points["statsdTestMetric1"]
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, float64(5), dp.Sum())
+	assert.Equal(t, float64(0), dp.Min())
+	assert.Equal(t, float64(2), dp.Max())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, "myvalue", dp.Attributes().AsRaw()["mykey"])
+	assert.Equal(t, time.Unix(711, 0), dp.Timestamp().AsTime().Local())
+
+	dp = points["statsdTestMetric2"]
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, float64(-4), dp.Min())
+	assert.Equal(t, float64(1000000), dp.Max())
+	assert.LessOrEqual(t, len(dp.Positive().MBucketCounts()), 10)
+	assert.Equal(t, []uint64{1}, dp.Negative().MBucketCounts())
+}
+
 func TestTimeNowFunc(t *testing.T) {
 	timeNow := timeNowFunc()
 	assert.NotNil(t, timeNow)
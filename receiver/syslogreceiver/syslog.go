@@ -17,7 +17,7 @@ package syslogreceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"gopkg.in/yaml.v2"
+	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -49,9 +49,13 @@ func (f ReceiverType) CreateDefaultConfig() config.Receiver {
 	return &SysLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 		},
-		Input: adapter.InputConfig{},
+		InputConfig: func() syslog.Config {
+			c := syslog.NewConfig("syslog_input")
+			c.BaseConfig = syslogparser.NewConfig("syslog_parser").BaseConfig
+			return *c
+		}(),
 	}
 }
 
@@ -63,18 +67,18 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) adapter.BaseConfig {
 // SysLogConfig defines configuration for the syslog receiver
 type SysLogConfig struct {
 	adapter.BaseConfig `mapstructure:",squash"`
-	Input              adapter.InputConfig `mapstructure:",remain"`
+	InputConfig        syslog.Config `mapstructure:",squash"`
 }
 
-// DecodeInputConfig unmarshals the input operator
-func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
-	logConfig := cfg.(*SysLogConfig)
-	yamlBytes, _ := yaml.Marshal(logConfig.Input)
-	inputCfg := syslog.NewConfig("syslog_input")
-	inputCfg.BaseConfig = syslogparser.NewConfig("syslog_parser").BaseConfig
-
-	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
-		return nil, err
+// Unmarshal decodes the config, including its input and operators, through mapstructure
+func (cfg *SysLogConfig) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
 	}
-	return &operator.Config{Builder: inputCfg}, nil
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
+}
+
+// InputConfig returns the input operator config
+func (f ReceiverType) InputConfig(cfg config.Receiver) operator.Config {
+	return operator.Config{Builder: &cfg.(*SysLogConfig).InputConfig}
 }
@@ -26,15 +26,20 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/syslog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
 )
 
 func TestSyslogWithTcp(t *testing.T) {
-	testSyslog(t, testdataConfigYamlAsMap())
+	testSyslog(t, testdataConfigYaml())
 }
 
 func TestSyslogWithUdp(t *testing.T) {
@@ -51,7 +56,7 @@ func testSyslog(t *testing.T, cfg *SysLogConfig) {
 	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
 
 	var conn net.Conn
-	if cfg.Input["tcp"] != nil {
+	if cfg.InputConfig.TCP != nil {
 		conn, err = net.Dial("tcp", "0.0.0.0:29018")
 		require.NoError(t, err)
 	} else {
@@ -94,25 +99,27 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	assert.Equal(t, len(cfg.Receivers), 1)
-	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewComponentID(typeStr)])
+	assert.Equal(t, testdataConfigYaml(), cfg.Receivers[config.NewComponentID(typeStr)])
 }
 
-func testdataConfigYamlAsMap() *SysLogConfig {
+func testdataConfigYaml() *SysLogConfig {
 	return &SysLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 			Converter: adapter.ConverterConfig{
 				FlushInterval: 100 * time.Millisecond,
 				WorkerCount:   1,
 			},
 		},
-		Input: adapter.InputConfig{
-			"tcp": map[string]interface{}{
-				"listen_address": "0.0.0.0:29018",
-			},
-			"protocol": "rfc5424",
-		},
+		InputConfig: func() syslog.Config {
+			c := syslog.NewConfig("syslog_input")
+			c.TCP = &tcp.BaseConfig{
+				ListenAddress: "0.0.0.0:29018",
+			}
+			c.Protocol = "rfc5424"
+			return *c
+		}(),
 	}
 }
 
@@ -120,36 +127,43 @@ func testdataUDPConfig() *SysLogConfig {
 	return &SysLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 			Converter: adapter.ConverterConfig{
 				FlushInterval: 100 * time.Millisecond,
 				WorkerCount:   1,
 			},
 		},
-		Input: adapter.InputConfig{
-			"udp": map[string]interface{}{
-				"listen_address": "0.0.0.0:29018",
-			},
-			"protocol": "rfc5424",
-		},
+		InputConfig: func() syslog.Config {
+			c := syslog.NewConfig("syslog_input")
+			c.UDP = &udp.BaseConfig{
+				ListenAddress: "0.0.0.0:29018",
+			}
+			c.Protocol = "rfc5424"
+			return *c
+		}(),
 	}
 }
 
 func TestDecodeInputConfigFailure(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*SysLogConfig)
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]interface{}{
+		"tcp": map[string]interface{}{
+			"max_buffer_size": "0.1.0.1-",
+		},
+		"protocol": "rfc5424",
+	}))
+	require.Error(t, err, "config unmarshaling should fail if input config isn't valid")
+	require.Contains(t, err.Error(), "max_buffer_size")
+}
+
+func TestCreateWithInvalidInputConfig(t *testing.T) {
 	sink := new(consumertest.LogsSink)
 	factory := NewFactory()
-	badCfg := &SysLogConfig{
-		BaseConfig: adapter.BaseConfig{
-			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"tcp": map[string]interface{}{
-				"max_buffer_size": "0.1.0.1-",
-			},
-			"protocol": "rfc5424",
-		},
+	badCfg := factory.CreateDefaultConfig().(*SysLogConfig)
+	badCfg.InputConfig.TCP = &tcp.BaseConfig{
+		ListenAddress: "0.1.0.1-",
 	}
+	badCfg.InputConfig.Protocol = "rfc5424"
 	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), badCfg, sink)
 	require.Error(t, err, "receiver creation should fail if input config isn't valid")
 	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
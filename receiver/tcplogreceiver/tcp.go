@@ -17,7 +17,7 @@ package tcplogreceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"gopkg.in/yaml.v2"
+	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -48,9 +48,9 @@ func (f ReceiverType) CreateDefaultConfig() config.Receiver {
 	return &TCPLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 		},
-		Input: adapter.InputConfig{},
+		InputConfig: *tcp.NewConfig("tcp_input"),
 	}
 }
 
@@ -62,18 +62,18 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) adapter.BaseConfig {
 // TCPLogConfig defines configuration for the tcp receiver
 type TCPLogConfig struct {
 	adapter.BaseConfig `mapstructure:",squash"`
-	Input              adapter.InputConfig `mapstructure:",remain"`
+	InputConfig        tcp.Config `mapstructure:",squash"`
 }
 
-// DecodeInputConfig unmarshals the input operator
-func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
-	logConfig := cfg.(*TCPLogConfig)
-	yamlBytes, _ := yaml.Marshal(logConfig.Input)
-	inputCfg := tcp.NewConfig("tcp_input")
-
-	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
-		return nil, err
+// Unmarshal decodes the config, including its input and operators, through mapstructure
+func (cfg *TCPLogConfig) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
 	}
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
+}
 
-	return &operator.Config{Builder: inputCfg}, nil
+// InputConfig returns the input operator config
+func (f ReceiverType) InputConfig(cfg config.Receiver) operator.Config {
+	return operator.Config{Builder: &cfg.(*TCPLogConfig).InputConfig}
 }
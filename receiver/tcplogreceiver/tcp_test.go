@@ -26,14 +26,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 )
 
 func TestTcp(t *testing.T) {
-	testTCP(t, testdataConfigYamlAsMap())
+	testTCP(t, testdataConfigYaml())
 }
 
 func testTCP(t *testing.T, cfg *TCPLogConfig) {
@@ -82,35 +85,39 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	assert.Equal(t, len(cfg.Receivers), 1)
-	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewComponentID(typeStr)])
+	assert.Equal(t, testdataConfigYaml(), cfg.Receivers[config.NewComponentID(typeStr)])
 }
 
-func testdataConfigYamlAsMap() *TCPLogConfig {
+func testdataConfigYaml() *TCPLogConfig {
 	return &TCPLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 			Converter: adapter.ConverterConfig{
 				WorkerCount: 1,
 			},
 		},
-		Input: adapter.InputConfig{
-			"listen_address": "0.0.0.0:29018",
-		},
+		InputConfig: func() tcp.Config {
+			c := tcp.NewConfig("tcp_input")
+			c.ListenAddress = "0.0.0.0:29018"
+			return *c
+		}(),
 	}
 }
 
 func TestDecodeInputConfigFailure(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*TCPLogConfig)
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]interface{}{
+		"max_buffer_size": "0.1.0.1-",
+	}))
+	require.Error(t, err, "config unmarshaling should fail if input config isn't valid")
+	require.Contains(t, err.Error(), "max_buffer_size")
+}
+
+func TestCreateWithInvalidInputConfig(t *testing.T) {
 	factory := NewFactory()
-	badCfg := &TCPLogConfig{
-		BaseConfig: adapter.BaseConfig{
-			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"max_buffer_size": "0.1.0.1-",
-		},
-	}
+	badCfg := factory.CreateDefaultConfig().(*TCPLogConfig)
+	badCfg.InputConfig.ListenAddress = "0.1.0.1-"
 	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), badCfg, consumertest.NewNop())
 	require.Error(t, err, "receiver creation should fail if input config isn't valid")
 	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
@@ -17,7 +17,7 @@ package udplogreceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"gopkg.in/yaml.v2"
+	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -48,9 +48,9 @@ func (f ReceiverType) CreateDefaultConfig() config.Receiver {
 	return &UDPLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 		},
-		Input: adapter.InputConfig{},
+		InputConfig: *udp.NewConfig("udp_input"),
 	}
 }
 
@@ -62,18 +62,18 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) adapter.BaseConfig {
 // UDPLogConfig defines configuration for the udp receiver
 type UDPLogConfig struct {
 	adapter.BaseConfig `mapstructure:",squash"`
-	Input              adapter.InputConfig `mapstructure:",remain"`
+	InputConfig        udp.Config `mapstructure:",squash"`
 }
 
-// DecodeInputConfig unmarshals the input operator
-func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
-	logConfig := cfg.(*UDPLogConfig)
-	yamlBytes, _ := yaml.Marshal(logConfig.Input)
-	inputCfg := udp.NewConfig("udp_input")
-
-	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
-		return nil, err
+// Unmarshal decodes the config, including its input and operators, through mapstructure
+func (cfg *UDPLogConfig) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
 	}
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
+}
 
-	return &operator.Config{Builder: inputCfg}, nil
+// InputConfig returns the input operator config
+func (f ReceiverType) InputConfig(cfg config.Receiver) operator.Config {
+	return operator.Config{Builder: &cfg.(*UDPLogConfig).InputConfig}
 }
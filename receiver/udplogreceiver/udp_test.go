@@ -26,14 +26,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
 )
 
 func TestUdp(t *testing.T) {
-	testUDP(t, testdataConfigYamlAsMap())
+	testUDP(t, testdataConfigYaml())
 }
 
 func testUDP(t *testing.T, cfg *UDPLogConfig) {
@@ -86,34 +89,37 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	assert.Equal(t, len(cfg.Receivers), 1)
-	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewComponentID("udplog")])
+	assert.Equal(t, testdataConfigYaml(), cfg.Receivers[config.NewComponentID("udplog")])
 }
 
-func testdataConfigYamlAsMap() *UDPLogConfig {
+func testdataConfigYaml() *UDPLogConfig {
 	return &UDPLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID("udplog")),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"listen_address": "0.0.0.0:29018",
+			Operators:        []operator.Config{},
 		},
+		InputConfig: func() udp.Config {
+			c := udp.NewConfig("udp_input")
+			c.ListenAddress = "0.0.0.0:29018"
+			return *c
+		}(),
 	}
 }
 
 func TestDecodeInputConfigFailure(t *testing.T) {
-	sink := new(consumertest.LogsSink)
+	cfg := NewFactory().CreateDefaultConfig().(*UDPLogConfig)
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]interface{}{
+		"max_buffer_size": "0.1.0.1-",
+	}))
+	require.Error(t, err, "config unmarshaling should fail if input config isn't valid")
+	require.Contains(t, err.Error(), "max_buffer_size")
+}
+
+func TestCreateWithInvalidInputConfig(t *testing.T) {
 	factory := NewFactory()
-	badCfg := &UDPLogConfig{
-		BaseConfig: adapter.BaseConfig{
-			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID("udplog")),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"max_buffer_size": "0.1.0.1-",
-		},
-	}
-	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), badCfg, sink)
+	badCfg := factory.CreateDefaultConfig().(*UDPLogConfig)
+	badCfg.InputConfig.ListenAddress = "0.1.0.1-"
+	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), badCfg, consumertest.NewNop())
 	require.Error(t, err, "receiver creation should fail if input config isn't valid")
 	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
 }
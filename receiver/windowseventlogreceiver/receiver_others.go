@@ -22,9 +22,12 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
 const (
@@ -51,10 +54,10 @@ func (f ReceiverType) CreateDefaultConfig() config.Receiver {
 	return &WindowsLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
+			Operators:        []operator.Config{},
 			Converter:        adapter.ConverterConfig{},
 		},
-		Input: adapter.InputConfig{},
+		Input: map[string]interface{}{},
 	}
 }
 
@@ -63,13 +66,31 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) adapter.BaseConfig {
 	return cfg.(*WindowsLogConfig).BaseConfig
 }
 
-// DecodeInputConfig unmarshals the input operator
-func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
+// InputConfig returns an input operator config which fails to build, as the receiver is only supported on Windows
+func (f ReceiverType) InputConfig(cfg config.Receiver) operator.Config {
+	return operator.Config{Builder: &unsupportedInputConfig{
+		InputConfig: helper.NewInputConfig("", "windows_eventlog_input"),
+	}}
+}
+
+type unsupportedInputConfig struct {
+	helper.InputConfig
+}
+
+func (c *unsupportedInputConfig) Build(_ *zap.SugaredLogger) (operator.Operator, error) {
 	return nil, errors.New("the windows eventlog receiver is only supported on Windows")
 }
 
 // WindowsLogConfig defines configuration for the windowseventlog receiver
 type WindowsLogConfig struct {
 	adapter.BaseConfig `mapstructure:",squash"`
-	Input              adapter.InputConfig `mapstructure:",remain"`
+	Input              map[string]interface{} `mapstructure:",remain"`
+}
+
+// Unmarshal decodes the config, including its operators, through mapstructure
+func (cfg *WindowsLogConfig) Unmarshal(component *confmap.Conf) error {
+	if component == nil {
+		return nil
+	}
+	return operator.UnmarshalMapstructure(component.ToStringMap(), cfg)
 }
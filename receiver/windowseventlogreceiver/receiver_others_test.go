@@ -18,10 +18,14 @@
 package windowseventlogreceiver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
 )
 
 func TestDefaultConfigFailure(t *testing.T) {
@@ -31,7 +35,11 @@ func TestDefaultConfigFailure(t *testing.T) {
 	require.NoError(t, configtest.CheckConfigStruct(cfg))
 
 	r := ReceiverType{}
-	ops, err := r.DecodeInputConfig(cfg)
-	require.Nil(t, ops, "failed to create default config")
+	op, err := r.InputConfig(cfg).Build(zap.NewNop().Sugar())
+	require.Nil(t, op, "failed to create default config")
+	require.ErrorContains(t, err, "the windows eventlog receiver is only supported on Windows")
+
+	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.Nil(t, receiver)
 	require.ErrorContains(t, err, "the windows eventlog receiver is only supported on Windows")
 }
@@ -33,6 +33,8 @@ import (
 	"golang.org/x/sys/windows/svc/eventlog"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -60,13 +62,8 @@ func TestLoadConfig(t *testing.T) {
 func TestCreateWithInvalidInputConfig(t *testing.T) {
 	t.Parallel()
 
-	cfg := &WindowsLogConfig{
-		BaseConfig: adapter.BaseConfig{},
-		Input: adapter.InputConfig{
-			"start_at": "end",
-		},
-	}
-	cfg.Input["include"] = "not an array"
+	cfg := createTestConfig()
+	cfg.InputConfig.StartAt = "middle"
 
 	_, err := NewFactory().CreateLogsReceiver(
 		context.Background(),
@@ -137,11 +134,13 @@ func createTestConfig() *WindowsLogConfig {
 	return &WindowsLogConfig{
 		BaseConfig: adapter.BaseConfig{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-			Operators:        adapter.OperatorConfigs{},
-		},
-		Input: adapter.InputConfig{
-			"channel":  "application",
-			"start_at": "end",
+			Operators:        []operator.Config{},
 		},
+		InputConfig: func() windows.Config {
+			c := windows.NewConfig()
+			c.Channel = "application"
+			c.StartAt = "end"
+			return *c
+		}(),
 	}
 }